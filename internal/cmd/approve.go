@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/mvwi/wt/internal/github"
+	"github.com/mvwi/wt/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var approveCmd = &cobra.Command{
+	Use:     "approve [name|#PR] [-- message]",
+	GroupID: groupWorkflow,
+	Short:   "Approve a worktree's PR",
+	Long: `Approve the pull request for a worktree.
+
+Without a name, approves the current worktree's PR — for a detached
+worktree from wt pr --detached, the PR it was checked out from. A PR
+number works in place of a name. An optional review message can follow
+"--".
+
+Requires the GitHub CLI (gh) to be installed.`,
+	Example: `  wt approve                        Approve the current branch's PR
+  wt approve sidebar                Approve sidebar's PR
+  wt approve sidebar -- "Nice!"     Approve with a message
+  wt approve 123                    Approve PR #123`,
+	ValidArgsFunction: completeWorktreeNames,
+	RunE:              runApprove,
+}
+
+func init() {
+	rootCmd.AddCommand(approveCmd)
+}
+
+func runApprove(cmd *cobra.Command, args []string) error {
+	return runReview(cmd, args, github.ReviewApprove)
+}
+
+// runReview implements approve and request-changes: resolve the target PR,
+// refuse to review your own PR, gather the body, and submit.
+func runReview(cmd *cobra.Command, args []string, action string) error {
+	if !github.IsAvailable() {
+		return fmt.Errorf("gh CLI is required for this command (brew install gh)")
+	}
+	if err := github.CheckAuth(); err != nil {
+		return err
+	}
+
+	name, body, err := splitNameAndMessage(cmd, args, "")
+	if err != nil {
+		return err
+	}
+
+	ctx, err := newContext()
+	if err != nil {
+		return err
+	}
+
+	pr, _, err := resolveTargetPR(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	// GitHub rejects reviews on your own PR with an opaque GraphQL error.
+	detail, detailErr := github.GetPRDetail(pr.Number)
+	me, meErr := github.CurrentUser(ctx.remoteHost())
+	if detailErr == nil && meErr == nil && detail != nil && detail.Author.Login == me {
+		return fmt.Errorf("PR #%d is your own — GitHub doesn't allow reviewing your own pull request", pr.Number)
+	}
+
+	if action == github.ReviewRequestChanges && body == "" {
+		body, err = readMessage("review")
+		if err != nil {
+			return err
+		}
+		if body == "" {
+			return fmt.Errorf("requesting changes needs a reason — nothing submitted")
+		}
+	}
+
+	if err := github.ReviewPR(pr.Number, action, body); err != nil {
+		return fmt.Errorf("failed to review PR #%d: %w", pr.Number, err)
+	}
+
+	if action == github.ReviewApprove {
+		ui.Success("Approved PR #%d", pr.Number)
+	} else {
+		ui.Success("Requested changes on PR #%d", pr.Number)
+	}
+	return nil
+}
@@ -0,0 +1,143 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/mvwi/wt/internal/git"
+	"github.com/mvwi/wt/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var archiveCmd = &cobra.Command{
+	Use:     "archive [name]",
+	GroupID: groupManage,
+	Short:   "Remove a worktree but keep its branch",
+	Long: `Archive a worktree: remove the directory but keep the local branch,
+so the work is shelved rather than deleted. Archived branches are listed
+at the bottom of wt list; bring one back with wt restore <branch>.
+
+Without arguments, archives the current worktree.
+With --tag, also tags the branch tip as archive/<branch>.
+
+Uncommitted changes are lost (you're asked first); commits are kept.`,
+	Example: `  wt archive               Archive current worktree
+  wt archive sidebar       Archive the "sidebar" worktree
+  wt archive sidebar --tag Archive and tag as archive/<branch>`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeWorktreeNames,
+	RunE:              runArchive,
+}
+
+var archiveTagFlag bool
+
+func init() {
+	archiveCmd.Flags().BoolVar(&archiveTagFlag, "tag", false, "tag the branch tip as archive/<branch>")
+	rootCmd.AddCommand(archiveCmd)
+}
+
+func runArchive(cmd *cobra.Command, args []string) error {
+	ctx, err := newContext()
+	if err != nil {
+		return err
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("cannot determine current directory: %w", err)
+	}
+	worktrees, err := git.ListWorktrees()
+	if err != nil {
+		return err
+	}
+
+	var targetPath string
+	if len(args) > 0 {
+		path, fuzzy, resolveErr := resolveWorktree(ctx, worktrees, args[0])
+		if resolveErr != nil {
+			return resolveErr
+		}
+		if path == "" {
+			return fmt.Errorf("worktree not found: %s\n   Run wt list to see available worktrees", args[0])
+		}
+		if fuzzy && !ui.Confirm(fmt.Sprintf("Archive %s?", ctx.shortName(path)), false) {
+			fmt.Println("Cancelled")
+			return nil
+		}
+		targetPath = path
+	} else {
+		if ctx.inMainWorktree(cwd) {
+			return fmt.Errorf("cannot archive the main repository worktree\n   Specify a worktree name: wt archive <name>")
+		}
+		// From a subdirectory, act on the worktree it's in.
+		if targetPath, err = git.TopLevel(); err != nil {
+			return err
+		}
+	}
+
+	if targetPath == ctx.MainWorktree {
+		return fmt.Errorf("cannot archive the main repository worktree")
+	}
+	if locked, reason := git.IsLocked(targetPath); locked {
+		if reason != "" {
+			reason = " (" + reason + ")"
+		}
+		return fmt.Errorf("worktree is locked%s: %s\n   Run wt unlock %s first", reason, ctx.shortName(targetPath), ctx.shortName(targetPath))
+	}
+
+	branch, _ := git.CurrentBranchIn(targetPath)
+	if branch == "" || branch == "HEAD" {
+		return fmt.Errorf("worktree is on a detached HEAD %s there's no branch to keep\n   Use wt close to remove it", ui.Dash)
+	}
+
+	// Safety: uncommitted changes don't survive removal, only commits do
+	if git.HasChangesIn(targetPath) {
+		ui.Warn("Worktree has uncommitted changes:")
+		short, _ := git.RunIn(targetPath, "status", "--short")
+		fmt.Println(short)
+		fmt.Println()
+		if !ui.Confirm("Discard changes and archive?", false) {
+			fmt.Println("Cancelled")
+			return nil
+		}
+	}
+
+	meta, _ := git.LoadWorktreeMeta(targetPath)
+	needsCd := cwd == targetPath || isSubpath(cwd, targetPath)
+
+	fmt.Printf("Archiving worktree: %s\n", targetPath)
+	fmt.Printf("Branch: %s (kept)\n", branch)
+	fmt.Println()
+
+	if err := git.RemoveWorktree(targetPath); err != nil {
+		return fmt.Errorf("failed to remove worktree: %w", err)
+	}
+	_ = git.DeleteWorktreeMeta(targetPath)
+
+	if needsCd {
+		dest := ctx.leaveTo(targetPath)
+		// Our cwd went with the worktree; git can't run from there.
+		_ = os.Chdir(dest)
+		ui.PrintCdHint(dest)
+		fmt.Printf("Moved to: %s\n", dest)
+	}
+
+	archived := git.ArchivedBranch{Branch: branch, Path: targetPath, Meta: meta}
+	if archiveTagFlag {
+		tag := "archive/" + branch
+		if err := git.CreateTag(tag, branch); err != nil {
+			ui.Warn("Could not create tag %s: %v", tag, err)
+		} else {
+			archived.Tag = tag
+			ui.Success("Tagged %s", tag)
+		}
+	}
+	if err := git.SaveArchivedBranch(archived); err != nil {
+		ui.Warn("Could not record archived branch: %v", err)
+	}
+
+	fmt.Println()
+	ui.Success("Archived %s", branch)
+	ui.PrintCTA("wt restore " + branch)
+	return nil
+}
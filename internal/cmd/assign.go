@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mvwi/wt/internal/github"
+	"github.com/mvwi/wt/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var assignCmd = &cobra.Command{
+	Use:     "assign [name]",
+	GroupID: groupWorkflow,
+	Short:   "Request reviewers or add assignees on a worktree's PR",
+	Long: `Request reviews and add assignees on a worktree's pull request.
+
+Without a name, uses the current branch's PR. Both flags take a
+comma-separated list of GitHub logins (or org/team for reviewers) and can
+be combined. GitHub doesn't allow requesting your own review, so that's
+refused up front.
+
+Requires the GitHub CLI (gh) to be installed.`,
+	Example: `  wt assign --reviewer alice,bob        Request reviews on the current PR
+  wt assign sidebar --assignee @me      Assign yourself to sidebar's PR
+  wt assign --reviewer acme/core --assignee carol`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeWorktreeNames,
+	RunE:              runAssign,
+}
+
+var (
+	assignReviewers []string
+	assignAssignees []string
+)
+
+func init() {
+	assignCmd.Flags().StringSliceVar(&assignReviewers, "reviewer", nil, "logins or org/team to request reviews from (comma-separated)")
+	assignCmd.Flags().StringSliceVar(&assignAssignees, "assignee", nil, "logins to assign, @me for yourself (comma-separated)")
+	assignCmd.MarkFlagsOneRequired("reviewer", "assignee")
+	rootCmd.AddCommand(assignCmd)
+}
+
+func runAssign(cmd *cobra.Command, args []string) error {
+	if !github.IsAvailable() {
+		return fmt.Errorf("gh CLI is required for this command (brew install gh)")
+	}
+	if err := github.CheckAuth(); err != nil {
+		return err
+	}
+
+	ctx, err := newContext()
+	if err != nil {
+		return err
+	}
+
+	if len(assignReviewers) > 0 {
+		me, _ := github.CurrentUser(ctx.remoteHost())
+		if containsLogin(assignReviewers, me) {
+			return fmt.Errorf("you can't request a review from yourself\n   Use --assignee @me to assign yourself instead")
+		}
+	}
+
+	var name string
+	if len(args) > 0 {
+		name = args[0]
+	}
+	pr, _, err := resolveTargetPR(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	if err := github.AssignPR(pr.Number, assignReviewers, assignAssignees); err != nil {
+		return fmt.Errorf("failed to update PR #%d: %w", pr.Number, err)
+	}
+	if len(assignReviewers) > 0 {
+		ui.Success("Requested review from %s on PR #%d", strings.Join(assignReviewers, ", "), pr.Number)
+	}
+	if len(assignAssignees) > 0 {
+		ui.Success("Assigned %s to PR #%d", strings.Join(assignAssignees, ", "), pr.Number)
+	}
+	return nil
+}
+
+// containsLogin reports whether logins names me, either as "@me" or by
+// login (case-insensitively, like GitHub). An empty me only matches "@me".
+func containsLogin(logins []string, me string) bool {
+	for _, l := range logins {
+		l = strings.TrimPrefix(l, "@")
+		if l == "me" || (me != "" && strings.EqualFold(l, me)) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,22 @@
+package cmd
+
+import "testing"
+
+func TestContainsLogin(t *testing.T) {
+	tests := []struct {
+		logins []string
+		me     string
+		want   bool
+	}{
+		{[]string{"alice", "bob"}, "carol", false},
+		{[]string{"alice", "Carol"}, "carol", true},
+		{[]string{"@carol"}, "carol", true},
+		{[]string{"@me"}, "", true},
+		{[]string{"acme/core"}, "", false},
+	}
+	for _, tt := range tests {
+		if got := containsLogin(tt.logins, tt.me); got != tt.want {
+			t.Errorf("containsLogin(%v, %q) = %v, want %v", tt.logins, tt.me, got, tt.want)
+		}
+	}
+}
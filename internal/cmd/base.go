@@ -0,0 +1,153 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/mvwi/wt/internal/git"
+	"github.com/mvwi/wt/internal/github"
+	"github.com/mvwi/wt/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var baseCmd = &cobra.Command{
+	Use:     "base <branch>",
+	GroupID: groupWorkflow,
+	Short:   "Change the base branch of the current PR",
+	Long: `Change the branch the current branch's PR merges into.
+
+The new base must exist on the remote. With --rebase, the local branch is
+also moved onto the new base: only its own commits are replayed (git rebase
+--onto), so nothing from the old base comes along. Push afterwards to
+update the PR.
+
+Requires the GitHub CLI (gh) to be installed.`,
+	Example: `  wt base release/2.1             Retarget the PR at release/2.1
+  wt base release/2.1 --rebase    Retarget and move the local commits too`,
+	Args: cobra.ExactArgs(1),
+	RunE: runBase,
+}
+
+var baseRebase bool
+
+func init() {
+	baseCmd.Flags().BoolVar(&baseRebase, "rebase", false, "also rebase the local branch onto the new base")
+	rootCmd.AddCommand(baseCmd)
+}
+
+func runBase(cmd *cobra.Command, args []string) error {
+	if !github.IsAvailable() {
+		return fmt.Errorf("gh CLI is required for this command (brew install gh)")
+	}
+	if err := github.CheckAuth(); err != nil {
+		return err
+	}
+
+	ctx, err := newContext()
+	if err != nil {
+		return err
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("cannot determine current directory: %w", err)
+	}
+	branch, err := git.CurrentBranch()
+	if err != nil {
+		return fmt.Errorf("not in a git repository or detached HEAD\n   Run this from inside a worktree")
+	}
+	if ctx.inMainWorktree(cwd) || ctx.isBaseBranch(branch) {
+		return fmt.Errorf("cannot change the base of %s\n   Switch to a feature worktree first", branch)
+	}
+
+	// The PR's base is a remote branch, so make sure we know about it
+	newBase := strings.TrimPrefix(args[0], ctx.Config.Remote+"/")
+	fetchErr := fetchWithSpinner(fmt.Sprintf("Fetching %s", newBase), ctx.Config.Remote, newBase)
+	if _, _, err := git.ResolveBranch(newBase, ctx.Config.Remote); err != nil {
+		// Offline, a real branch looks missing; say why.
+		if fetchErr != nil {
+			return fmt.Errorf("failed to fetch %s: %w", newBase, fetchErr)
+		}
+		return err
+	}
+	if fetchErr != nil {
+		ui.Warn("Fetch failed: %v", fetchErr)
+	}
+	newRef := ctx.Config.Remote + "/" + newBase
+	if !git.RemoteBranchExists(newRef) {
+		return fmt.Errorf("%s isn't on %s\n   Push it first: git push -u %s %s", newBase, ctx.Config.Remote, ctx.Config.Remote, newBase)
+	}
+
+	pr, err := github.GetPRDetails(branch)
+	if err != nil {
+		return fmt.Errorf("failed to check for PR: %w", err)
+	}
+	if pr == nil {
+		return fmt.Errorf("no open PR found for branch: %s\n   Run wt submit to push and create one", branch)
+	}
+
+	// With --rebase, find where the branch's own commits start before
+	// touching the PR, so a missing old base fails cleanly.
+	oldBase := pr.BaseRefName
+	var upstream string
+	if baseRebase {
+		if upstream, err = oldBaseUpstream(ctx, oldBase, newRef); err != nil {
+			return err
+		}
+	}
+
+	if oldBase == newBase {
+		ui.Success("PR #%d already targets %s", pr.Number, newBase)
+	} else {
+		if err := github.EditPRBase(pr.Number, newBase); err != nil {
+			return fmt.Errorf("failed to change the base of PR #%d: %w", pr.Number, err)
+		}
+		ui.Success("PR #%d now targets %s (was %s)", pr.Number, newBase, oldBase)
+	}
+
+	if top, err := git.TopLevel(); err == nil {
+		if meta, err := git.LoadWorktreeMeta(top); err == nil {
+			meta.Base = newRef
+			_ = git.SaveWorktreeMeta(top, meta)
+		}
+	}
+
+	if !baseRebase {
+		if oldBase != newBase {
+			fmt.Printf("  %s\n", ui.Dim("The local branch still sits on "+oldBase+"; wt base "+newBase+" --rebase moves it"))
+		}
+		return nil
+	}
+
+	fmt.Println()
+	if err := rebaseFeatureBranch(ctx, branch, newRef, upstream); err != nil {
+		return err
+	}
+	if inProgress, _ := git.IsRebaseInProgress(); !inProgress {
+		fmt.Printf("  Update the PR: %s\n", ui.Cyan("git push --force-with-lease"))
+		ui.PrintCTA("git push --force-with-lease")
+	}
+	return nil
+}
+
+// oldBaseUpstream returns what the branch's own commits sit on top of, for
+// rebase --onto: the old base's remote-tracking ref, freshly fetched. If the
+// old base is gone from the remote, the fork point from a local branch of
+// that name stands in for it.
+func oldBaseUpstream(ctx *cmdContext, oldBase, newRef string) (string, error) {
+	ref := ctx.Config.Remote + "/" + oldBase
+	if err := git.FetchBranch(ctx.Config.Remote, oldBase); err != nil {
+		tracer.Printf("fetch %s: %v", ref, err)
+	}
+	if git.RemoteBranchExists(ref) {
+		return ref, nil
+	}
+	if git.BranchExists(oldBase) {
+		if sha, err := git.ForkPoint(oldBase, "HEAD"); err == nil && sha != "" {
+			ui.Warn("%s not found %s moving the commits after its fork point (%s)", ref, ui.Dash, shortSHA(sha))
+			return sha, nil
+		}
+	}
+	return "", fmt.Errorf("old base %s not found, so there's no telling which commits are this branch's\n   Fetch it and retry, or rebase by hand: git rebase --onto %s <last-base-commit>", ref, newRef)
+}
@@ -0,0 +1,169 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/mvwi/wt/internal/git"
+	"github.com/mvwi/wt/internal/github"
+	"github.com/mvwi/wt/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var browseCmd = &cobra.Command{
+	Use:     "browse [path[:line]]",
+	GroupID: groupWorkflow,
+	Short:   "Open the branch or a file on GitHub",
+	Long: `Open the current branch on GitHub in your browser.
+
+With a path, opens that file or directory on the current branch. Append
+:<line> or :<start>-<end> to jump to lines. Paths are relative to the
+current directory.
+
+Uses gh browse when the GitHub CLI is installed and logged in; otherwise
+builds the URL from the remote.`,
+	Example: `  wt browse                      Open the current branch's tree
+  wt browse internal/cmd          Open a directory
+  wt browse main.go:42            Open a file at line 42
+  wt browse main.go:42-60         Open a file with lines 42-60 highlighted`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runBrowse,
+}
+
+func init() {
+	rootCmd.AddCommand(browseCmd)
+}
+
+func runBrowse(cmd *cobra.Command, args []string) error {
+	ctx, err := newContext()
+	if err != nil {
+		return err
+	}
+
+	var target string
+	if len(args) > 0 {
+		target = args[0]
+	}
+
+	// Detached HEAD (e.g. a wt pr --detached review worktree): browse the commit
+	ref, refErr := git.CurrentBranch()
+	detached := refErr != nil || ref == "HEAD"
+	if detached {
+		if ref, err = git.Run("rev-parse", "HEAD"); err != nil {
+			return fmt.Errorf("could not determine the current branch or commit")
+		}
+	}
+
+	if github.IsAvailable() && github.CheckAuth() == nil {
+		ghArgs := []string{"browse"}
+		if target != "" {
+			ghArgs = append(ghArgs, target)
+		}
+		if detached {
+			ghArgs = append(ghArgs, "--commit="+ref)
+		} else {
+			ghArgs = append(ghArgs, "--branch", ref)
+		}
+		ghCmd := exec.Command("gh", ghArgs...)
+		ghCmd.Stdin = os.Stdin
+		ghCmd.Stdout = os.Stdout
+		ghCmd.Stderr = os.Stderr
+		return ghCmd.Run()
+	}
+
+	// No usable gh: build the URL from the remote
+	remoteURL, err := git.RemoteURL(ctx.Config.Remote)
+	if err != nil {
+		return fmt.Errorf("no remote %q to browse", ctx.Config.Remote)
+	}
+	host, slug := git.RemoteHost(remoteURL), git.RemoteSlug(remoteURL)
+	if host == "" || slug == "" {
+		return fmt.Errorf("remote %q (%s) isn't a GitHub URL", ctx.Config.Remote, remoteURL)
+	}
+
+	var relPath, lines string
+	isDir := true
+	if target != "" {
+		path, l := splitPathLine(target)
+		lines = l
+		abs, err := filepath.Abs(path)
+		if err != nil {
+			return err
+		}
+		fi, err := os.Stat(abs)
+		if err != nil {
+			return fmt.Errorf("no such file or directory: %s", path)
+		}
+		isDir = fi.IsDir()
+		top, err := git.TopLevel()
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(top, abs)
+		if err != nil || strings.HasPrefix(rel, "..") {
+			return fmt.Errorf("%s is outside the repository", path)
+		}
+		if rel != "." {
+			relPath = filepath.ToSlash(rel)
+		}
+	}
+
+	u := browseURL(host, slug, ref, relPath, isDir, lines)
+	fmt.Printf("Opening %s\n", u)
+	return openInBrowser(u)
+}
+
+// splitPathLine splits "file.go:42" or "file.go:42-60" into the path and
+// line spec. A suffix that isn't a line number stays part of the path.
+func splitPathLine(s string) (path, lines string) {
+	i := strings.LastIndex(s, ":")
+	if i < 0 {
+		return s, ""
+	}
+	spec := s[i+1:]
+	start, end, _ := strings.Cut(spec, "-")
+	if !isAllDigits(start) || (end != "" && !isAllDigits(end)) {
+		return s, ""
+	}
+	return s[:i], spec
+}
+
+// browseURL builds a GitHub web URL for ref, optionally narrowed to a
+// repo-relative path and a line spec ("42" or "42-60").
+func browseURL(host, slug, ref, relPath string, isDir bool, lines string) string {
+	u := "https://" + host + "/" + slug
+	kind := "tree"
+	if !isDir {
+		kind = "blob"
+	}
+	u += "/" + kind + "/" + ref
+	if relPath != "" {
+		u += "/" + relPath
+	}
+	if lines != "" && !isDir {
+		start, end, _ := strings.Cut(lines, "-")
+		u += "#L" + start
+		if end != "" {
+			u += "-L" + end
+		}
+	}
+	return u
+}
+
+// openInBrowser opens url with the platform's default handler.
+func openInBrowser(url string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", url)
+	case "linux":
+		cmd = exec.Command("xdg-open", url)
+	default:
+		return fmt.Errorf("cannot open browser on %s %s visit %s", runtime.GOOS, ui.Dash, url)
+	}
+	return cmd.Run()
+}
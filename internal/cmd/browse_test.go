@@ -0,0 +1,43 @@
+package cmd
+
+import "testing"
+
+func TestSplitPathLine(t *testing.T) {
+	tests := []struct {
+		in, path, lines string
+	}{
+		{"main.go", "main.go", ""},
+		{"main.go:42", "main.go", "42"},
+		{"main.go:42-60", "main.go", "42-60"},
+		{"odd:name.go", "odd:name.go", ""},
+		{"main.go:", "main.go:", ""},
+	}
+	for _, tt := range tests {
+		path, lines := splitPathLine(tt.in)
+		if path != tt.path || lines != tt.lines {
+			t.Errorf("splitPathLine(%q) = (%q, %q), want (%q, %q)", tt.in, path, lines, tt.path, tt.lines)
+		}
+	}
+}
+
+func TestBrowseURL(t *testing.T) {
+	tests := []struct {
+		name    string
+		relPath string
+		isDir   bool
+		lines   string
+		want    string
+	}{
+		{"branch root", "", true, "", "https://github.com/mvwi/wt/tree/michael/x"},
+		{"directory", "internal/cmd", true, "", "https://github.com/mvwi/wt/tree/michael/x/internal/cmd"},
+		{"file at line", "main.go", false, "42", "https://github.com/mvwi/wt/blob/michael/x/main.go#L42"},
+		{"file line range", "main.go", false, "42-60", "https://github.com/mvwi/wt/blob/michael/x/main.go#L42-L60"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := browseURL("github.com", "mvwi/wt", "michael/x", tt.relPath, tt.isDir, tt.lines); got != tt.want {
+				t.Errorf("browseURL() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/mvwi/wt/internal/github"
+	"github.com/mvwi/wt/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var checksCmd = &cobra.Command{
+	Use:     "checks [name]",
+	GroupID: groupWorkflow,
+	Short:   "Show CI checks for a worktree's PR",
+	Long: `Show the CI checks for a worktree's pull request, grouped by status,
+with a link to each check's details.
+
+Without a name, shows checks for the current branch's PR.
+
+With --rerun-failed, re-runs the failed jobs of every GitHub Actions run
+that has a failing check. Checks from other CI providers can't be rerun
+from here; their links are printed instead.
+
+Requires the GitHub CLI (gh) to be installed.`,
+	Example: `  wt checks                     Show checks for the current branch's PR
+  wt checks sidebar             Show checks for "sidebar" worktree's PR
+  wt checks --rerun-failed      Rerun failed GitHub Actions jobs`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeWorktreeNames,
+	RunE:              runChecks,
+}
+
+var checksRerunFailed bool
+
+func init() {
+	checksCmd.Flags().BoolVar(&checksRerunFailed, "rerun-failed", false, "rerun failed GitHub Actions jobs")
+	rootCmd.AddCommand(checksCmd)
+}
+
+func runChecks(cmd *cobra.Command, args []string) error {
+	if !github.IsAvailable() {
+		return fmt.Errorf("gh CLI is required for this command (brew install gh)")
+	}
+	if err := github.CheckAuth(); err != nil {
+		return err
+	}
+
+	ctx, err := newContext()
+	if err != nil {
+		return err
+	}
+
+	var name string
+	if len(args) > 0 {
+		name = args[0]
+	}
+	pr, _, err := resolveTargetPR(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	ws, err := github.GetWatchStatus(strconv.Itoa(pr.Number))
+	if err != nil {
+		return fmt.Errorf("failed to fetch checks for PR #%d: %w", pr.Number, err)
+	}
+
+	printWatchHeader(ws)
+	pass, fail, pending := ws.ChecksByStatus()
+	if len(pass)+len(fail)+len(pending) == 0 {
+		fmt.Println()
+		ui.Info("No CI checks reported")
+		return nil
+	}
+
+	printCheckGroup("Failed", fail, ui.Red(ui.Fail))
+	printCheckGroup("Pending", pending, ui.Yellow(ui.Pending))
+	printCheckGroup("Passed", pass, ui.Green(ui.Pass))
+	fmt.Println()
+
+	if !checksRerunFailed {
+		return nil
+	}
+	if len(fail) == 0 {
+		ui.Info("No failed checks to rerun")
+		return nil
+	}
+	return rerunFailedChecks(fail)
+}
+
+// printCheckGroup prints one status bucket with a details link per check.
+func printCheckGroup(title string, checks []github.StatusCheckRun, glyph string) {
+	if len(checks) == 0 {
+		return
+	}
+	fmt.Println()
+	fmt.Printf("  %s  %s\n", ui.Bold(title), ui.Dim(strconv.Itoa(len(checks))))
+	for _, c := range checks {
+		fmt.Printf("    %s  %-*s", glyph, watchCheckColWidth, ui.Truncate(c.Name, watchCheckColWidth))
+		if url := c.URL(); url != "" {
+			fmt.Printf("  %s", ui.Dim(url))
+		}
+		fmt.Println()
+	}
+}
+
+// rerunFailedChecks re-runs each distinct Actions run behind a failed check.
+// Several checks (jobs) usually share one run, so runs are deduplicated.
+func rerunFailedChecks(fail []github.StatusCheckRun) error {
+	seen := make(map[string]bool)
+	var skipped []string
+	var failed int
+	for _, c := range fail {
+		runID := c.RunID()
+		if runID == "" {
+			skipped = append(skipped, c.Name)
+			continue
+		}
+		if seen[runID] {
+			continue
+		}
+		seen[runID] = true
+
+		if err := github.RerunFailedJobs(runID); err != nil {
+			ui.Warn("Could not rerun run %s: %v", runID, err)
+			failed++
+			continue
+		}
+		ui.Success("Rerunning failed jobs in run %s", runID)
+	}
+
+	for _, name := range skipped {
+		ui.Warn("%s isn't a GitHub Actions check %s rerun it from its details page", name, ui.Dash)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d run(s) could not be rerun", failed)
+	}
+	return nil
+}
@@ -3,6 +3,7 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 
 	"github.com/mvwi/wt/internal/git"
 	"github.com/mvwi/wt/internal/github"
@@ -19,20 +20,44 @@ var closeCmd = &cobra.Command{
 
 Without arguments, closes the current worktree.
 With a name, closes the specified worktree.
+With --keep-branch, only the directory is removed; the branch stays for
+wt restore later. With --remote, the remote branch is deleted too.
+
+With --all-merged, closes every worktree whose PR is merged, without
+prompts. Like wt prune, it skips the main and current worktrees, base
+branches, locked worktrees (wt lock), and worktrees with uncommitted
+changes or commits that were never pushed.
 
 Safety checks:
   - Warns if worktree has uncommitted changes
+  - Warns if the branch has commits that were never pushed
   - Warns if PR is still open
-  - Cannot close the main repository worktree`,
+  - Cannot close the main repository worktree
+  - Cannot close a locked worktree (wt unlock it first)`,
 	Example: `  wt close                Close current worktree
   wt close sidebar         Close the "sidebar" worktree
-  wt close sidebar --yes   Close without confirmation prompts`,
+  wt close sidebar --yes   Close without confirmation prompts
+  wt close --keep-branch   Remove the directory, keep the branch
+  wt close --remote        Also delete the remote branch
+  wt close --all-merged    Close every worktree whose PR is merged`,
 	Args:              cobra.MaximumNArgs(1),
 	ValidArgsFunction: completeWorktreeNames,
 	RunE:              runClose,
 }
 
+var (
+	closeKeepBranch bool
+	closeRemote     bool
+	closeAllMerged  bool
+)
+
 func init() {
+	closeCmd.Flags().BoolVar(&closeKeepBranch, "keep-branch", false, "remove the worktree but keep the local branch")
+	closeCmd.Flags().BoolVar(&closeRemote, "remote", false, "also delete the remote branch")
+	closeCmd.Flags().BoolVar(&closeAllMerged, "all-merged", false, "close every worktree whose PR is merged, without prompts")
+	closeCmd.MarkFlagsMutuallyExclusive("keep-branch", "remote")
+	closeCmd.MarkFlagsMutuallyExclusive("all-merged", "keep-branch")
+	closeCmd.MarkFlagsMutuallyExclusive("all-merged", "remote")
 	rootCmd.AddCommand(closeCmd)
 }
 
@@ -42,6 +67,13 @@ func runClose(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	if closeAllMerged {
+		if len(args) > 0 {
+			return fmt.Errorf("--all-merged doesn't take a worktree name")
+		}
+		return runCloseAllMerged(ctx)
+	}
+
 	cwd, err := os.Getwd()
 	if err != nil {
 		return fmt.Errorf("cannot determine current directory: %w", err)
@@ -74,7 +106,7 @@ func runClose(cmd *cobra.Command, args []string) error {
 		}
 	} else {
 		// Close current worktree
-		if cwd == ctx.MainWorktree || isSubpath(cwd, ctx.MainWorktree) {
+		if ctx.inMainWorktree(cwd) {
 			return fmt.Errorf("cannot close the main repository worktree\n   Specify a worktree name: wt close <name>")
 		}
 		targetPath = cwd
@@ -83,8 +115,20 @@ func runClose(cmd *cobra.Command, args []string) error {
 	if targetPath == ctx.MainWorktree {
 		return fmt.Errorf("cannot close the main repository worktree")
 	}
+	if locked, reason := git.IsLocked(targetPath); locked {
+		if reason != "" {
+			reason = " (" + reason + ")"
+		}
+		return fmt.Errorf("worktree is locked%s: %s\n   Run wt unlock %s first", reason, ctx.shortName(targetPath), ctx.shortName(targetPath))
+	}
 
 	targetBranch, _ = git.CurrentBranchIn(targetPath)
+	// Detached HEAD (e.g. a `wt pr --detached` review worktree): no branch
+	// to check for a PR or delete.
+	detached := targetBranch == "HEAD"
+	if detached {
+		targetBranch = ""
+	}
 
 	// Safety: uncommitted changes
 	if git.HasChangesIn(targetPath) {
@@ -98,9 +142,17 @@ func runClose(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	// Safety: unpushed commits
-	if unpushed := git.UnpushedCountIn(targetPath); unpushed > 0 {
-		ui.Warn("Branch has %d unpushed commit(s)", unpushed)
+	// Safety: unpushed commits. The branch is force-deleted, so commits
+	// that aren't on any remote are gone for good. A branch with no
+	// upstream counts everything no remote branch has. Nothing is lost when
+	// the branch is kept.
+	unpushed, hasUpstream := git.UnpushedCountIn(targetPath)
+	if !hasUpstream {
+		unpushed = git.UnpublishedCountIn(targetPath)
+	}
+	if unpushed > 0 && !closeKeepBranch {
+		ui.Warn("%d unpushed commit(s) will be lost", unpushed)
+		fmt.Printf("  %s\n", ui.Dim("wt close --keep-branch removes the worktree but keeps the branch"))
 		if !ui.Confirm("Discard commits and close?", false) {
 			fmt.Println("Cancelled")
 			return nil
@@ -108,9 +160,11 @@ func runClose(cmd *cobra.Command, args []string) error {
 	}
 
 	// Safety: open PR
-	if github.IsAvailable() && targetBranch != "" {
+	var openPR *github.PR
+	if github.IsAvailable() && targetBranch != "" && !closeKeepBranch {
 		pr, _ := github.GetPRForBranch(targetBranch)
 		if pr != nil && pr.State == "OPEN" {
+			openPR = pr
 			ui.Warn("PR #%d is still open", pr.Number)
 			if !ui.Confirm("Close worktree anyway?", false) {
 				fmt.Println("Cancelled")
@@ -120,7 +174,13 @@ func runClose(cmd *cobra.Command, args []string) error {
 	}
 
 	fmt.Printf("Closing worktree: %s\n", targetPath)
-	fmt.Printf("Branch: %s\n", targetBranch)
+	if detached {
+		fmt.Println("Branch: (detached HEAD)")
+	} else if closeKeepBranch {
+		fmt.Printf("Branch: %s (kept)\n", targetBranch)
+	} else {
+		fmt.Printf("Branch: %s\n", targetBranch)
+	}
 	fmt.Println()
 
 	// Check if we need to cd out before removal
@@ -130,15 +190,19 @@ func runClose(cmd *cobra.Command, args []string) error {
 	if err := git.RemoveWorktree(targetPath); err != nil {
 		return fmt.Errorf("failed to remove worktree: %w", err)
 	}
+	_ = git.DeleteWorktreeMeta(targetPath)
 
 	// cd out after successful removal
 	if needsCd {
-		ui.PrintCdHint(ctx.MainWorktree)
-		fmt.Printf("Moved to main repo: %s\n", ctx.MainWorktree)
+		dest := ctx.leaveTo(targetPath)
+		ui.PrintCdHint(dest)
+		fmt.Printf("Moved to: %s\n", dest)
 	}
 
 	// Delete local branch
-	if targetBranch != "" && git.BranchExists(targetBranch) {
+	if closeKeepBranch && targetBranch != "" {
+		ui.Success("Kept local branch: %s", targetBranch)
+	} else if targetBranch != "" && git.BranchExists(targetBranch) {
 		if err := git.DeleteBranch(targetBranch); err != nil {
 			ui.Warn("Could not delete local branch %s: %v", targetBranch, err)
 		} else {
@@ -148,6 +212,127 @@ func runClose(cmd *cobra.Command, args []string) error {
 
 	fmt.Println()
 	ui.Success("Closed worktree")
+
+	if closeRemote && targetBranch != "" {
+		closeRemoteBranch(ctx, targetBranch, openPR)
+	}
+	if closeKeepBranch && targetBranch != "" {
+		ui.PrintCTA("wt restore "+targetBranch, "wt list")
+	} else {
+		ui.PrintCTA("wt list")
+	}
+	return nil
+}
+
+// closeRemoteBranch deletes branch from the remote after a confirmation,
+// for wt close --remote. It runs after local cleanup and only warns on
+// failure, so a remote problem doesn't read as the close having failed.
+func closeRemoteBranch(ctx *cmdContext, branch string, openPR *github.PR) {
+	remoteRef := ctx.Config.Remote + "/" + branch
+	if !git.RemoteBranchExists(remoteRef) {
+		fmt.Printf("  %s\n", ui.Dim("No remote branch "+remoteRef+" to delete"))
+		return
+	}
+
+	fmt.Println()
+	if openPR != nil {
+		ui.Warn("Deleting %s will close PR #%d", remoteRef, openPR.Number)
+	}
+	if !ui.Confirm(fmt.Sprintf("Delete remote branch %s?", remoteRef), openPR == nil) {
+		fmt.Println("Kept remote branch")
+		return
+	}
+	if err := git.DeleteRemoteBranch(ctx.Config.Remote, branch); err != nil {
+		ui.Warn("Could not delete remote branch %s: %v", remoteRef, err)
+		return
+	}
+	ui.Success("Deleted remote branch: %s", remoteRef)
+}
+
+// runCloseAllMerged closes every worktree whose PR is merged, with the same
+// skips as wt prune but no prompts, and summarizes what happened.
+func runCloseAllMerged(ctx *cmdContext) error {
+	if !github.IsAvailable() {
+		return fmt.Errorf("gh CLI is required for --all-merged (brew install gh)")
+	}
+	if err := github.CheckAuth(); err != nil {
+		return err
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("cannot determine current directory: %w", err)
+	}
+
+	spin := ui.NewSpinner("Finding merged worktrees")
+	worktrees, err := git.ListWorktrees()
+	if err != nil {
+		spin.Stop()
+		return err
+	}
+	mergedPRs, err := github.ListPRs("merged")
+	if err != nil {
+		spin.Stop()
+		return fmt.Errorf("could not fetch merged PRs: %w", err)
+	}
+	mergedPRs, _, lookupErr := completeDonePRs(prBranches(ctx, worktrees), mergedPRs, nil)
+	merged, skipped := findStaleWorktrees(ctx, cwd, worktrees, mergedPRs, nil, false)
+	merged, skipped = skipUnpushed(merged, skipped)
+	git.PruneWorktrees()
+	spin.Stop()
+	if lookupErr != nil {
+		ui.Warn("Could not fetch some PR data — results may be incomplete")
+	}
+
+	if len(merged) == 0 {
+		ui.Success("No merged worktrees to close")
+		printSkipped(skipped)
+		return nil
+	}
+
+	fmt.Printf("Closing %d merged worktree(s)...\n", len(merged))
+	closed := removeStaleWorktrees(merged)
+
+	fmt.Println()
+	ui.Success("Closed %d worktree(s)", closed)
+	if failed := len(merged) - closed; failed > 0 {
+		ui.Warn("%d worktree(s) could not be removed", failed)
+	}
+	printSkipped(skipped)
 	ui.PrintCTA("wt list")
 	return nil
 }
+
+// skipUnpushed moves merged worktrees whose branch has commits that would be
+// lost to the force-delete into skipped, like the unpushed-commit check in a
+// single wt close. Commits in the merged PR count as published even when its
+// remote branch has since been deleted.
+func skipUnpushed(merged, skipped []staleWorktree) (keep, skip []staleWorktree) {
+	skip = skipped
+	for _, s := range merged {
+		if n := unpushedAfterPR(s); n > 0 {
+			s.Skip = fmt.Sprintf("%d unpushed commit(s); wt close %s to review", n, filepath.Base(s.Path))
+			skip = append(skip, s)
+			continue
+		}
+		keep = append(keep, s)
+	}
+	return keep, skip
+}
+
+// unpushedAfterPR counts the commits on s's worktree that no remote has and
+// that weren't part of its PR.
+func unpushedAfterPR(s staleWorktree) int {
+	unpushed, hasUpstream := git.UnpushedCountIn(s.Path)
+	if hasUpstream {
+		return unpushed
+	}
+	unpushed = git.UnpublishedCountIn(s.Path)
+	if unpushed > 0 && s.PRHead != "" {
+		// Errors when the PR head was never fetched; then keep the count.
+		if ab, err := git.GetAheadBehindIn(s.Path, s.PRHead); err == nil && ab.Ahead < unpushed {
+			unpushed = ab.Ahead
+		}
+	}
+	return unpushed
+}
@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/mvwi/wt/internal/github"
+	"github.com/mvwi/wt/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var commentCmd = &cobra.Command{
+	Use:     "comment [name|#PR] [-- message]",
+	GroupID: groupWorkflow,
+	Short:   "Post a comment on a worktree's PR",
+	Long: `Post a comment on the pull request for a worktree.
+
+Without a name, comments on the current worktree's PR, including a
+detached one from wt pr --detached. A PR number works in place of a name.
+The message comes after "--"; without one, it's read from stdin when
+piped, or composed in $EDITOR otherwise.
+
+Requires the GitHub CLI (gh) to be installed.`,
+	Example: `  wt comment -- "LGTM once CI is green"     Comment on the current PR
+  wt comment sidebar -- "Rebased, PTAL"       Comment on sidebar's PR
+  wt comment --name sidebar -- "Rebased"      Same, naming the worktree by flag
+  git log -1 --format=%B | wt comment         Message from stdin
+  wt comment                                  Compose in $EDITOR`,
+	ValidArgsFunction: completeWorktreeNames,
+	RunE:              runComment,
+}
+
+var commentName string
+
+func init() {
+	commentCmd.Flags().StringVarP(&commentName, "name", "n", "", "worktree whose PR to comment on (default: current)")
+	rootCmd.AddCommand(commentCmd)
+}
+
+func runComment(cmd *cobra.Command, args []string) error {
+	if !github.IsAvailable() {
+		return fmt.Errorf("gh CLI is required for this command (brew install gh)")
+	}
+	if err := github.CheckAuth(); err != nil {
+		return err
+	}
+
+	name, message, err := splitNameAndMessage(cmd, args, commentName)
+	if err != nil {
+		return err
+	}
+
+	ctx, err := newContext()
+	if err != nil {
+		return err
+	}
+
+	pr, _, err := resolveTargetPR(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	if message == "" {
+		message, err = readMessage("comment")
+		if err != nil {
+			return err
+		}
+	}
+	if message == "" {
+		return fmt.Errorf("empty comment — nothing posted")
+	}
+
+	if err := github.CommentPR(pr.Number, message); err != nil {
+		return fmt.Errorf("failed to comment on PR #%d: %w", pr.Number, err)
+	}
+	ui.Success("Commented on PR #%d", pr.Number)
+	return nil
+}
+
+// splitNameAndMessage parses "[name] [-- message...]" positional args. The
+// worktree name can also come from a --name flag (flagName), but not both.
+func splitNameAndMessage(cmd *cobra.Command, args []string, flagName string) (name, message string, err error) {
+	before := args
+	var after []string
+	if dash := cmd.ArgsLenAtDash(); dash >= 0 {
+		before, after = args[:dash], args[dash:]
+	}
+	if len(before) > 1 {
+		return "", "", fmt.Errorf("too many arguments — put the message after --\n   e.g. wt %s %s -- \"message\"", cmd.Name(), before[0])
+	}
+
+	name = flagName
+	if len(before) == 1 {
+		if name != "" {
+			return "", "", fmt.Errorf("worktree given twice (%s and --name %s)", before[0], name)
+		}
+		name = before[0]
+	}
+	return name, strings.TrimSpace(strings.Join(after, " ")), nil
+}
+
+// readMessage reads a message body from stdin when it's piped, otherwise
+// opens $EDITOR. kind names the temp file (e.g. "comment").
+func readMessage(kind string) (string, error) {
+	if fi, err := os.Stdin.Stat(); err == nil && fi.Mode()&os.ModeCharDevice == 0 {
+		data, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return "", fmt.Errorf("reading stdin: %w", err)
+		}
+		return strings.TrimSpace(string(data)), nil
+	}
+	return ui.EditText(kind, "")
+}
@@ -1,8 +1,18 @@
 package cmd
 
 import (
+	"bytes"
+	"fmt"
+	"io"
 	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/mvwi/wt/internal/git"
+	"github.com/mvwi/wt/internal/github"
+	"github.com/mvwi/wt/internal/ui"
 	"github.com/spf13/cobra"
 )
 
@@ -14,25 +24,178 @@ var completionCmd = &cobra.Command{
   Fish:       wt completion fish | source
   Bash:       eval "$(wt completion bash)"
   Zsh:        eval "$(wt completion zsh)"
-  PowerShell: wt completion powershell | Out-String | Invoke-Expression`,
-	Args:      cobra.ExactArgs(1),
+  PowerShell: wt completion powershell | Out-String | Invoke-Expression
+
+With --install, writes the script where the shell loads completions from
+instead (shell detected from $SHELL when not given):
+
+  Fish:  ~/.config/fish/completions/wt.fish
+  Bash:  ~/.local/share/bash-completion/completions/wt (bash-completion 2)
+  Zsh:   ~/.zfunc/_wt (add ~/.zfunc to fpath before compinit)`,
+	Example: `  wt completion zsh              Print the zsh completion script
+  wt completion --install        Install completions for your $SHELL
+  wt completion fish --install   Install fish completions`,
+	Args:      cobra.RangeArgs(0, 1),
 	ValidArgs: []string{"bash", "zsh", "fish", "powershell"},
-	RunE: func(cmd *cobra.Command, args []string) error {
-		switch args[0] {
-		case "bash":
-			return rootCmd.GenBashCompletion(os.Stdout)
-		case "zsh":
-			return rootCmd.GenZshCompletion(os.Stdout)
-		case "fish":
-			return rootCmd.GenFishCompletion(os.Stdout, true)
-		case "powershell":
-			return rootCmd.GenPowerShellCompletionWithDesc(os.Stdout)
-		default:
-			return cmd.Help()
-		}
-	},
+	RunE:      runCompletion,
 }
 
+var completionInstall bool
+
 func init() {
+	completionCmd.Flags().BoolVar(&completionInstall, "install", false, "write the script to the shell's completion directory")
 	rootCmd.AddCommand(completionCmd)
 }
+
+func runCompletion(cmd *cobra.Command, args []string) error {
+	if completionInstall {
+		return installCompletion(args)
+	}
+	if len(args) == 0 {
+		return cmd.Help()
+	}
+	return genCompletion(os.Stdout, args[0])
+}
+
+// genCompletion writes shell's completion script to w.
+func genCompletion(w io.Writer, shell string) error {
+	switch shell {
+	case "bash":
+		return rootCmd.GenBashCompletion(w)
+	case "zsh":
+		return rootCmd.GenZshCompletion(w)
+	case "fish":
+		return rootCmd.GenFishCompletion(w, true)
+	case "powershell":
+		return rootCmd.GenPowerShellCompletionWithDesc(w)
+	default:
+		return fmt.Errorf("unsupported shell: %s (use bash, zsh, fish, or powershell)", shell)
+	}
+}
+
+// installCompletion writes the completion script for the named shell, or
+// the one in $SHELL, to completionInstallPath.
+func installCompletion(args []string) error {
+	shell := ""
+	if len(args) > 0 {
+		shell = args[0]
+	} else if s := os.Getenv("SHELL"); s != "" {
+		shell = filepath.Base(s)
+	}
+	if shell == "" {
+		return fmt.Errorf("could not detect your shell from $SHELL\n   Name it: wt completion <bash|zsh|fish> --install")
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return fmt.Errorf("cannot determine home directory: %w", err)
+	}
+	path, err := completionInstallPath(shell, home, os.Getenv)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	if err := genCompletion(&buf, shell); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(path), err)
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+
+	ui.Success("Installed %s completions: %s", shell, path)
+	switch shell {
+	case "zsh":
+		fmt.Printf("  %s\n", ui.Dim("If ~/.zfunc isn't on your fpath, add this to ~/.zshrc before compinit:"))
+		fmt.Printf("  %s\n", ui.Cyan("fpath=(~/.zfunc $fpath)"))
+	case "bash":
+		fmt.Printf("  %s\n", ui.Dim("Loaded by bash-completion 2 in new shells"))
+	default:
+		fmt.Printf("  %s\n", ui.Dim("Open a new shell to use them"))
+	}
+	return nil
+}
+
+// completionInstallPath returns where shell loads a user's completion
+// scripts from, honoring XDG_CONFIG_HOME, XDG_DATA_HOME, and ZDOTDIR.
+func completionInstallPath(shell, home string, getenv func(string) string) (string, error) {
+	orHome := func(env string, rel ...string) string {
+		if dir := getenv(env); dir != "" {
+			return dir
+		}
+		return filepath.Join(append([]string{home}, rel...)...)
+	}
+	switch shell {
+	case "fish":
+		return filepath.Join(orHome("XDG_CONFIG_HOME", ".config"), "fish", "completions", "wt.fish"), nil
+	case "bash":
+		return filepath.Join(orHome("XDG_DATA_HOME", ".local", "share"), "bash-completion", "completions", "wt"), nil
+	case "zsh":
+		return filepath.Join(orHome("ZDOTDIR"), ".zfunc", "_wt"), nil
+	case "powershell", "pwsh":
+		return "", fmt.Errorf("--install doesn't support PowerShell\n   Add to $PROFILE: wt completion powershell | Out-String | Invoke-Expression")
+	default:
+		return "", fmt.Errorf("unsupported shell: %s (use bash, zsh, or fish)", shell)
+	}
+}
+
+// Open PRs are cached for completion so repeated <TAB>s don't each wait
+// on gh.
+const (
+	openPRCacheFile = "wt-open-prs.json"
+	openPRCacheTTL  = time.Minute
+)
+
+// openPRsForCompletion returns open PRs for completion, from the cache when
+// it's fresh. Returns nil without gh or on any error.
+func openPRsForCompletion() []github.PR {
+	var prs []github.PR
+	if git.LoadCache(openPRCacheFile, openPRCacheTTL, &prs) {
+		return prs
+	}
+	if !github.IsAvailable() {
+		return nil
+	}
+	prs, err := github.ListPRs("open")
+	if err != nil {
+		return nil
+	}
+	_ = git.SaveCache(openPRCacheFile, prs)
+	return prs
+}
+
+// prCompletions formats PRs as completions, "<prefix><number>" described
+// by the title and head branch, keeping those that start with toComplete.
+func prCompletions(prs []github.PR, prefix, toComplete string) []string {
+	var out []string
+	for _, pr := range prs {
+		value := prefix + strconv.Itoa(pr.Number)
+		if !strings.HasPrefix(value, toComplete) {
+			continue
+		}
+		out = append(out, fmt.Sprintf("%s\t%s (%s)", value, pr.Title, pr.HeadRefName))
+	}
+	return out
+}
+
+// completeOpenPRs suggests open PR numbers for wt pr.
+func completeOpenPRs(_ *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return prCompletions(openPRsForCompletion(), "", toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeFromRefs suggests values for wt new --from: local branches
+// without a worktree, then open PRs as "#<number>".
+func completeFromRefs(cmd *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	var out []string
+	if !strings.HasPrefix(toComplete, "#") {
+		out, _ = completeBranchesWithoutWorktrees(cmd, nil, toComplete)
+	}
+	out = append(out, prCompletions(openPRsForCompletion(), "#", toComplete)...)
+	return out, cobra.ShellCompDirectiveNoFileComp
+}
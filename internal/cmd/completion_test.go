@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/mvwi/wt/internal/git"
+	"github.com/mvwi/wt/internal/github"
+)
+
+func TestPRCompletions(t *testing.T) {
+	prs := []github.PR{
+		{Number: 7, Title: "Add sidebar", HeadRefName: "mary/sidebar"},
+		{Number: 12, Title: "Fix login", HeadRefName: "fix-login"},
+		{Number: 120, Title: "Bump deps", HeadRefName: "deps"},
+	}
+	tests := []struct {
+		prefix, toComplete string
+		want               []string
+	}{
+		{"", "", []string{"7\tAdd sidebar (mary/sidebar)", "12\tFix login (fix-login)", "120\tBump deps (deps)"}},
+		{"", "12", []string{"12\tFix login (fix-login)", "120\tBump deps (deps)"}},
+		{"#", "#7", []string{"#7\tAdd sidebar (mary/sidebar)"}},
+		{"#", "7", nil},
+	}
+	for _, tt := range tests {
+		if got := prCompletions(prs, tt.prefix, tt.toComplete); !slices.Equal(got, tt.want) {
+			t.Errorf("prCompletions(%q, %q) = %q, want %q", tt.prefix, tt.toComplete, got, tt.want)
+		}
+	}
+}
+
+func TestCompletionInstallPath(t *testing.T) {
+	env := map[string]string{}
+	getenv := func(k string) string { return env[k] }
+
+	tests := []struct {
+		shell string
+		env   map[string]string
+		want  string
+	}{
+		{"fish", nil, "/home/u/.config/fish/completions/wt.fish"},
+		{"fish", map[string]string{"XDG_CONFIG_HOME": "/xdg"}, "/xdg/fish/completions/wt.fish"},
+		{"bash", nil, "/home/u/.local/share/bash-completion/completions/wt"},
+		{"bash", map[string]string{"XDG_DATA_HOME": "/data"}, "/data/bash-completion/completions/wt"},
+		{"zsh", nil, "/home/u/.zfunc/_wt"},
+		{"zsh", map[string]string{"ZDOTDIR": "/zdot"}, "/zdot/.zfunc/_wt"},
+	}
+	for _, tt := range tests {
+		env = tt.env
+		got, err := completionInstallPath(tt.shell, "/home/u", getenv)
+		if err != nil || got != tt.want {
+			t.Errorf("completionInstallPath(%q) with %v = %q, %v; want %q", tt.shell, tt.env, got, err, tt.want)
+		}
+	}
+
+	for _, shell := range []string{"powershell", "tcsh"} {
+		if _, err := completionInstallPath(shell, "/home/u", getenv); err == nil {
+			t.Errorf("completionInstallPath(%q) succeeded, want error", shell)
+		}
+	}
+}
+
+func TestWorktreeCompletionDesc(t *testing.T) {
+	tests := []struct {
+		branch string
+		dirty  bool
+		ab     *git.AheadBehind
+		want   string
+	}{
+		{"mary/api", false, &git.AheadBehind{}, "mary/api"},
+		{"mary/api", false, &git.AheadBehind{Behind: 2}, "mary/api, 2 behind"},
+		{"mary/api", true, &git.AheadBehind{Ahead: 1, Behind: 3}, "mary/api, dirty, 1 ahead, 3 behind"},
+		{"main", true, nil, "main, dirty"},
+		{"", false, nil, "detached"},
+	}
+	for _, tt := range tests {
+		if got := worktreeCompletionDesc(tt.branch, tt.dirty, tt.ab); got != tt.want {
+			t.Errorf("worktreeCompletionDesc(%q, %v, %v) = %q, want %q", tt.branch, tt.dirty, tt.ab, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,233 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mvwi/wt/internal/config"
+	"github.com/mvwi/wt/internal/git"
+	"github.com/mvwi/wt/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var configCmd = &cobra.Command{
+	Use:     "config",
+	GroupID: groupManage,
+	Short:   "Manage wt configuration",
+	Long: `Manage wt configuration.
+
+With --edit, opens the repo's .wt.toml in $VISUAL / $EDITOR (or the global
+~/.config/wt/config.toml with --global), writing a commented template first
+if the file doesn't exist. The saved file is re-parsed and any errors or
+unrecognized keys are reported.`,
+	Example: `  wt config --edit            Edit this repo's .wt.toml
+  wt config --edit --global   Edit ~/.config/wt/config.toml`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if configEdit {
+			return runConfigEdit(configGlobal)
+		}
+		if configGlobal {
+			return fmt.Errorf("--global is only valid with --edit")
+		}
+		return cmd.Help()
+	},
+}
+
+var configInitCmd = &cobra.Command{
+	Use:   "init",
+	Short: "Write a commented .wt.toml to the repo root",
+	Long: `Write a commented .wt.toml template to the main worktree's root.
+
+Settings are filled in from the current config (base branch, remote) and
+the remote's default branch; the [init] section lists what wt init would
+auto-detect in this repo. Everything else is commented out with its
+default.
+
+Refuses to overwrite an existing .wt.toml unless --force is given.`,
+	Example: `  wt config init           Scaffold .wt.toml
+  wt config init --force   Replace an existing .wt.toml`,
+	Args: cobra.NoArgs,
+	RunE: runConfigInit,
+}
+
+var (
+	configInitForce bool
+	configEdit      bool
+	configGlobal    bool
+)
+
+func init() {
+	configCmd.Flags().BoolVarP(&configEdit, "edit", "e", false, "open the config file in $EDITOR")
+	configCmd.Flags().BoolVar(&configGlobal, "global", false, "with --edit, edit ~/.config/wt/config.toml")
+	configInitCmd.Flags().BoolVar(&configInitForce, "force", false, "overwrite an existing .wt.toml")
+	configCmd.AddCommand(configInitCmd)
+	rootCmd.AddCommand(configCmd)
+}
+
+func runConfigInit(cmd *cobra.Command, args []string) error {
+	ctx, err := newContext()
+	if err != nil {
+		return err
+	}
+
+	path := filepath.Join(ctx.ConfigDir, ".wt.toml")
+	if fileExists(path) && !configInitForce {
+		return fmt.Errorf("%s already exists\n   Use wt config init --force to replace it", path)
+	}
+
+	baseBranch := ctx.Config.BaseBranch
+	if ctx.Config.BaseBranch == "main" {
+		// Still the built-in default: the remote knows better
+		if b, err := git.RemoteDefaultBranch(ctx.Config.Remote); err == nil && b != "" {
+			baseBranch = b
+		}
+	}
+	copyFiles, commands := detectInit(ctx.ConfigDir)
+
+	if err := os.WriteFile(path, []byte(configTemplate(baseBranch, ctx.Config.Remote, copyFiles, commands)), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+
+	ui.Success("Wrote %s", path)
+	fmt.Printf("  %s\n", ui.Dim("Uncomment and edit what you need; see wt whereami for the result"))
+	return nil
+}
+
+// runConfigEdit opens .wt.toml (or the global config) in the editor,
+// scaffolding it first if missing, then checks what was saved.
+func runConfigEdit(global bool) error {
+	var path, template string
+	if global {
+		p, err := config.GlobalPath()
+		if err != nil {
+			return err
+		}
+		path, template = p, globalConfigTemplate
+	} else {
+		dir, err := git.MainWorktree()
+		if err != nil {
+			return err
+		}
+		// A broken config only costs the bare-clone lookup of where
+		// .wt.toml lives and the template's detected values; it doesn't
+		// block creating or fixing the repo's file.
+		ctx, ctxErr := newContext()
+		if ctxErr == nil {
+			dir = ctx.ConfigDir
+		}
+		path = filepath.Join(dir, ".wt.toml")
+		if !fileExists(path) {
+			template = configTemplate("main", "origin", nil, nil)
+			if ctxErr == nil {
+				copyFiles, commands := detectInit(ctx.ConfigDir)
+				template = configTemplate(ctx.Config.BaseBranch, ctx.Config.Remote, copyFiles, commands)
+			}
+		}
+	}
+
+	if !fileExists(path) {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return fmt.Errorf("failed to create %s: %w", filepath.Dir(path), err)
+		}
+		if err := os.WriteFile(path, []byte(template), 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", path, err)
+		}
+		ui.Info("Created %s", path)
+	}
+
+	if err := ui.EditFile(path); err != nil {
+		return err
+	}
+
+	unknown, err := config.CheckFile(path, global)
+	if err != nil {
+		again := "wt config --edit"
+		if global {
+			again += " --global"
+		}
+		return fmt.Errorf("%s: %w\n   Run %s again to fix it", path, err, again)
+	}
+	for _, key := range unknown {
+		ui.Warn("Unknown key %s in %s", key, path)
+	}
+	ui.Success("Saved %s", path)
+	return nil
+}
+
+// globalConfigTemplate is written by wt config --edit --global when
+// ~/.config/wt/config.toml doesn't exist yet.
+const globalConfigTemplate = `# wt global configuration — see https://github.com/mvwi/wt#configuration
+# Top-level settings apply to every repo; .wt.toml in a repo overrides them.
+
+# Prefix for new branch names: "<prefix>/<name>". Default: your git
+# username's first name. Set to "" to disable prefixing.
+# branch_prefix = "michael"
+
+# Days before a worktree with no open PR is flagged stale in wt list.
+# stale_threshold = 7
+
+# Check GitHub daily for a new wt release. Only read from this file.
+# WT_NO_UPDATE_CHECK=1 also turns it off.
+# check_updates = false
+
+# Per-repo overrides, keyed by repo name, for settings you'd rather not
+# commit to the repo.
+# [repos.my-repo]
+# base_branch = "develop"
+`
+
+// configTemplate renders the .wt.toml written by wt config init. Detected
+// copy files and commands go in [init] uncommented; with none detected the
+// section is left as a commented example.
+func configTemplate(baseBranch, remote string, copyFiles, commands []string) string {
+	var b strings.Builder
+	b.WriteString(`# wt configuration — see https://github.com/mvwi/wt#configuration
+# Settings here override ~/.config/wt/config.toml for this repo.
+
+# Branch new worktrees are created from and rebased onto.
+`)
+	fmt.Fprintf(&b, "base_branch = %q\n\n", baseBranch)
+	b.WriteString("# Git remote to fetch from and push to.\n")
+	fmt.Fprintf(&b, "remote = %q\n\n", remote)
+	b.WriteString(`# Prefix for new branch names: "<prefix>/<name>". Default: your git
+# username's first name. Set to "" to disable prefixing.
+# branch_prefix = "michael"
+
+# Worktree directory naming. Default: nested "wt-<repo>/<name>".
+# Set for a flat layout: "<prefix><name>" (e.g. "wt-" → "wt-sidebar").
+# worktree_prefix = "wt-"
+
+# Days before a worktree with no open PR is flagged stale in wt list.
+# stale_threshold = 7
+
+`)
+
+	if len(copyFiles) == 0 && len(commands) == 0 {
+		b.WriteString(`# What wt init does: files copied from the main worktree, then commands.
+# Without this section, wt init auto-detects both.
+# [init]
+# copy_files = [".env"]
+# commands = ["pnpm install"]
+`)
+		return b.String()
+	}
+
+	b.WriteString("# What wt init does: files copied from the main worktree, then commands.\n")
+	b.WriteString("# Detected in this repo; with this section, detection is off.\n")
+	b.WriteString("[init]\n")
+	fmt.Fprintf(&b, "copy_files = %s\n", tomlStringArray(copyFiles))
+	fmt.Fprintf(&b, "commands = %s\n", tomlStringArray(commands))
+	return b.String()
+}
+
+// tomlStringArray renders strings as a one-line TOML array.
+func tomlStringArray(values []string) string {
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = fmt.Sprintf("%q", v)
+	}
+	return "[" + strings.Join(quoted, ", ") + "]"
+}
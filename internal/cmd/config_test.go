@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/mvwi/wt/internal/config"
+)
+
+func TestConfigTemplate(t *testing.T) {
+	tests := []struct {
+		name      string
+		copyFiles []string
+		commands  []string
+	}{
+		{"with detected init", []string{".env", ".claude"}, []string{"pnpm install --frozen-lockfile", "pnpm exec prisma generate --schema 'my db/schema.prisma'"}},
+		{"nothing detected", nil, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			t.Setenv("HOME", t.TempDir())
+			writeFile(t, filepath.Join(dir, ".wt.toml"), configTemplate("develop", "upstream", tt.copyFiles, tt.commands))
+
+			// The template must load, and round-trip what was filled in
+			cfg, err := config.Load(dir, "myrepo")
+			if err != nil {
+				t.Fatalf("template doesn't load: %v", err)
+			}
+			if cfg.BaseBranch != "develop" || cfg.Remote != "upstream" {
+				t.Errorf("BaseBranch, Remote = %q, %q; want develop, upstream", cfg.BaseBranch, cfg.Remote)
+			}
+			if cfg.BranchPrefix != nil || cfg.StaleThreshold != 0 {
+				t.Errorf("commented-out settings were set: %+v", cfg)
+			}
+			if !slices.Equal(cfg.Init.CopyFiles, tt.copyFiles) {
+				t.Errorf("CopyFiles = %v, want %v", cfg.Init.CopyFiles, tt.copyFiles)
+			}
+			var runs []string
+			for _, c := range cfg.Init.Commands {
+				runs = append(runs, c.Run)
+			}
+			if !slices.Equal(runs, tt.commands) {
+				t.Errorf("Commands = %v, want %v", runs, tt.commands)
+			}
+		})
+	}
+}
+
+func TestUpdateChecksEnabled(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	dir := filepath.Join(home, ".config", "wt")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	writeGlobal := func(content string) {
+		if err := os.WriteFile(filepath.Join(dir, "config.toml"), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name   string
+		env    string
+		config string
+		want   bool
+	}{
+		{"default", "", "", true},
+		{"config off", "", "check_updates = false\n", false},
+		{"env off", "1", "", false},
+		{"env on beats config", "0", "check_updates = false\n", true},
+		{"unparseable env falls back to config", "maybe", "check_updates = false\n", false},
+		{"broken config keeps checks on", "", "check_updates = ", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("WT_NO_UPDATE_CHECK", tt.env)
+			writeGlobal(tt.config)
+			if got := updateChecksEnabled(); got != tt.want {
+				t.Errorf("updateChecksEnabled() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
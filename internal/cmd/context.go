@@ -1,12 +1,16 @@
 package cmd
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/mvwi/wt/internal/config"
 	"github.com/mvwi/wt/internal/git"
+	"github.com/mvwi/wt/internal/github"
 	"github.com/mvwi/wt/internal/ui"
 	"github.com/spf13/cobra"
 )
@@ -15,9 +19,21 @@ import (
 type cmdContext struct {
 	Config       *config.Config
 	RepoName     string
-	MainWorktree string
-	ParentDir    string
-	Username     string
+	MainWorktree string // in a bare clone, the bare repo directory itself
+	ParentDir    string // where worktrees are created: worktree_root, or the main worktree's parent
+	Bare         bool   // bare clone with only linked worktrees (repo.git or repo/.bare)
+
+	// HomeWorktree is the checkout that stands in for the main worktree:
+	// where wt switch <repo> goes and where closing the current worktree
+	// sends you. In a bare clone it's the worktree on the base branch, or
+	// "" when there is none.
+	HomeWorktree string
+	// ConfigDir holds the .wt.toml in effect and the files wt init copies:
+	// HomeWorktree, or in a bare clone without one, the current worktree.
+	ConfigDir string
+
+	username         string // resolved lazily; see Username
+	usernameResolved bool
 }
 
 // newContext builds shared context from the current repo.
@@ -37,54 +53,242 @@ func newContext() (*cmdContext, error) {
 		return nil, err
 	}
 
-	parentDir, err := git.ParentDir()
-	if err != nil {
-		return nil, err
+	// A bare clone has no checkout of its own, so .wt.toml is read from the
+	// base branch's worktree, or failing that the current one. The base
+	// branch can itself come from .wt.toml, so look again after loading.
+	bare := git.IsBareRepo()
+	homeWT, configDir := mainWT, mainWT
+	if bare {
+		homeWT = branchWorktree(cfg.BaseBranch)
+		configDir = homeWT
+		if configDir == "" {
+			configDir, _ = git.TopLevel()
+		}
+		if configDir == "" {
+			configDir = mainWT
+		} else if cfg, err = config.Load(configDir, repo); err != nil {
+			return nil, err
+		}
+		if wt := branchWorktree(cfg.BaseBranch); wt != "" {
+			homeWT = wt
+		}
 	}
 
-	username, err := git.Username()
-	if err != nil && cfg.BranchPrefix == nil {
-		// Only warn if branch_prefix isn't explicitly configured,
-		// since the username is only used as a fallback prefix.
-		ui.Warn("Could not determine git username — branches won't have a prefix. Set branch_prefix in .wt.toml or run: git config user.name \"Your Name\"")
+	github.MaxRetries = cfg.EffectiveGHRetries()
+	github.ListLimit = cfg.EffectivePRLimit()
+	github.Timeout = cfg.EffectiveNetworkTimeout()
+	git.NetworkTimeout = cfg.EffectiveNetworkTimeout()
+	git.LocalTimeout = cfg.EffectiveGitTimeout()
+	ui.Hyperlinks = cfg.Hyperlinks
+
+	// Worktrees go under worktree_root if set, else next to the main worktree
+	parentDir := cfg.EffectiveWorktreeRoot(mainWT, repo)
+	if parentDir == "" {
+		parentDir, err = git.ParentDir()
+		if err != nil {
+			return nil, err
+		}
 	}
 
+	tracer.Printf("context: repo=%s main=%s (bare=%v) config in %s, worktrees in %s, base %s/%s", repo, mainWT, bare, configDir, parentDir, cfg.Remote, cfg.BaseBranch)
 	return &cmdContext{
 		Config:       cfg,
 		RepoName:     repo,
 		MainWorktree: mainWT,
 		ParentDir:    parentDir,
-		Username:     username,
+		Bare:         bare,
+		HomeWorktree: homeWT,
+		ConfigDir:    configDir,
 	}, nil
 }
 
+// branchWorktree returns the worktree that has branch checked out, or "".
+func branchWorktree(branch string) string {
+	worktrees, err := git.ListWorktrees()
+	if err != nil {
+		return ""
+	}
+	for _, wt := range worktrees {
+		if !wt.Bare && wt.Branch == branch {
+			return wt.Path
+		}
+	}
+	return ""
+}
+
+// leaveTo returns where to cd after removing the worktree at path:
+// HomeWorktree, or the directory holding the worktrees when that's gone
+// too (a bare clone, where the bare repo itself is no place to land).
+func (c *cmdContext) leaveTo(path string) string {
+	if c.HomeWorktree != "" && c.HomeWorktree != path {
+		return c.HomeWorktree
+	}
+	return c.ParentDir
+}
+
+// Username returns the username used as the default branch prefix, from
+// the configured username_source. It's resolved on first use, since the
+// gh-login source is a network call and most commands never need it.
+func (c *cmdContext) Username() string {
+	if c.usernameResolved {
+		return c.username
+	}
+	c.usernameResolved = true
+
+	var err error
+	switch source := c.Config.EffectiveUsernameSource(); source {
+	case config.UsernameGitNameFirst:
+		c.username, err = git.Username()
+	case config.UsernameGitNameFull:
+		c.username, err = git.UsernameFull()
+	case config.UsernameGitEmailLocal:
+		c.username, err = git.EmailUsername()
+	case config.UsernameGHLogin:
+		if err = github.CheckAuth(); err == nil {
+			var login string
+			login, err = github.CurrentUser(c.remoteHost())
+			c.username = strings.ToLower(login)
+		}
+	default:
+		err = fmt.Errorf("unknown username_source %q (use git-name-first, git-name-full, git-email-local, or gh-login)", source)
+	}
+	if err != nil {
+		// Only reached without branch_prefix: the username is just its fallback
+		ui.Warn("Could not determine username: %v — branches won't have a prefix. Set branch_prefix or username_source in .wt.toml", err)
+	}
+	return c.username
+}
+
 // branchName builds a full branch name using config.
 func (c *cmdContext) branchName(name string) string {
-	return c.Config.EffectiveBranchName(name, c.Username)
+	if c.Config.BranchTemplate != "" {
+		return c.expandTemplate(c.Config.BranchTemplate, name)
+	}
+	if c.Config.BranchPrefix != nil {
+		return c.Config.EffectiveBranchName(name, "")
+	}
+	return c.Config.EffectiveBranchName(name, c.Username())
 }
 
-// worktreeDir builds a worktree directory name using config.
+// worktreeDir builds a worktree directory name using config. In a bare
+// clone the worktrees already sit in a folder of their own (next to
+// repo.git or .bare), so without a worktree_prefix the layout is flat.
 func (c *cmdContext) worktreeDir(name string) string {
+	if c.Config.WorktreeTemplate != "" {
+		return c.expandTemplate(c.Config.WorktreeTemplate, name)
+	}
+	if c.Bare && c.Config.WorktreePrefix == nil && c.Config.WorktreeRoot == "" {
+		return name
+	}
 	return c.Config.EffectiveWorktreeDir(c.RepoName, name)
 }
 
+// expandTemplate expands a branch_template or worktree_template for name.
+// The username is only resolved when the template needs it. Templates are
+// validated when config loads, so expansion can't fail here.
+func (c *cmdContext) expandTemplate(tmpl, name string) string {
+	vars := config.TemplateVars{
+		Name: name,
+		Repo: c.RepoName,
+		Date: time.Now().Format(time.DateOnly),
+	}
+	if strings.Contains(tmpl, "${user}") {
+		vars.User = c.Username()
+	}
+	if strings.Contains(tmpl, "${prefix}") {
+		if c.Config.BranchPrefix != nil {
+			vars.Prefix = *c.Config.BranchPrefix
+		} else {
+			vars.Prefix = c.Username()
+		}
+	}
+	out, _ := config.ExpandTemplate(tmpl, vars)
+	return out
+}
+
 // worktreePath builds a full worktree path.
 func (c *cmdContext) worktreePath(name string) string {
 	return c.ParentDir + "/" + c.worktreeDir(name)
 }
 
+// newWorktreePath is worktreePath for a worktree about to be created or
+// renamed into. It rejects names that are empty, absolute, or contain "."
+// or ".." path elements, and checks that the expanded path (after
+// worktree_prefix or worktree_template) stays strictly under ParentDir,
+// so no name can put a worktree somewhere else on disk.
+func (c *cmdContext) newWorktreePath(name string) (string, error) {
+	if err := validateWorktreeName(name); err != nil {
+		return "", err
+	}
+	root := filepath.Clean(c.ParentDir)
+	path := filepath.Clean(c.worktreePath(name))
+	if rel, err := filepath.Rel(root, path); err != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("worktree path for %q is outside %s: %s\n   Check worktree_prefix and worktree_template in .wt.toml", name, root, path)
+	}
+	return path, nil
+}
+
+// validateWorktreeName rejects worktree names that could escape the
+// worktree root: empty, absolute, or with "." or ".." elements.
+func validateWorktreeName(name string) error {
+	if strings.TrimSpace(name) == "" {
+		return fmt.Errorf("worktree name is empty")
+	}
+	if filepath.IsAbs(name) || strings.HasPrefix(name, "/") || strings.HasPrefix(name, `\`) {
+		return fmt.Errorf("invalid worktree name %q: must be a name, not an absolute path", name)
+	}
+	for _, elem := range strings.FieldsFunc(name, func(r rune) bool { return r == '/' || r == '\\' }) {
+		if elem == "." || elem == ".." {
+			return fmt.Errorf("invalid worktree name %q: %q path elements aren't allowed", name, elem)
+		}
+	}
+	return nil
+}
+
 // baseRef returns the full remote ref for the base branch (e.g., "origin/staging").
 func (c *cmdContext) baseRef() string {
 	return c.Config.Remote + "/" + c.Config.BaseBranch
 }
 
+// fetchBase updates baseRef from the remote. The base branch needn't exist
+// locally — wt only ever works against the remote-tracking ref.
+func (c *cmdContext) fetchBase() error {
+	return git.FetchBranch(c.Config.Remote, c.Config.BaseBranch)
+}
+
+// remoteHost returns the hostname of the configured remote (e.g.
+// "github.com" or a GitHub Enterprise host), or "" if it can't be determined.
+func (c *cmdContext) remoteHost() string {
+	u, err := git.RemoteURL(c.Config.Remote)
+	if err != nil {
+		return ""
+	}
+	return git.RemoteHost(u)
+}
+
 // shortName strips the configured worktree prefix from a path to produce a display name.
 func (c *cmdContext) shortName(path string) string {
 	base := filepath.Base(path)
-	prefix := c.Config.EffectiveWorktreeDir(c.RepoName, "")
+	prefix := c.Config.WorktreeDirPrefix(c.RepoName)
 	return strings.TrimPrefix(base, prefix)
 }
 
+// inMainWorktree reports whether dir is the main worktree or inside it.
+// A worktree_root inside the main worktree (e.g. ".worktrees") holds other
+// worktrees, so paths under it don't count.
+func (c *cmdContext) inMainWorktree(dir string) bool {
+	if dir == c.MainWorktree {
+		return true
+	}
+	if !isSubpath(dir, c.MainWorktree) {
+		return false
+	}
+	if isSubpath(c.ParentDir, c.MainWorktree) && (dir == c.ParentDir || isSubpath(dir, c.ParentDir)) {
+		return false
+	}
+	return true
+}
+
 // isBaseBranch returns true if the branch is the configured base branch or a common default.
 func (c *cmdContext) isBaseBranch(branch string) bool {
 	return branch == c.Config.BaseBranch || branch == "main" || branch == "master"
@@ -111,7 +315,9 @@ func isDir(path string) bool {
 	return err == nil && info.IsDir()
 }
 
-// completeWorktreeNames returns a Cobra ValidArgsFunction that suggests worktree short names.
+// completeWorktreeNames returns a Cobra ValidArgsFunction that suggests worktree
+// short names, described by branch and local sync state. Everything is read
+// from local refs, so completion never waits on the network.
 func completeWorktreeNames(_ *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 	ctx, err := newContext()
 	if err != nil {
@@ -123,16 +329,69 @@ func completeWorktreeNames(_ *cobra.Command, _ []string, toComplete string) ([]s
 		return nil, cobra.ShellCompDirectiveNoFileComp
 	}
 
-	prefix := ctx.Config.EffectiveWorktreeDir(ctx.RepoName, "")
-	var names []string
-	for _, wt := range worktrees {
+	prefix := ctx.Config.WorktreeDirPrefix(ctx.RepoName)
+	var matches []git.Worktree
+	for _, wt := range withoutBare(worktrees) {
 		if wt.Path == ctx.MainWorktree {
 			continue
 		}
 		short := strings.TrimPrefix(filepath.Base(wt.Path), prefix)
 		if toComplete == "" || strings.HasPrefix(short, toComplete) {
-			names = append(names, short)
+			matches = append(matches, wt)
 		}
 	}
+
+	names := make([]string, len(matches))
+	var wg sync.WaitGroup
+	for i, wt := range matches {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			short := strings.TrimPrefix(filepath.Base(wt.Path), prefix)
+			dirty := git.HasChangesIn(wt.Path)
+			var ab *git.AheadBehind
+			if wt.Branch != "" && !ctx.isBaseBranch(wt.Branch) {
+				if v, err := git.GetAheadBehindIn(wt.Path, ctx.baseRef()); err == nil {
+					ab = &v
+				}
+			}
+			names[i] = short + "\t" + worktreeCompletionDesc(wt.Branch, dirty, ab)
+		}()
+	}
+	wg.Wait()
 	return names, cobra.ShellCompDirectiveNoFileComp
 }
+
+// withoutBare drops the bare repository's entry from a worktree list. A
+// bare repo has nothing checked out, so there's nothing to list or switch to.
+func withoutBare(worktrees []git.Worktree) []git.Worktree {
+	var out []git.Worktree
+	for _, wt := range worktrees {
+		if !wt.Bare {
+			out = append(out, wt)
+		}
+	}
+	return out
+}
+
+// worktreeCompletionDesc describes a worktree for completion menus, e.g.
+// "mary/api, dirty, 2 behind". ab is nil when sync state is unknown or
+// not meaningful (detached HEAD, base branch).
+func worktreeCompletionDesc(branch string, dirty bool, ab *git.AheadBehind) string {
+	if branch == "" {
+		branch = "detached"
+	}
+	parts := []string{branch}
+	if dirty {
+		parts = append(parts, "dirty")
+	}
+	if ab != nil {
+		if ab.Ahead > 0 {
+			parts = append(parts, fmt.Sprintf("%d ahead", ab.Ahead))
+		}
+		if ab.Behind > 0 {
+			parts = append(parts, fmt.Sprintf("%d behind", ab.Behind))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/mvwi/wt/internal/config"
+)
+
+func TestNewWorktreePath(t *testing.T) {
+	parent := filepath.FromSlash("/src")
+	ctx := &cmdContext{
+		Config:       &config.Config{BaseBranch: "main", Remote: "origin"},
+		RepoName:     "repo",
+		MainWorktree: filepath.Join(parent, "repo"),
+		ParentDir:    parent,
+	}
+
+	tests := []struct {
+		name    string
+		want    string
+		wantErr bool
+	}{
+		{name: "feat", want: filepath.Join(parent, "wt-repo", "feat")},
+		{name: "team/feat", want: filepath.Join(parent, "wt-repo", "team", "feat")},
+		{name: "a..b", want: filepath.Join(parent, "wt-repo", "a..b")},
+		{name: "", wantErr: true},
+		{name: "  ", wantErr: true},
+		{name: ".", wantErr: true},
+		{name: "..", wantErr: true},
+		{name: "../x", wantErr: true},
+		{name: "../../etc", wantErr: true},
+		{name: "a/../../x", wantErr: true},
+		{name: `..\x`, wantErr: true},
+		{name: "/tmp/x", wantErr: true},
+		{name: `\tmp\x`, wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ctx.newWorktreePath(tt.name)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("newWorktreePath(%q) = %q, want error", tt.name, got)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("newWorktreePath(%q) = %q, %v; want %q", tt.name, got, err, tt.want)
+		}
+	}
+}
+
+func TestNewWorktreePathEscapingPrefix(t *testing.T) {
+	prefix := "../"
+	ctx := &cmdContext{
+		Config:    &config.Config{WorktreePrefix: &prefix},
+		RepoName:  "repo",
+		ParentDir: filepath.FromSlash("/src/worktrees"),
+	}
+	if got, err := ctx.newWorktreePath("feat"); err == nil {
+		t.Errorf("newWorktreePath() = %q with worktree_prefix %q, want error", got, prefix)
+	}
+
+	prefix = ""
+	if got, err := ctx.newWorktreePath("feat"); err != nil || got != filepath.FromSlash("/src/worktrees/feat") {
+		t.Errorf("newWorktreePath() = %q, %v; want /src/worktrees/feat", got, err)
+	}
+}
+
+func TestWorktreeDirBare(t *testing.T) {
+	ctx := &cmdContext{
+		Config:       &config.Config{},
+		RepoName:     "repo",
+		MainWorktree: filepath.FromSlash("/src/repo/.bare"),
+		ParentDir:    filepath.FromSlash("/src/repo"),
+		Bare:         true,
+	}
+	if got := ctx.worktreeDir("feat"); got != "feat" {
+		t.Errorf("worktreeDir() = %q in a bare clone, want flat %q", got, "feat")
+	}
+
+	prefix := "wt-"
+	ctx.Config.WorktreePrefix = &prefix
+	if got := ctx.worktreeDir("feat"); got != "wt-feat" {
+		t.Errorf("worktreeDir() = %q with worktree_prefix, want %q", got, "wt-feat")
+	}
+}
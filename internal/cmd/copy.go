@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/mvwi/wt/internal/git"
+	"github.com/mvwi/wt/internal/github"
+	"github.com/mvwi/wt/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var copyCmd = &cobra.Command{
+	Use:     "copy [name]",
+	GroupID: groupWorkflow,
+	Short:   "Copy the branch name (or PR URL) to the clipboard",
+	Long: `Copy the current branch name to the system clipboard.
+
+With a name, copies that worktree's branch. With --pr, copies the URL of
+the branch's pull request instead (requires gh).
+
+When no clipboard is available, or output isn't a terminal, the text is
+printed instead.`,
+	Example: `  wt copy                  Copy the current branch name
+  wt copy sidebar          Copy sidebar's branch name
+  wt copy --pr             Copy the current branch's PR URL`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeWorktreeNames,
+	RunE:              runCopy,
+}
+
+var copyPRFlag bool
+
+func init() {
+	copyCmd.Flags().BoolVar(&copyPRFlag, "pr", false, "copy the PR URL instead of the branch name")
+	rootCmd.AddCommand(copyCmd)
+}
+
+func runCopy(cmd *cobra.Command, args []string) error {
+	ctx, err := newContext()
+	if err != nil {
+		return err
+	}
+
+	var name string
+	if len(args) > 0 {
+		name = args[0]
+	}
+
+	var text string
+	if copyPRFlag {
+		if !github.IsAvailable() {
+			return fmt.Errorf("gh CLI is required for --pr (brew install gh)")
+		}
+		if err := github.CheckAuth(); err != nil {
+			return err
+		}
+		pr, _, err := resolveTargetPR(ctx, name)
+		if err != nil {
+			return err
+		}
+		text = pr.URL
+	} else if name == "" {
+		text, err = git.CurrentBranch()
+		if err != nil {
+			return fmt.Errorf("not on a branch (detached HEAD)")
+		}
+	} else {
+		worktrees, err := git.ListWorktrees()
+		if err != nil {
+			return err
+		}
+		target, _, err := resolveWorktree(ctx, worktrees, name)
+		if err != nil {
+			return err
+		}
+		if target == "" {
+			return fmt.Errorf("worktree not found: %s\n   Run wt list to see available worktrees", name)
+		}
+		text, err = git.CurrentBranchIn(target)
+		if err != nil {
+			return fmt.Errorf("could not determine branch for worktree: %s", name)
+		}
+	}
+
+	// Scripts and agents get the bare text on stdout
+	if !ui.IsTTY() || !ui.ClipboardAvailable() {
+		fmt.Println(text)
+		return nil
+	}
+	if err := ui.CopyToClipboard(text); err != nil {
+		ui.Warn("Failed to copy: %v", err)
+		fmt.Println(text)
+		return nil
+	}
+	ui.Success("Copied %s", ui.Bold(text))
+	return nil
+}
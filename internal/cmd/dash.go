@@ -69,6 +69,7 @@ func runDash(cmd *cobra.Command, args []string) error {
 		if err := git.RemoveWorktree(path); err != nil {
 			return err
 		}
+		_ = git.DeleteWorktreeMeta(path)
 		if branch != "" && git.BranchExists(branch) {
 			_ = git.DeleteBranch(branch)
 		}
@@ -183,7 +184,7 @@ func dashRebase(ctx *cmdContext, path, branch string) (int, error) {
 
 	// Fetch the base ref so the rebase target is current. Failure here is
 	// non-fatal — fall through to rebase against whatever's local.
-	_ = git.Fetch(ctx.Config.Remote, ctx.Config.BaseBranch)
+	_ = ctx.fetchBase()
 
 	ab, err := git.GetAheadBehindIn(path, ctx.baseRef())
 	if err != nil {
@@ -203,7 +204,7 @@ func dashRebase(ctx *cmdContext, path, branch string) (int, error) {
 // Mirrors the CLI's rebaseBaseBranch but without any interactive prompts: we
 // already bailed on dirty in dashRebase, so this just fetches and merges.
 func dashFastForwardBase(ctx *cmdContext, path string) (int, error) {
-	if err := git.Fetch(ctx.Config.Remote, ctx.Config.BaseBranch); err != nil {
+	if err := ctx.fetchBase(); err != nil {
 		return 0, fmt.Errorf("fetch failed: %w", err)
 	}
 	remoteRef := ctx.Config.Remote + "/" + ctx.Config.BaseBranch
@@ -283,11 +284,11 @@ func gatherDashItems(ctx *cmdContext, cwd string) ([]tui.DashItem, error) {
 		return nil, err
 	}
 
-	infos, _ := collectWorktreeInfos(ctx, cwd, worktrees)
+	infos, _ := collectWorktreeInfos(ctx, cwd, worktrees, false)
 
 	var openPRs, mergedPRs, closedPRs []github.PR
 	if github.IsAvailable() {
-		openPRs, mergedPRs, closedPRs, _ = fetchPRData()
+		openPRs, mergedPRs, closedPRs, _ = fetchPRData(prBranches(ctx, worktrees))
 	}
 
 	staleThreshold := ctx.Config.EffectiveStaleThreshold()
@@ -310,17 +311,21 @@ func gatherDashItems(ctx *cmdContext, cwd string) ([]tui.DashItem, error) {
 
 		if !isBase {
 			openPR := github.FindPRForBranch(openPRs, info.Branch)
-			mergedPR := github.FindPRForBranch(mergedPRs, info.Branch)
-			closedPR := github.FindPRForBranch(closedPRs, info.Branch)
+			mergedPR := github.FindPRForBranchOrHead(mergedPRs, info.Branch, info.prMatchHead())
+			closedPR := github.FindPRForBranchOrHead(closedPRs, info.Branch, info.prMatchHead())
 
-			daysAgo := git.WorktreeAgeDays(info.Path)
-			item.StaleDimmed = daysAgo >= staleThreshold && openPR == nil
+			item.StaleDimmed, _ = staleVerdict(git.WorktreeAgeDays(info.Path), staleThreshold, openPR != nil)
 
 			// Unpushed commits are the canonical data-loss signal for close —
 			// fetch for every non-base worktree, not just ones with an open PR.
-			// Cheap call (single rev-list --count); returns 0 if there's no
-			// upstream so no need to special-case.
-			item.Unpushed = git.UnpushedCountIn(info.Path)
+			// Without an upstream (never pushed, or remote branch deleted) the
+			// ahead-of-upstream count is meaningless, so count the commits no
+			// remote has instead.
+			unpushed, hasUpstream := git.UnpushedCountIn(info.Path)
+			if !hasUpstream {
+				unpushed = git.UnpublishedCountIn(info.Path)
+			}
+			item.Unpushed = unpushed
 
 			switch {
 			case openPR != nil:
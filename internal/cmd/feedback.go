@@ -6,12 +6,23 @@ import (
 	"os"
 	"os/exec"
 	"runtime"
+	"strconv"
+	"strings"
 
+	"github.com/mvwi/wt/internal/config"
+	"github.com/mvwi/wt/internal/git"
 	"github.com/mvwi/wt/internal/github"
+	"github.com/mvwi/wt/internal/trace"
+	"github.com/mvwi/wt/internal/ui"
 	"github.com/spf13/cobra"
 )
 
-const feedbackRepo = "mvwi/wt"
+// wt's own issue tracker lives on public GitHub, whatever host the current
+// repo uses — so feedback pins the host instead of detecting it.
+const (
+	feedbackHost = github.DefaultHost
+	feedbackRepo = "mvwi/wt"
+)
 
 var feedbackCmd = &cobra.Command{
 	Use:   "feedback [message]",
@@ -19,7 +30,15 @@ var feedbackCmd = &cobra.Command{
 	Long: `Open a new GitHub issue on the wt repository.
 
 If a message is provided, it will be used as the issue title.
-The issue form opens in your default browser.
+The issue form opens in your default browser, with the body pre-filled
+with diagnostics: wt version, OS/arch, git and gh versions, gh login
+status, and (inside a repo) the resolved config. Init commands, copied
+files, and env values are only counted, not included. Review and edit the
+form before submitting.
+
+For bug reports, re-run the failing command with --debug first: it writes
+a trace of the git and gh commands wt ran to ~/.config/wt/logs/, and
+wt feedback prints the newest log's path so you can attach it.
 
 Examples:
   wt feedback
@@ -38,16 +57,24 @@ func runFeedback(cmd *cobra.Command, args []string) error {
 		title = args[0]
 	}
 
+	if log := trace.Latest(); log != "" {
+		fmt.Printf("Latest debug log (attach it if it's relevant): %s\n", log)
+	} else {
+		ui.DimF("Reporting a bug? Re-run the failing command with --debug to capture a log to attach.\n")
+	}
 	fmt.Println("Opening GitHub issue form...")
 
+	body := feedbackBody()
 	if github.IsAvailable() {
-		return feedbackViaGH(title)
+		return feedbackViaGH(title, body)
 	}
-	return feedbackViaBrowser(title)
+	return feedbackViaBrowser(title, body)
 }
 
-func feedbackViaGH(title string) error {
-	args := []string{"issue", "create", "--web", "--repo", feedbackRepo}
+func feedbackViaGH(title, body string) error {
+	// HOST/OWNER/REPO: a bare OWNER/REPO would follow GH_HOST, which GitHub
+	// Enterprise users often set.
+	args := []string{"issue", "create", "--web", "--repo", feedbackHost + "/" + feedbackRepo, "--body", body}
 	if title != "" {
 		args = append(args, "--title", title)
 	}
@@ -58,20 +85,102 @@ func feedbackViaGH(title string) error {
 	return ghCmd.Run()
 }
 
-func feedbackViaBrowser(title string) error {
-	issueURL := "https://github.com/" + feedbackRepo + "/issues/new"
+func feedbackViaBrowser(title, body string) error {
+	q := url.Values{"body": {body}}
 	if title != "" {
-		issueURL += "?title=" + url.QueryEscape(title)
+		q.Set("title", title)
+	}
+	return openInBrowser("https://" + feedbackHost + "/" + feedbackRepo + "/issues/new?" + q.Encode())
+}
+
+// feedbackBody is the pre-filled issue body: a prompt for the report, then
+// the environment and, inside a repo, the resolved config.
+func feedbackBody() string {
+	gitVersion, err := git.Version()
+	if err != nil {
+		gitVersion = "unknown"
+	}
+	ghVersion := "not installed"
+	if github.IsAvailable() {
+		if v, err := github.Version(); err == nil {
+			ghVersion = v
+		} else {
+			ghVersion = "unknown"
+		}
+		if github.CheckAuth() == nil {
+			ghVersion += " (logged in)"
+		} else {
+			ghVersion += " (not logged in)"
+		}
 	}
+	env := [][2]string{
+		{"wt", Version},
+		{"os/arch", runtime.GOOS + "/" + runtime.GOARCH},
+		{"git", gitVersion},
+		{"gh", ghVersion},
+	}
+
+	var b strings.Builder
+	b.WriteString("<!-- What happened, and what did you expect? -->\n\n\n\n---\n\n")
+	b.WriteString("**Environment** (added by `wt feedback`; edit out anything you'd rather not share)\n\n")
+	b.WriteString(diagnosticBlock(env))
+	if ctx, err := newContext(); err == nil {
+		b.WriteString("\n**Config** (resolved for this repo)\n\n")
+		b.WriteString(diagnosticBlock(configDiagnostics(ctx.Config)))
+	}
+	return b.String()
+}
+
+// configDiagnostics lists cfg's effective settings for a bug report. Init
+// commands, copied files, and env are only counted: they can hold secrets.
+func configDiagnostics(cfg *config.Config) [][2]string {
+	orDefault := func(v, def string) string {
+		if v == "" {
+			return def
+		}
+		return v
+	}
+	prefix := "(from username_source = " + cfg.EffectiveUsernameSource() + ")"
+	if cfg.BranchPrefix != nil {
+		prefix = strconv.Quote(*cfg.BranchPrefix)
+	}
+	worktreePrefix := "(default)"
+	if cfg.WorktreePrefix != nil {
+		worktreePrefix = strconv.Quote(*cfg.WorktreePrefix)
+	}
+	hyperlinks := "auto"
+	if cfg.Hyperlinks != nil {
+		hyperlinks = strconv.FormatBool(*cfg.Hyperlinks)
+	}
+	return [][2]string{
+		{"base_branch", cfg.BaseBranch},
+		{"remote", cfg.Remote},
+		{"branch_prefix", prefix},
+		{"worktree_prefix", worktreePrefix},
+		{"worktree_root", orDefault(cfg.WorktreeRoot, "(default)")},
+		{"branch_template", orDefault(cfg.BranchTemplate, "(none)")},
+		{"worktree_template", orDefault(cfg.WorktreeTemplate, "(none)")},
+		{"stale_threshold", strconv.Itoa(cfg.EffectiveStaleThreshold())},
+		{"auto_install", strconv.FormatBool(cfg.EffectiveAutoInstall())},
+		{"fetch_on_new", strconv.FormatBool(cfg.EffectiveFetchOnNew())},
+		{"list_prs", strconv.FormatBool(cfg.EffectiveListPRs())},
+		{"gh_retries", strconv.Itoa(cfg.EffectiveGHRetries())},
+		{"pr_limit", strconv.Itoa(cfg.EffectivePRLimit())},
+		{"network_timeout", cfg.EffectiveNetworkTimeout().String()},
+		{"git_timeout", cfg.EffectiveGitTimeout().String()},
+		{"hyperlinks", hyperlinks},
+		{"init", fmt.Sprintf("%d command(s), %d copied file(s), %d env var(s), timeout %s",
+			len(cfg.Init.Commands), len(cfg.Init.CopyFiles), len(cfg.Init.Env), cfg.EffectiveInitTimeout())},
+	}
+}
 
-	var cmd *exec.Cmd
-	switch runtime.GOOS {
-	case "darwin":
-		cmd = exec.Command("open", issueURL)
-	case "linux":
-		cmd = exec.Command("xdg-open", issueURL)
-	default:
-		return fmt.Errorf("cannot open browser on %s — visit %s", runtime.GOOS, issueURL)
+// diagnosticBlock renders key/value rows as an aligned fenced code block.
+func diagnosticBlock(rows [][2]string) string {
+	var b strings.Builder
+	b.WriteString("```\n")
+	for _, r := range rows {
+		fmt.Fprintf(&b, "%-18s %s\n", r[0], r[1])
 	}
-	return cmd.Run()
+	b.WriteString("```\n")
+	return b.String()
 }
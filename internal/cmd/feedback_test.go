@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mvwi/wt/internal/config"
+)
+
+func TestConfigDiagnostics(t *testing.T) {
+	prefix := ""
+	cfg := &config.Config{
+		BaseBranch:   "staging",
+		Remote:       "origin",
+		BranchPrefix: &prefix,
+		Init: config.InitConfig{
+			Commands:  []config.InitCommand{{Run: "pnpm install --token=secret"}},
+			CopyFiles: []string{".env"},
+			Env:       map[string]string{"API_KEY": "secret"},
+		},
+	}
+	block := diagnosticBlock(configDiagnostics(cfg))
+
+	for _, want := range []string{
+		"base_branch        staging\n",
+		"branch_prefix      \"\"\n",
+		"worktree_root      (default)\n",
+		"stale_threshold    7\n",
+		"hyperlinks         auto\n",
+		"init               1 command(s), 1 copied file(s), 1 env var(s), timeout 10m0s\n",
+	} {
+		if !strings.Contains(block, want) {
+			t.Errorf("diagnostics missing %q:\n%s", want, block)
+		}
+	}
+	if strings.Contains(block, "secret") {
+		t.Errorf("diagnostics leak init commands or env:\n%s", block)
+	}
+	if !strings.HasPrefix(block, "```\n") || !strings.HasSuffix(block, "```\n") {
+		t.Errorf("diagnostics not fenced:\n%s", block)
+	}
+}
@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/mvwi/wt/internal/git"
+	"github.com/mvwi/wt/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var fetchCmd = &cobra.Command{
+	Use:     "fetch",
+	GroupID: groupSync,
+	Short:   "Fetch all remotes and show what changed",
+	Long: `Fetch all remotes, prune deleted branches, and list the remote
+branches that were added, updated, or deleted.
+
+Use --base to fetch only the base branch.`,
+	Example: `  wt fetch                 Fetch all remotes (with --prune)
+  wt fetch --base          Fetch only the base branch`,
+	Args: cobra.NoArgs,
+	RunE: runFetch,
+}
+
+var fetchBaseOnly bool
+
+func init() {
+	fetchCmd.Flags().BoolVar(&fetchBaseOnly, "base", false, "fetch only the base branch")
+	rootCmd.AddCommand(fetchCmd)
+}
+
+func runFetch(cmd *cobra.Command, args []string) error {
+	ctx, err := newContext()
+	if err != nil {
+		return err
+	}
+
+	before, err := git.RemoteRefs()
+	if err != nil {
+		return err
+	}
+
+	if fetchBaseOnly {
+		err = fetchWithSpinner(fmt.Sprintf("Fetching %s", ctx.baseRef()), ctx.Config.Remote, git.TrackingRefspec(ctx.Config.Remote, ctx.Config.BaseBranch))
+	} else {
+		err = fetchWithSpinner("Fetching all remotes", "--all", "--prune")
+	}
+	if err != nil {
+		return fmt.Errorf("fetch failed: %w", err)
+	}
+
+	after, err := git.RemoteRefs()
+	if err != nil {
+		return err
+	}
+
+	changes := git.DiffRefs(before, after)
+	if len(changes.Added)+len(changes.Updated)+len(changes.Deleted) == 0 {
+		ui.Success("Already up to date")
+		return nil
+	}
+
+	for _, ref := range changes.Added {
+		fmt.Printf("  %s %s %s\n", ui.Green("+"), ref, ui.Dim("(new)"))
+	}
+	for _, ref := range changes.Updated {
+		fmt.Printf("  %s %s %s\n", ui.Yellow("~"), ref, ui.Dim(shortSHA(before[ref])+".."+shortSHA(after[ref])))
+	}
+	for _, ref := range changes.Deleted {
+		fmt.Printf("  %s %s %s\n", ui.Red("-"), ref, ui.Dim("(deleted)"))
+	}
+	fmt.Println()
+	ui.Success("Fetched: %d new, %d updated, %d deleted", len(changes.Added), len(changes.Updated), len(changes.Deleted))
+	return nil
+}
+
+// fetchWithSpinner runs git fetch with args behind a spinner showing message,
+// followed by git's progress (objects received, percent) as it arrives.
+func fetchWithSpinner(message string, args ...string) error {
+	spin := ui.NewSpinner(message)
+	defer spin.Stop()
+	return git.FetchWithProgress(func(progress string) {
+		spin.SetMessage(message + " " + ui.Dash + " " + progress)
+	}, args...)
+}
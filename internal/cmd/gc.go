@@ -0,0 +1,191 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mvwi/wt/internal/git"
+	"github.com/mvwi/wt/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var gcCmd = &cobra.Command{
+	Use:     "gc",
+	GroupID: groupManage,
+	Short:   "Clean up worktree bookkeeping and find orphaned directories",
+	Long: `Clean up the mess a manual rm -rf or a half-finished removal leaves:
+
+  - Registered worktrees whose directory is gone are reported and dropped
+    from git's worktree list (git worktree prune). Locked ones are kept.
+  - Orphaned directories are found and offered for removal: checkouts in
+    the worktree area whose .git file points at this repo, but that git no
+    longer lists as worktrees.
+
+With --dry-run, reports both without changing anything. Unlike wt prune,
+gc never looks at PRs or removes a worktree git still knows about.`,
+	Example: `  wt gc                    Prune bookkeeping, offer to remove orphans
+  wt gc --dry-run          Report without changing anything
+  wt gc --yes              Also remove every orphan without prompts`,
+	Args: cobra.NoArgs,
+	RunE: runGC,
+}
+
+var gcDryRun bool
+
+// gcMaxDepth bounds how far below the worktree area gc looks for orphans:
+// names with slashes (team/feat) nest a level or two.
+const gcMaxDepth = 3
+
+func init() {
+	gcCmd.Flags().BoolVar(&gcDryRun, "dry-run", false, "report what would be cleaned up without changing anything")
+	rootCmd.AddCommand(gcCmd)
+}
+
+func runGC(cmd *cobra.Command, args []string) error {
+	ctx, err := newContext()
+	if err != nil {
+		return err
+	}
+	commonDir, err := git.CommonDir()
+	if err != nil {
+		return err
+	}
+	worktrees, err := git.ListWorktrees()
+	if err != nil {
+		return err
+	}
+
+	var missing []git.Worktree
+	registered := make(map[string]bool)
+	for _, wt := range worktrees {
+		registered[filepath.Clean(wt.Path)] = true
+		if !wt.Bare && (wt.Prunable || !isDir(wt.Path)) {
+			missing = append(missing, wt)
+		}
+	}
+
+	root, prefix := ctx.worktreeArea()
+	orphans := findOrphanedWorktrees(root, prefix, commonDir, registered)
+
+	if len(missing) == 0 && len(orphans) == 0 {
+		ui.Success("Nothing to clean up")
+		return nil
+	}
+
+	if len(missing) > 0 {
+		fmt.Println("Registered worktrees with missing directories:")
+		for _, wt := range missing {
+			note := "pruned"
+			switch {
+			case wt.Locked:
+				note = fmt.Sprintf("locked, kept %s wt unlock %s to let gc prune it", ui.Dash, ctx.shortName(wt.Path))
+			case gcDryRun:
+				note = "would be pruned"
+			}
+			fmt.Printf("  %s %s  %s\n", ui.Yellow("!"), wt.Path, ui.Dim(note))
+		}
+		if !gcDryRun {
+			git.PruneWorktrees()
+			for _, wt := range missing {
+				if !wt.Locked {
+					_ = git.DeleteWorktreeMeta(wt.Path)
+					_ = os.Remove(filepath.Dir(wt.Path)) // only if now empty
+				}
+			}
+		}
+		fmt.Println()
+	}
+
+	if len(orphans) > 0 {
+		fmt.Println("Orphaned directories (not registered with git):")
+		for _, dir := range orphans {
+			fmt.Printf("  %s %s\n", ui.Yellow("?"), dir)
+		}
+		fmt.Println()
+		if gcDryRun {
+			fmt.Println("No changes made (--dry-run)")
+			return nil
+		}
+
+		removed := 0
+		for _, dir := range orphans {
+			if !ui.Confirm(fmt.Sprintf("Delete %s and everything in it?", dir), false) {
+				continue
+			}
+			if err := os.RemoveAll(dir); err != nil {
+				ui.Warn("Could not remove %s: %v", dir, err)
+				continue
+			}
+			_ = os.Remove(filepath.Dir(dir)) // only if now empty
+			removed++
+		}
+		if removed > 0 {
+			ui.Success("Removed %d of %d orphaned directories", removed, len(orphans))
+		} else {
+			fmt.Println("Kept orphaned directories")
+		}
+		return nil
+	}
+
+	if gcDryRun {
+		fmt.Println("No changes made (--dry-run)")
+		return nil
+	}
+	ui.Success("Cleaned up worktree bookkeeping")
+	return nil
+}
+
+// worktreeArea returns where new worktrees are created: the directory
+// holding them and the prefix their first path element starts with (e.g.
+// ParentDir/wt-repo and "", or ParentDir and "wt-" with worktree_prefix).
+func (c *cmdContext) worktreeArea() (root, prefix string) {
+	const probe = "x"
+	path := filepath.Clean(c.worktreePath(probe))
+	return filepath.Dir(path), strings.TrimSuffix(filepath.Base(path), probe)
+}
+
+// findOrphanedWorktrees walks root (entries starting with prefix at the
+// top level, up to gcMaxDepth deep) for linked worktree checkouts whose
+// .git file points into commonDir's worktrees/ at an entry that no longer
+// exists, and that aren't in registered. Checking the pointer keeps other
+// repos' worktrees, which may share the directory, out of it; requiring the
+// entry to be gone means a worktree git still tracks (say, under a
+// symlinked path) is never called an orphan. Any directory with a .git
+// ends the walk down that branch.
+func findOrphanedWorktrees(root, prefix, commonDir string, registered map[string]bool) []string {
+	adminDir := filepath.Join(filepath.Clean(commonDir), "worktrees")
+	var orphans []string
+
+	var walk func(dir string, depth int)
+	walk = func(dir string, depth int) {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			return
+		}
+		for _, e := range entries {
+			if !e.IsDir() || (depth == 1 && !strings.HasPrefix(e.Name(), prefix)) {
+				continue
+			}
+			path := filepath.Join(dir, e.Name())
+			if registered[path] {
+				continue
+			}
+			if gitDir, ok := git.LinkedGitDir(path); ok {
+				if isSubpath(gitDir, adminDir) && !isDir(gitDir) {
+					orphans = append(orphans, path)
+				}
+				continue
+			}
+			if fileExists(filepath.Join(path, ".git")) {
+				continue // a repo of its own
+			}
+			if depth < gcMaxDepth {
+				walk(path, depth+1)
+			}
+		}
+	}
+	walk(filepath.Clean(root), 1)
+	return orphans
+}
@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/mvwi/wt/internal/config"
+)
+
+func TestFindOrphanedWorktrees(t *testing.T) {
+	base := t.TempDir()
+	commonDir := filepath.Join(base, "repo", ".git")
+	root := filepath.Join(base, "wt-repo")
+
+	// checkout makes a linked worktree checkout at rel whose .git file
+	// points to gitDir.
+	checkout := func(rel, gitDir string) string {
+		dir := filepath.Join(root, filepath.FromSlash(rel))
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, ".git"), []byte("gitdir: "+gitDir+"\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return dir
+	}
+	orphan := checkout("orphan", filepath.Join(commonDir, "worktrees", "orphan"))
+	nested := checkout("team/feat", filepath.Join(commonDir, "worktrees", "feat"))
+	live := checkout("live", filepath.Join(commonDir, "worktrees", "live"))
+	checkout("other", filepath.Join(base, "other", ".git", "worktrees", "other"))
+	// Known to git (its entry exists) even though it isn't in registered.
+	checkout("moved", filepath.Join(commonDir, "worktrees", "moved"))
+	if err := os.MkdirAll(filepath.Join(commonDir, "worktrees", "moved"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(root, "repo2", ".git"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	got := findOrphanedWorktrees(root, "", commonDir, map[string]bool{live: true})
+	slices.Sort(got)
+	want := []string{orphan, nested}
+	slices.Sort(want)
+	if !slices.Equal(got, want) {
+		t.Errorf("findOrphanedWorktrees() = %v, want %v", got, want)
+	}
+
+	if got := findOrphanedWorktrees(root, "wt-", commonDir, nil); len(got) != 0 {
+		t.Errorf("findOrphanedWorktrees() with prefix wt- = %v, want none", got)
+	}
+}
+
+func TestWorktreeArea(t *testing.T) {
+	parent := filepath.FromSlash("/src")
+	ctx := &cmdContext{Config: &config.Config{}, RepoName: "repo", ParentDir: parent}
+	if root, prefix := ctx.worktreeArea(); root != filepath.Join(parent, "wt-repo") || prefix != "" {
+		t.Errorf("worktreeArea() = %q, %q; want %q, \"\"", root, prefix, filepath.Join(parent, "wt-repo"))
+	}
+
+	wtPrefix := "wt-"
+	ctx.Config.WorktreePrefix = &wtPrefix
+	if root, prefix := ctx.worktreeArea(); root != parent || prefix != "wt-" {
+		t.Errorf("worktreeArea() = %q, %q; want %q, \"wt-\"", root, prefix, parent)
+	}
+}
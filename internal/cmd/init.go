@@ -1,13 +1,23 @@
 package cmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"io/fs"
+	"maps"
 	"os"
 	"os/exec"
+	"os/signal"
 	"path/filepath"
+	"slices"
+	"sort"
 	"strings"
+	"time"
 
+	"github.com/mattn/go-isatty"
+	"github.com/mvwi/wt/internal/config"
+	"github.com/mvwi/wt/internal/git"
 	"github.com/mvwi/wt/internal/ui"
 	"github.com/spf13/cobra"
 )
@@ -25,7 +35,8 @@ When [init] is configured in .wt.toml, runs exactly those steps:
 
 When no [init] section exists, auto-detects common patterns:
 
-  • Copies .env files found in the main worktree
+  • Copies .env and .env.* files found in the main worktree, and in
+    apps/*, packages/*, and services/* (not .env.example/.sample/.template)
   • Copies AI config (.claude, .cursorrules, .cursor/rules)
   • Detects package manager from lockfile and runs install
   • Detects Prisma schema and runs prisma generate
@@ -34,13 +45,37 @@ Example config (overrides auto-detection):
 
   [init]
   copy_files = [".env", ".env.local"]
-  commands = ["pnpm install", "npx prisma generate"]`,
+  commands = ["pnpm install", { name = "db", run = "npx prisma generate" }]
+
+Files that already exist in the worktree are left alone, and commands
+that already succeeded are skipped until the worktree's lockfiles change,
+so wt init is safe to run again. With --force, commands re-run and
+existing files are overwritten from the main worktree after a
+confirmation (skipped with --yes). Copied directories are merged: files
+are overwritten, extra files in the worktree are kept.
+
+--only and --skip pick commands by name (or, for unnamed commands, by
+the command itself). --only runs just those commands, even if up to date,
+and copies no files.`,
 	Example: `  wt init                  Initialize current worktree
+  wt init --force          Refresh copied files from the main worktree
+  wt init --only db        Re-run just the "db" command
+  wt init --skip db        Run everything except "db"
   wt new feature --init    Create worktree + initialize in one step`,
 	RunE: runInit,
 }
 
+var (
+	initForce bool
+	initOnly  []string
+	initSkip  []string
+)
+
 func init() {
+	initCmd.Flags().BoolVar(&initForce, "force", false, "overwrite existing files and re-run all commands")
+	initCmd.Flags().StringSliceVar(&initOnly, "only", nil, "run only these commands (by name)")
+	initCmd.Flags().StringSliceVar(&initSkip, "skip", nil, "skip these commands (by name)")
+	initCmd.MarkFlagsMutuallyExclusive("only", "skip")
 	rootCmd.AddCommand(initCmd)
 }
 
@@ -50,11 +85,13 @@ func runInit(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	cwd, err := os.Getwd()
+	// From a subdirectory, initialize the worktree it's in: files are
+	// copied to, commands run in, and metadata is keyed by its root.
+	top, err := git.TopLevel()
 	if err != nil {
-		return fmt.Errorf("cannot determine current directory: %w", err)
+		return fmt.Errorf("not in a git repository\n   Run this from inside a worktree")
 	}
-	return runInitIn(cwd, ctx)
+	return runInitIn(top, ctx)
 }
 
 // initStep records the outcome of a single initialization step.
@@ -66,7 +103,7 @@ type initStep struct {
 
 func runInitIn(dir string, ctx *cmdContext) error {
 	// Check if we're in the main repo
-	if dir == ctx.MainWorktree || isSubpath(dir, ctx.MainWorktree) {
+	if ctx.inMainWorktree(dir) {
 		return fmt.Errorf("you're in the main repo\n   Switch to a worktree first: wt switch <name>")
 	}
 
@@ -76,7 +113,11 @@ func runInitIn(dir string, ctx *cmdContext) error {
 
 	// Auto-detect when no [init] section is configured
 	if !configured {
-		copyFiles, commands = detectInit(ctx.MainWorktree)
+		var detected []string
+		copyFiles, detected = detectInit(ctx.ConfigDir)
+		for _, run := range detected {
+			commands = append(commands, config.InitCommand{Run: run})
+		}
 		if len(copyFiles) == 0 && len(commands) == 0 {
 			fmt.Println("Nothing to initialize — no [init] config and nothing detected.")
 			fmt.Println()
@@ -89,6 +130,54 @@ func runInitIn(dir string, ctx *cmdContext) error {
 		}
 	}
 
+	selected, err := selectInitCommands(commands, initOnly, initSkip)
+	if err != nil {
+		return err
+	}
+	if len(initOnly) > 0 {
+		copyFiles = nil
+	}
+
+	// Commands that succeeded before are current until the lockfiles change.
+	// Commands named with --only run regardless.
+	meta, _ := git.LoadWorktreeMeta(dir)
+	inputHash := lockfileHash(dir)
+	commandDone := func(command string) bool {
+		return !initForce && len(initOnly) == 0 && meta.Init[command] == inputHash
+	}
+
+	var selectedRuns []string
+	for i, c := range commands {
+		if selected[i] {
+			selectedRuns = append(selectedRuns, c.Run)
+		}
+	}
+	if !initForce && initUpToDate(ctx.ConfigDir, dir, copyFiles, selectedRuns, commandDone) {
+		ui.Success("Already initialized, nothing to do")
+		fmt.Printf("  %s\n", ui.Dim("wt init --force re-runs every step"))
+		return nil
+	}
+
+	// --force: confirm once for every file that would be overwritten
+	overwrite := false
+	if initForce {
+		var existing []string
+		for _, file := range copyFiles {
+			if fileExists(filepath.Join(ctx.ConfigDir, file)) && fileExists(filepath.Join(dir, file)) {
+				existing = append(existing, file)
+			}
+		}
+		if len(existing) > 0 {
+			ui.Warn("These will be overwritten from the main worktree:")
+			for _, file := range existing {
+				fmt.Printf("  %s\n", file)
+			}
+			fmt.Println()
+			overwrite = ui.Confirm("Overwrite?", false)
+			fmt.Println()
+		}
+	}
+
 	fmt.Println("Initializing worktree...")
 	fmt.Println()
 
@@ -96,14 +185,14 @@ func runInitIn(dir string, ctx *cmdContext) error {
 
 	// Step 1: Copy files/directories from main worktree
 	for _, file := range copyFiles {
-		src := filepath.Join(ctx.MainWorktree, file)
+		src := filepath.Join(ctx.ConfigDir, file)
 		dst := filepath.Join(dir, file)
 
 		if !fileExists(src) {
 			steps = append(steps, initStep{"copy " + file, "skip", "not found in main worktree"})
 			continue
 		}
-		if fileExists(dst) {
+		if fileExists(dst) && !overwrite {
 			steps = append(steps, initStep{"copy " + file, "skip", "already exists"})
 			continue
 		}
@@ -118,6 +207,8 @@ func runInitIn(dir string, ctx *cmdContext) error {
 			data, err := os.ReadFile(src)
 			if err != nil {
 				steps = append(steps, initStep{"copy " + file, "fail", err.Error()})
+			} else if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+				steps = append(steps, initStep{"copy " + file, "fail", err.Error()})
 			} else if err := os.WriteFile(dst, data, 0644); err != nil {
 				steps = append(steps, initStep{"copy " + file, "fail", err.Error()})
 			} else {
@@ -127,15 +218,48 @@ func runInitIn(dir string, ctx *cmdContext) error {
 	}
 
 	// Step 2: Run commands
-	for _, command := range commands {
-		fmt.Printf("  %s %s\n", ui.Dim("$"), command)
-		if err := runShellString(dir, command); err != nil {
-			steps = append(steps, initStep{"run " + command, "fail", err.Error()})
+	ran := false
+	var succeeded []string
+	for i, c := range commands {
+		label := "run " + c.Label()
+		switch {
+		case !selected[i] && len(initOnly) > 0:
+			continue
+		case !selected[i]:
+			steps = append(steps, initStep{label, "skip", "--skip"})
+			continue
+		case commandDone(c.Run):
+			steps = append(steps, initStep{label, "skip", "up to date"})
+			continue
+		}
+		fmt.Printf("  %s %s\n", ui.Dim("$"), c.Run)
+		if err := ctx.runInitCommand(dir, c.Run); err != nil {
+			if errors.Is(err, errInterrupted) {
+				return err
+			}
+			steps = append(steps, initStep{label, "fail", err.Error()})
+			delete(meta.Init, c.Run)
 		} else {
-			steps = append(steps, initStep{"run " + command, "ok", ""})
+			steps = append(steps, initStep{label, "ok", ""})
+			succeeded = append(succeeded, c.Run)
 		}
+		ran = true
 		fmt.Println()
 	}
+	if ran {
+		// Hash after the commands: installs often rewrite the lockfile, and
+		// the pre-run hash would make them look stale on the next init.
+		if len(succeeded) > 0 {
+			doneHash := lockfileHash(dir)
+			if meta.Init == nil {
+				meta.Init = make(map[string]string)
+			}
+			for _, run := range succeeded {
+				meta.Init[run] = doneHash
+			}
+		}
+		_ = git.SaveWorktreeMeta(dir, meta)
+	}
 
 	// Print summary
 	hasFailures := false
@@ -166,16 +290,53 @@ func runInitIn(dir string, ctx *cmdContext) error {
 	return nil
 }
 
+// selectInitCommands reports, per command, whether it runs under --only
+// and --skip. Names match a command's name, or an unnamed command's text.
+// Errors on names that match nothing, listing the ones that would.
+func selectInitCommands(commands []config.InitCommand, only, skip []string) ([]bool, error) {
+	labels := make([]string, len(commands))
+	for i, c := range commands {
+		labels[i] = c.Label()
+	}
+	for _, name := range append(slices.Clone(only), skip...) {
+		if !slices.Contains(labels, name) {
+			return nil, fmt.Errorf("no init command named %q\n   Commands: %s", name, strings.Join(labels, ", "))
+		}
+	}
+
+	selected := make([]bool, len(commands))
+	for i, label := range labels {
+		if len(only) > 0 {
+			selected[i] = slices.Contains(only, label)
+		} else {
+			selected[i] = !slices.Contains(skip, label)
+		}
+	}
+	return selected, nil
+}
+
+// initUpToDate reports whether wt init has nothing to do: every file to
+// copy is already in the worktree (or missing from main) and every command
+// is done.
+func initUpToDate(mainWorktree, dir string, copyFiles, commands []string, commandDone func(string) bool) bool {
+	for _, file := range copyFiles {
+		if fileExists(filepath.Join(mainWorktree, file)) && !fileExists(filepath.Join(dir, file)) {
+			return false
+		}
+	}
+	for _, command := range commands {
+		if !commandDone(command) {
+			return false
+		}
+	}
+	return true
+}
+
 // detectInit auto-detects initialization steps from the main worktree.
 // Returns env files to copy and install commands to run.
 func detectInit(mainWorktree string) (copyFiles []string, commands []string) {
 	// Detect .env files
-	envFiles := []string{".env", ".env.local", ".env.development", ".env.test"}
-	for _, f := range envFiles {
-		if fileExists(filepath.Join(mainWorktree, f)) {
-			copyFiles = append(copyFiles, f)
-		}
-	}
+	copyFiles = append(copyFiles, findEnvFiles(mainWorktree)...)
 
 	// Detect AI config files/directories
 	aiConfigs := []string{".claude", ".cursorrules", ".cursor/rules"}
@@ -205,12 +366,154 @@ func detectInit(mainWorktree string) (copyFiles []string, commands []string) {
 	return
 }
 
-func runShellString(dir, command string) error {
-	cmd := exec.Command("sh", "-c", command)
+// envAppDirs are monorepo directories whose immediate subdirectories
+// (apps/web, services/api, ...) are searched for .env files too.
+var envAppDirs = []string{"apps", "packages", "services"}
+
+// findEnvFiles returns the repo-relative paths of .env and .env.* files at
+// root and one level into each of envAppDirs' subdirectories, sorted.
+// Committed templates (.env.example, .env.sample, .env.template) are
+// skipped: they're already in every worktree.
+func findEnvFiles(root string) []string {
+	patterns := []string{"."}
+	for _, d := range envAppDirs {
+		patterns = append(patterns, filepath.Join(d, "*"))
+	}
+
+	var found []string
+	for _, pattern := range patterns {
+		for _, glob := range []string{".env", ".env.*"} {
+			matches, _ := filepath.Glob(filepath.Join(root, pattern, glob))
+			for _, m := range matches {
+				if isDir(m) || isEnvTemplate(filepath.Base(m)) {
+					continue
+				}
+				if rel, err := filepath.Rel(root, m); err == nil {
+					found = append(found, rel)
+				}
+			}
+		}
+	}
+	sort.Strings(found)
+	return found
+}
+
+// isEnvTemplate reports whether an env file name is a committed template.
+func isEnvTemplate(name string) bool {
+	for _, suffix := range []string{".example", ".sample", ".template"} {
+		if strings.HasSuffix(name, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// runInitCommand runs an init command in dir with initCommandEnv, killing
+// it after the configured init timeout.
+func (c *cmdContext) runInitCommand(dir, command string) error {
+	return runShellString(dir, command, initCommandEnv(c, dir), c.Config.EffectiveInitTimeout())
+}
+
+// initCommandEnv returns the environment for init commands: wt's own plus
+// WT_* variables describing the worktree, then the [init].env entries,
+// whose values may reference either (e.g. "app_${WT_WORKTREE_NAME}").
+func initCommandEnv(ctx *cmdContext, dir string) []string {
+	vars := [][2]string{
+		{"WT_WORKTREE", dir},
+		{"WT_WORKTREE_NAME", ctx.shortName(dir)},
+		{"WT_MAIN_WORKTREE", ctx.ConfigDir},
+		{"WT_REPO", ctx.RepoName},
+	}
+	lookup := func(name string) string {
+		for _, kv := range vars {
+			if kv[0] == name {
+				return kv[1]
+			}
+		}
+		return os.Getenv(name)
+	}
+
+	env := os.Environ()
+	for _, kv := range vars {
+		env = append(env, kv[0]+"="+kv[1])
+	}
+	for _, k := range slices.Sorted(maps.Keys(ctx.Config.Init.Env)) {
+		env = append(env, k+"="+os.Expand(ctx.Config.Init.Env[k], lookup))
+	}
+	return env
+}
+
+// errInterrupted is returned by runShellString when Ctrl-C stopped the
+// command. Callers stop rather than run the next command, and Execute exits
+// 130 as an uncaught interrupt would.
+var errInterrupted = errors.New("interrupted")
+
+// stdinIsTerminal reports whether wt's stdin is a terminal, which init
+// commands may prompt on. A var so tests can take either path.
+var stdinIsTerminal = func() bool {
+	return isatty.IsTerminal(os.Stdin.Fd()) || isatty.IsCygwinTerminal(os.Stdin.Fd())
+}
+
+// runShellString runs command with sh in dir, streaming its output. A nil
+// env inherits wt's environment; timeout <= 0 means no limit.
+func runShellString(dir, command string, env []string, timeout time.Duration) error {
+	runCtx, cancel := context.Background(), context.CancelFunc(func() {})
+	if timeout > 0 {
+		runCtx, cancel = context.WithTimeout(runCtx, timeout)
+	}
+	defer cancel()
+
+	cmd := exec.CommandContext(runCtx, "sh", "-c", command)
 	cmd.Dir = dir
+	cmd.Env = env
+	cmd.Stdin = os.Stdin
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
-	return cmd.Run()
+	// From a terminal, stay in its foreground process group: a command in a
+	// group of its own is stopped by SIGTTIN as soon as it prompts (npm's
+	// "Proceed? (Y/n)"). Otherwise run in its own process group (where the
+	// OS has them) so a timeout kills everything the shell started (npm and
+	// its children), not just the shell.
+	grouped := !stdinIsTerminal()
+	if grouped {
+		startInProcessGroup(cmd)
+	}
+	cmd.WaitDelay = time.Second
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+
+	// Catch Ctrl-C so wt outlives the command and can report the interrupt
+	// to the caller. Outside the terminal's process group the command no
+	// longer sees it, so pass it on.
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, interruptSignals...)
+	defer signal.Stop(sigs)
+	interrupted, done := make(chan struct{}), make(chan struct{})
+	go func() {
+		select {
+		case sig := <-sigs:
+			if grouped {
+				_ = signalProcessGroup(cmd, sig)
+			} else {
+				_ = cmd.Process.Signal(sig)
+			}
+			close(interrupted)
+		case <-done:
+		}
+	}()
+
+	err := cmd.Wait()
+	close(done)
+	select {
+	case <-interrupted:
+		return errInterrupted
+	default:
+	}
+	if errors.Is(runCtx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("timed out after %s; raise [init] timeout in .wt.toml", timeout)
+	}
+	return err
 }
 
 // copyDirRecursive copies a directory tree from src to dst, preserving permissions.
@@ -1,9 +1,16 @@
 package cmd
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/mvwi/wt/internal/config"
 )
 
 func TestDetectInit(t *testing.T) {
@@ -185,6 +192,49 @@ func TestDetectInit(t *testing.T) {
 	})
 }
 
+func TestFindEnvFiles(t *testing.T) {
+	t.Run("globs root env files and skips templates", func(t *testing.T) {
+		dir := t.TempDir()
+		for _, f := range []string{".env", ".env.production.local", ".env.staging", ".env.example", ".env.sample", ".env.template", ".envrc", "env.txt"} {
+			touch(t, dir, f)
+		}
+		mkdir(t, dir, ".env.d")
+
+		got := findEnvFiles(dir)
+
+		want := []string{".env", ".env.production.local", ".env.staging"}
+		if !slices.Equal(got, want) {
+			t.Errorf("findEnvFiles() = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("looks one level into app dirs", func(t *testing.T) {
+		dir := t.TempDir()
+		for _, d := range []string{"apps/web/nested", "services/api", "docs/site"} {
+			mkdir(t, dir, d)
+		}
+		touch(t, dir, "apps/web/.env.local")
+		touch(t, dir, "apps/web/.env.example")
+		touch(t, dir, "services/api/.env")
+		touch(t, dir, "apps/web/nested/.env") // too deep
+		touch(t, dir, "docs/site/.env")       // not an app dir
+		touch(t, dir, "apps/.env")            // the app dir itself isn't searched
+
+		got := findEnvFiles(dir)
+
+		want := []string{"apps/web/.env.local", "services/api/.env"}
+		if !slices.Equal(got, want) {
+			t.Errorf("findEnvFiles() = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("nothing found", func(t *testing.T) {
+		if got := findEnvFiles(t.TempDir()); len(got) != 0 {
+			t.Errorf("findEnvFiles() = %v, want none", got)
+		}
+	})
+}
+
 func TestCopyDirRecursive(t *testing.T) {
 	t.Run("copies nested files preserving structure", func(t *testing.T) {
 		src := t.TempDir()
@@ -272,3 +322,200 @@ func contains(slice []string, s string) bool {
 	}
 	return false
 }
+
+func TestLockfileHash(t *testing.T) {
+	dir := t.TempDir()
+	empty := lockfileHash(dir)
+	if empty != lockfileHash(t.TempDir()) {
+		t.Error("lockfileHash differs between two empty directories")
+	}
+
+	writeFile(t, filepath.Join(dir, "package-lock.json"), "v1")
+	// Unrelated files aren't inputs
+	touch(t, dir, "README.md")
+	v1 := lockfileHash(dir)
+	if v1 == empty {
+		t.Error("lockfileHash unchanged after adding a lockfile")
+	}
+
+	writeFile(t, filepath.Join(dir, "package-lock.json"), "v2")
+	if lockfileHash(dir) == v1 {
+		t.Error("lockfileHash unchanged after editing a lockfile")
+	}
+}
+
+func TestInitUpToDate(t *testing.T) {
+	main, dir := t.TempDir(), t.TempDir()
+	touch(t, main, ".env")
+	done := map[string]bool{"pnpm install": true}
+	commandDone := func(c string) bool { return done[c] }
+
+	if initUpToDate(main, dir, []string{".env"}, nil, commandDone) {
+		t.Error("up to date with a file still to copy")
+	}
+	touch(t, dir, ".env")
+	if !initUpToDate(main, dir, []string{".env", ".env.local"}, []string{"pnpm install"}, commandDone) {
+		t.Error("not up to date with files copied (or missing from main) and commands done")
+	}
+	if initUpToDate(main, dir, nil, []string{"pnpm install", "npx prisma generate"}, commandDone) {
+		t.Error("up to date with a command not yet run")
+	}
+}
+
+func TestSelectInitCommands(t *testing.T) {
+	commands := []config.InitCommand{
+		{Run: "pnpm install"},
+		{Name: "db", Run: "npx prisma generate"},
+		{Name: "seed", Run: "pnpm seed"},
+	}
+	tests := []struct {
+		name       string
+		only, skip []string
+		want       []bool
+	}{
+		{"everything by default", nil, nil, []bool{true, true, true}},
+		{"only by name", []string{"db"}, nil, []bool{false, true, false}},
+		{"only unnamed by command", []string{"pnpm install", "seed"}, nil, []bool{true, false, true}},
+		{"skip by name", nil, []string{"seed"}, []bool{true, true, false}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := selectInitCommands(commands, tt.only, tt.skip)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("selectInitCommands() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// A named command isn't addressable by its command text
+	for _, names := range [][]string{{"nope"}, {"npx prisma generate"}} {
+		if _, err := selectInitCommands(commands, names, nil); err == nil {
+			t.Errorf("selectInitCommands(only=%v) succeeded, want error", names)
+		}
+		if _, err := selectInitCommands(commands, nil, names); err == nil {
+			t.Errorf("selectInitCommands(skip=%v) succeeded, want error", names)
+		}
+	}
+}
+
+func TestRunShellString(t *testing.T) {
+	dir := t.TempDir()
+
+	t.Run("passes env", func(t *testing.T) {
+		out := filepath.Join(dir, "out")
+		env := append(os.Environ(), "WT_TEST_VAR=hello")
+		if err := runShellString(dir, `printf %s "$WT_TEST_VAR" > out`, env, 0); err != nil {
+			t.Fatal(err)
+		}
+		if data, _ := os.ReadFile(out); string(data) != "hello" {
+			t.Errorf("command saw WT_TEST_VAR=%q, want hello", data)
+		}
+	})
+
+	t.Run("kills on timeout", func(t *testing.T) {
+		start := time.Now()
+		err := runShellString(dir, "sleep 5", nil, 100*time.Millisecond)
+		if err == nil || !strings.Contains(err.Error(), "timed out") {
+			t.Errorf("runShellString() error = %v, want timeout", err)
+		}
+		if elapsed := time.Since(start); elapsed > 3*time.Second {
+			t.Errorf("runShellString() took %s, want it killed near the timeout", elapsed)
+		}
+	})
+
+	for _, terminal := range []bool{false, true} {
+		name := map[bool]string{false: "piped", true: "terminal"}[terminal]
+		t.Run("reads stdin/"+name, func(t *testing.T) {
+			stubStdinTerminal(t, terminal)
+			r, w, err := os.Pipe()
+			if err != nil {
+				t.Fatal(err)
+			}
+			origStdin := os.Stdin
+			os.Stdin = r
+			t.Cleanup(func() { os.Stdin = origStdin; r.Close() })
+			if _, err := w.WriteString("y\n"); err != nil {
+				t.Fatal(err)
+			}
+			w.Close()
+
+			out := filepath.Join(dir, "answer")
+			if err := runShellString(dir, `read answer && printf %s "$answer" > answer`, nil, 5*time.Second); err != nil {
+				t.Fatal(err)
+			}
+			if data, _ := os.ReadFile(out); string(data) != "y" {
+				t.Errorf("command read %q from stdin, want y", data)
+			}
+		})
+
+		t.Run("returns errInterrupted on Ctrl-C/"+name, func(t *testing.T) {
+			if runtime.GOOS == "windows" {
+				t.Skip("can't send os.Interrupt to a process on Windows")
+			}
+			stubStdinTerminal(t, terminal)
+			self, err := os.FindProcess(os.Getpid())
+			if err != nil {
+				t.Fatal(err)
+			}
+			// Give runShellString time to start catching interrupts
+			timer := time.AfterFunc(300*time.Millisecond, func() { _ = self.Signal(os.Interrupt) })
+			defer timer.Stop()
+			// A terminal's Ctrl-C reaches its whole foreground group; here
+			// only wt gets it and passes it to the shell, so exec sleep to
+			// have it land there.
+			command := "sleep 5"
+			if terminal {
+				command = "exec sleep 5"
+			}
+			start := time.Now()
+			if err := runShellString(dir, command, nil, 0); !errors.Is(err, errInterrupted) {
+				t.Errorf("runShellString() error = %v, want errInterrupted", err)
+			}
+			if elapsed := time.Since(start); elapsed > 3*time.Second {
+				t.Errorf("runShellString() took %s, want the interrupt to stop the command", elapsed)
+			}
+		})
+	}
+}
+
+// stubStdinTerminal makes runShellString treat stdin as a terminal or not
+// for the rest of the test.
+func stubStdinTerminal(t *testing.T, terminal bool) {
+	t.Helper()
+	orig := stdinIsTerminal
+	stdinIsTerminal = func() bool { return terminal }
+	t.Cleanup(func() { stdinIsTerminal = orig })
+}
+
+func TestInitCommandEnv(t *testing.T) {
+	t.Setenv("WT_TEST_HOME", "/home/me")
+	ctx := &cmdContext{
+		Config:       &config.Config{Init: config.InitConfig{Env: map[string]string{"DB": "app_${WT_WORKTREE_NAME}", "CACHE": "$WT_TEST_HOME/cache"}}},
+		RepoName:     "myapp",
+		MainWorktree: "/code/myapp",
+		ConfigDir:    "/code/myapp",
+	}
+	env := initCommandEnv(ctx, "/code/wt-myapp/sidebar")
+
+	// Later entries win, as with exec.Cmd.Env
+	got := make(map[string]string)
+	for _, kv := range env {
+		k, v, _ := strings.Cut(kv, "=")
+		got[k] = v
+	}
+	for k, want := range map[string]string{
+		"WT_WORKTREE":      "/code/wt-myapp/sidebar",
+		"WT_WORKTREE_NAME": "sidebar",
+		"WT_MAIN_WORKTREE": "/code/myapp",
+		"WT_REPO":          "myapp",
+		"DB":               "app_sidebar",
+		"CACHE":            "/home/me/cache",
+	} {
+		if got[k] != want {
+			t.Errorf("%s = %q, want %q", k, got[k], want)
+		}
+	}
+}
@@ -0,0 +1,31 @@
+//go:build !windows
+
+package cmd
+
+import (
+	"os"
+	"os/exec"
+	"syscall"
+)
+
+// interruptSignals are the signals runShellString passes on to an init
+// command and treats as the user stopping it.
+var interruptSignals = []os.Signal{os.Interrupt, syscall.SIGTERM}
+
+// startInProcessGroup makes cmd start in a process group of its own, which
+// its timeout kills as a whole.
+func startInProcessGroup(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	cmd.Cancel = func() error {
+		return syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+	}
+}
+
+// signalProcessGroup sends sig to every process in cmd's group.
+func signalProcessGroup(cmd *exec.Cmd, sig os.Signal) error {
+	s, ok := sig.(syscall.Signal)
+	if !ok {
+		s = syscall.SIGINT
+	}
+	return syscall.Kill(-cmd.Process.Pid, s)
+}
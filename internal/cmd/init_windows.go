@@ -0,0 +1,22 @@
+//go:build windows
+
+package cmd
+
+import (
+	"os"
+	"os/exec"
+)
+
+// interruptSignals are the signals runShellString passes on to an init
+// command and treats as the user stopping it.
+var interruptSignals = []os.Signal{os.Interrupt}
+
+// startInProcessGroup is a no-op on Windows: the command shares wt's
+// console, so Ctrl-C already reaches it, and a timeout kills the shell.
+func startInProcessGroup(cmd *exec.Cmd) {}
+
+// signalProcessGroup stops cmd. Windows can't deliver an interrupt to
+// another process, so it's killed.
+func signalProcessGroup(cmd *exec.Cmd, sig os.Signal) error {
+	return cmd.Process.Kill()
+}
@@ -1,6 +1,11 @@
 package cmd
 
-import "path/filepath"
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+)
 
 // lockfileMapping maps a lockfile name to its install command.
 type lockfileMapping struct {
@@ -33,3 +38,20 @@ func detectInstallCommand(dir string) (lockfile, command string) {
 	}
 	return "", ""
 }
+
+// lockfileHash hashes the known lockfiles present in dir, names and
+// contents, as the inputs wt init's commands depend on. A directory with no
+// lockfiles hashes to the same constant every time.
+func lockfileHash(dir string) string {
+	h := sha256.New()
+	for _, lf := range knownLockfiles {
+		data, err := os.ReadFile(filepath.Join(dir, lf.file))
+		if err != nil {
+			continue
+		}
+		h.Write([]byte(lf.file + "\x00"))
+		h.Write(data)
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
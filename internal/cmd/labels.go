@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mvwi/wt/internal/github"
+	"github.com/mvwi/wt/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var labelsCmd = &cobra.Command{
+	Use:     "labels [name]",
+	GroupID: groupWorkflow,
+	Short:   "Show or edit a worktree's PR labels",
+	Long: `Show the labels on a worktree's pull request, or add and remove them.
+
+Without a name, uses the current branch's PR. Without --add or --remove,
+prints the PR's current labels. Both flags take a comma-separated list and
+can be combined.
+
+Requires the GitHub CLI (gh) to be installed.`,
+	Example: `  wt labels                          Show the current PR's labels
+  wt labels --add bug,urgent         Add labels
+  wt labels sidebar --remove wip     Remove a label from sidebar's PR
+  wt labels --add ready --remove wip Swap labels in one call`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeWorktreeNames,
+	RunE:              runLabels,
+}
+
+var (
+	labelsAdd    []string
+	labelsRemove []string
+)
+
+func init() {
+	labelsCmd.Flags().StringSliceVar(&labelsAdd, "add", nil, "labels to add (comma-separated)")
+	labelsCmd.Flags().StringSliceVar(&labelsRemove, "remove", nil, "labels to remove (comma-separated)")
+	rootCmd.AddCommand(labelsCmd)
+}
+
+func runLabels(cmd *cobra.Command, args []string) error {
+	if !github.IsAvailable() {
+		return fmt.Errorf("gh CLI is required for this command (brew install gh)")
+	}
+	if err := github.CheckAuth(); err != nil {
+		return err
+	}
+
+	ctx, err := newContext()
+	if err != nil {
+		return err
+	}
+
+	var name string
+	if len(args) > 0 {
+		name = args[0]
+	}
+	pr, _, err := resolveTargetPR(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	if len(labelsAdd) == 0 && len(labelsRemove) == 0 {
+		if len(pr.Labels) == 0 {
+			ui.Info("PR #%d has no labels", pr.Number)
+			return nil
+		}
+		fmt.Printf("PR #%d labels:\n", pr.Number)
+		for _, l := range pr.Labels {
+			fmt.Printf("  %s\n", l.Name)
+		}
+		return nil
+	}
+
+	if err := github.EditPRLabels(pr.Number, labelsAdd, labelsRemove); err != nil {
+		return fmt.Errorf("failed to edit labels on PR #%d: %w", pr.Number, err)
+	}
+	if len(labelsAdd) > 0 {
+		ui.Success("Added %s to PR #%d", strings.Join(labelsAdd, ", "), pr.Number)
+	}
+	if len(labelsRemove) > 0 {
+		ui.Success("Removed %s from PR #%d", strings.Join(labelsRemove, ", "), pr.Number)
+	}
+	return nil
+}
@@ -2,10 +2,15 @@ package cmd
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"maps"
 	"os"
+	"slices"
+	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/mvwi/wt/internal/git"
 	"github.com/mvwi/wt/internal/github"
@@ -24,10 +29,32 @@ Output is in two phases:
   1. Worktree names and branches (instant)
   2. PR status table with reviews and CI (requires GitHub API)
 
-Use --json for machine-readable output (all data in one pass).`,
+Use --json for machine-readable output (all data in one pass).
+
+With --no-pr (or WT_NO_PR=1, or list_prs = false in config), skips
+GitHub entirely and shows only phase 1: no PR fetch, no spinner. Useful
+offline and in prompt integrations.
+
+With --wide, phase 1 also shows each feature branch's size: commits ahead
+of the base branch and lines added/removed since it branched off. JSON and
+toon output always include them.
+
+With --explain, prints why each feature worktree is or isn't stale instead
+of the tables: days since its last activity, the stale_threshold, whether
+it has an open PR, and the verdicts for wt list (dimmed) and wt prune
+(removed when its PR was merged or closed).
+
+If some PR data can't be fetched, wt list says which and keeps going.
+When the open PRs couldn't be fetched, affected branches show "?" in the
+PR column rather than looking PR-less (and aren't dimmed as stale); JSON
+output sets pr_unknown. --verbose prints the underlying errors.`,
 	Example: `  wt list                 Show all worktrees with status
   wt list --output json    Machine-readable JSON output
-  wt list --output toon    Flat YAML-like output for agents`,
+  wt list --output toon    Flat YAML-like output for agents
+  wt list --no-pr          Worktrees only, without GitHub calls
+  wt list --wide           Also show commits and lines changed per branch
+  wt list --explain        Show why each worktree is or isn't stale
+  wt list --verbose        Show why PR data is incomplete, if it is`,
 	RunE: runList,
 }
 
@@ -35,6 +62,11 @@ func init() {
 	listCmd.Flags().String("output", "", "Output format: json, toon")
 	listCmd.Flags().Bool("json", false, "Output as JSON")
 	_ = listCmd.Flags().MarkDeprecated("json", "use --output json instead")
+	listCmd.Flags().Bool("no-pr", false, "skip fetching PR data from GitHub")
+	listCmd.Flags().Bool("wide", false, "show commits ahead and lines added/removed per branch")
+	listCmd.Flags().Bool("explain", false, "explain each worktree's staleness verdict")
+	listCmd.MarkFlagsMutuallyExclusive("explain", "output")
+	listCmd.MarkFlagsMutuallyExclusive("explain", "json")
 	rootCmd.AddCommand(listCmd)
 }
 
@@ -42,18 +74,43 @@ type worktreeInfo struct {
 	Path       string
 	ShortName  string
 	Branch     string
+	Head       string
+	Detached   bool // Branch is empty; see branchLabel
 	IsCurrent  bool
 	Age        string
 	Behind     int
 	Ahead      int
 	DirtyCount int
+	Additions  int // lines added since the branch left base; only with stats
+	Deletions  int
+}
+
+// branchLabel is the branch for display: the short SHA in parentheses for
+// a detached HEAD, e.g. a wt pr --detached review worktree.
+func (w worktreeInfo) branchLabel() string {
+	if w.Detached {
+		return "(" + shortSHA(w.Head) + ")"
+	}
+	return w.Branch
+}
+
+// prMatchHead returns the commit SHA to match merged/closed PRs against when
+// the branch name doesn't match (e.g. the branch was renamed). Empty when the
+// branch has no commits beyond base: a fresh branch sitting on base's tip
+// could otherwise match a fast-forward-merged PR that isn't its own.
+func (w worktreeInfo) prMatchHead() string {
+	if w.Ahead == 0 {
+		return ""
+	}
+	return w.Head
 }
 
 // JSON output structs
 
 type listJSONOutput struct {
-	Worktrees []listJSONEntry `json:"worktrees"`
-	CTA       []string        `json:"cta,omitempty"`
+	Worktrees []listJSONEntry    `json:"worktrees"`
+	Archived  []listJSONArchived `json:"archived,omitempty"`
+	CTA       []string           `json:"cta,omitempty"`
 }
 
 type listJSONReview struct {
@@ -80,13 +137,19 @@ type listJSONEntry struct {
 	Name       string      `json:"name"`
 	Path       string      `json:"path"`
 	Branch     string      `json:"branch"`
+	Detached   bool        `json:"detached,omitempty"`
+	Head       string      `json:"head,omitempty"` // only for detached worktrees, whose branch is ""
 	Current    bool        `json:"current"`
 	BaseBranch bool        `json:"base_branch"`
 	Age        string      `json:"age,omitempty"`
 	Dirty      int         `json:"dirty"`
 	Behind     int         `json:"behind"`
 	Ahead      int         `json:"ahead"`
+	Commits    int         `json:"commits"`
+	Additions  int         `json:"additions"`
+	Deletions  int         `json:"deletions"`
 	PR         *listJSONPR `json:"pr"`
+	PRUnknown  bool        `json:"pr_unknown,omitempty"` // open PR couldn't be fetched; a null pr proves nothing
 }
 
 func runList(cmd *cobra.Command, args []string) error {
@@ -110,12 +173,21 @@ func runList(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	worktrees = withoutBare(worktrees)
+
+	noPR, _ := cmd.Flags().GetBool("no-pr")
+	withPRs := listWithPRs(ctx, noPR)
+	wide, _ := cmd.Flags().GetBool("wide")
+
+	if explain, _ := cmd.Flags().GetBool("explain"); explain {
+		return runListExplain(ctx, cwd, worktrees, withPRs, verbose)
+	}
 
 	switch outputFormat {
 	case "json":
-		return runListJSON(ctx, cwd, worktrees)
+		return runListJSON(ctx, cwd, worktrees, withPRs)
 	case "toon":
-		return runListTOON(ctx, cwd, worktrees)
+		return runListTOON(ctx, cwd, worktrees, withPRs)
 	}
 
 	if len(worktrees) == 0 {
@@ -123,11 +195,28 @@ func runList(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
-	return runListTerminal(ctx, cwd, worktrees)
+	return runListTerminal(ctx, cwd, worktrees, withPRs, wide, verbose)
+}
+
+// listWithPRs reports whether wt list should fetch PR data. --no-pr wins,
+// then WT_NO_PR, then the list_prs config; gh must be available either way.
+func listWithPRs(ctx *cmdContext, noPR bool) bool {
+	if noPR {
+		return false
+	}
+	if v, err := strconv.ParseBool(os.Getenv("WT_NO_PR")); err == nil {
+		if v {
+			return false
+		}
+	} else if !ctx.Config.EffectiveListPRs() {
+		return false
+	}
+	return github.IsAvailable()
 }
 
-// collectWorktreeInfos gathers branch/status data for all worktrees.
-func collectWorktreeInfos(ctx *cmdContext, cwd string, worktrees []git.Worktree) ([]worktreeInfo, []string) {
+// collectWorktreeInfos gathers branch/status data for all worktrees. With
+// withStats, feature branches also get their diff size against base.
+func collectWorktreeInfos(ctx *cmdContext, cwd string, worktrees []git.Worktree, withStats bool) ([]worktreeInfo, []string) {
 	var infos []worktreeInfo
 	var featureBranches []string
 
@@ -135,7 +224,7 @@ func collectWorktreeInfos(ctx *cmdContext, cwd string, worktrees []git.Worktree)
 		short := ctx.shortName(wt.Path)
 
 		branch := wt.Branch
-		if branch == "" {
+		if branch == "" && !wt.Detached {
 			branch, _ = git.CurrentBranchIn(wt.Path)
 		}
 
@@ -145,6 +234,8 @@ func collectWorktreeInfos(ctx *cmdContext, cwd string, worktrees []git.Worktree)
 			Path:      wt.Path,
 			ShortName: short,
 			Branch:    branch,
+			Head:      wt.Head,
+			Detached:  wt.Detached,
 			IsCurrent: isCurrent,
 		}
 
@@ -160,7 +251,15 @@ func collectWorktreeInfos(ctx *cmdContext, cwd string, worktrees []git.Worktree)
 				info.Behind = ab.Behind
 				info.Ahead = ab.Ahead
 			}
-			featureBranches = append(featureBranches, branch)
+			if withStats {
+				if stats, err := git.DiffStatsIn(wt.Path, ctx.baseRef()); err == nil {
+					info.Additions = stats.Additions
+					info.Deletions = stats.Deletions
+				}
+			}
+			if !wt.Detached {
+				featureBranches = append(featureBranches, branch)
+			}
 		}
 
 		infos = append(infos, info)
@@ -169,26 +268,232 @@ func collectWorktreeInfos(ctx *cmdContext, cwd string, worktrees []git.Worktree)
 	return infos, featureBranches
 }
 
-// fetchPRData fetches open, merged, and closed PRs in parallel.
-func fetchPRData() (openPRs, mergedPRs, closedPRs []github.PR, hasErrors bool) {
-	var openErr, mergedErr, closedErr error
+// prFetchError records which parts of fetchPRData failed, so callers can
+// tell "no PR" apart from "couldn't find out".
+type prFetchError struct {
+	Open, Merged, Closed error
+	Branches             map[string]error // failed per-branch lookups
+}
+
+func (e *prFetchError) Error() string {
+	var msgs []string
+	for _, part := range []struct {
+		what string
+		err  error
+	}{{"open PRs", e.Open}, {"merged PRs", e.Merged}, {"closed PRs", e.Closed}} {
+		if part.err != nil {
+			msgs = append(msgs, fmt.Sprintf("%s: %v", part.what, part.err))
+		}
+	}
+	for _, b := range slices.Sorted(maps.Keys(e.Branches)) {
+		msgs = append(msgs, fmt.Sprintf("PRs for %s: %v", b, e.Branches[b]))
+	}
+	return strings.Join(msgs, "\n")
+}
+
+func (e *prFetchError) Unwrap() []error {
+	errs := []error{e.Open, e.Merged, e.Closed}
+	for _, err := range e.Branches {
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+// openUnknown reports whether err means branch's open PR couldn't be
+// fetched, so its absence from the open list proves nothing.
+func openUnknown(err error, branch string) bool {
+	var fe *prFetchError
+	if !errors.As(err, &fe) {
+		return false
+	}
+	return fe.Open != nil || fe.Branches[branch] != nil
+}
+
+// fetchPRData fetches open, merged, and closed PRs in parallel. When a
+// listing hits github.ListLimit, branches it doesn't cover are looked up by
+// head name so busy repos don't lose PRs past the cap. A non-nil err is a
+// *prFetchError; whatever did succeed is still returned. With no branches
+// there's nothing to match PRs to, so gh isn't called.
+func fetchPRData(branches []string) (openPRs, mergedPRs, closedPRs []github.PR, err error) {
+	if len(branches) == 0 {
+		return nil, nil, nil, nil
+	}
+	var fe prFetchError
 	var wg sync.WaitGroup
 	wg.Add(3)
-	go func() { defer wg.Done(); openPRs, openErr = github.ListPRs("open") }()
-	go func() { defer wg.Done(); mergedPRs, mergedErr = github.ListPRs("merged") }()
-	go func() { defer wg.Done(); closedPRs, closedErr = github.ListPRs("closed") }()
+	go func() { defer wg.Done(); openPRs, fe.Open = github.ListPRs("open") }()
+	go func() { defer wg.Done(); mergedPRs, fe.Merged = github.ListPRs("merged") }()
+	go func() { defer wg.Done(); closedPRs, fe.Closed = github.ListPRs("closed") }()
 	wg.Wait()
-	hasErrors = openErr != nil || mergedErr != nil || closedErr != nil
+	defer func() {
+		if fe.Open != nil || fe.Merged != nil || fe.Closed != nil || len(fe.Branches) > 0 {
+			err = &fe
+		}
+	}()
+	if errors.Is(&fe, github.ErrNotAuthenticated) {
+		return
+	}
+
+	missing := branchesMissingPRs(branches, openPRs, mergedPRs, closedPRs)
+	if len(missing) == 0 {
+		return
+	}
+	tracer.Printf("PR listing capped at %d; looking up %d branch(es) by head", github.ListLimit, len(missing))
+	var found []github.PR
+	found, fe.Branches = listPRsForBranches(missing)
+	openPRs, mergedPRs, closedPRs = addPRsByState(found, openPRs, mergedPRs, closedPRs)
 	return
 }
 
-func runListJSON(ctx *cmdContext, cwd string, worktrees []git.Worktree) error {
-	infos, _ := collectWorktreeInfos(ctx, cwd, worktrees)
+// addPRsByState appends each PR in found to the list for its state, skipping
+// ones already there.
+func addPRsByState(found, openPRs, mergedPRs, closedPRs []github.PR) (open, merged, closed []github.PR) {
+	for _, pr := range found {
+		switch pr.State {
+		case "OPEN":
+			openPRs = appendNewPR(openPRs, pr)
+		case "MERGED":
+			mergedPRs = appendNewPR(mergedPRs, pr)
+		case "CLOSED":
+			closedPRs = appendNewPR(closedPRs, pr)
+		}
+	}
+	return openPRs, mergedPRs, closedPRs
+}
+
+// completeDonePRs is fetchPRData's cap fallback for merged and closed PRs
+// alone, for prune and close --all-merged. Branches whose lookup failed
+// are reported in the error.
+func completeDonePRs(branches []string, mergedPRs, closedPRs []github.PR) (merged, closed []github.PR, err error) {
+	missing := branchesMissingPRs(branches, nil, mergedPRs, closedPRs)
+	if len(missing) == 0 {
+		return mergedPRs, closedPRs, nil
+	}
+	tracer.Printf("PR listing capped at %d; looking up %d branch(es) by head", github.ListLimit, len(missing))
+	found, failed := listPRsForBranches(missing)
+	_, mergedPRs, closedPRs = addPRsByState(found, nil, mergedPRs, closedPRs)
+	if len(failed) > 0 {
+		err = &prFetchError{Branches: failed}
+	}
+	return mergedPRs, closedPRs, err
+}
+
+// branchesMissingPRs returns the branches a capped PR listing may have
+// missed: those with no open PR when the open list hit the cap, or no merged
+// or closed PR when either of those did.
+func branchesMissingPRs(branches []string, openPRs, mergedPRs, closedPRs []github.PR) []string {
+	openCapped := github.Truncated(openPRs)
+	doneCapped := github.Truncated(mergedPRs) || github.Truncated(closedPRs)
+	var missing []string
+	for _, b := range branches {
+		noOpen := github.FindPRForBranch(openPRs, b) == nil
+		noDone := github.FindPRForBranch(mergedPRs, b) == nil && github.FindPRForBranch(closedPRs, b) == nil
+		if (openCapped && noOpen) || (doneCapped && noDone) {
+			missing = append(missing, b)
+		}
+	}
+	return missing
+}
+
+// prLookupConcurrency bounds the gh searches listPRsForBranches runs at
+// once.
+const prLookupConcurrency = 4
+
+// listPRsForBranches looks up every PR for branches, github.SearchBatch
+// branches per gh call and a few calls at a time. Returns the PRs found and
+// the failed lookups by branch.
+func listPRsForBranches(branches []string) ([]github.PR, map[string]error) {
+	var batches [][]string
+	for b := range slices.Chunk(branches, github.SearchBatch) {
+		batches = append(batches, b)
+	}
+	results := make([][]github.PR, len(batches))
+	errs := make([]error, len(batches))
+	sem := make(chan struct{}, prLookupConcurrency)
+	var wg sync.WaitGroup
+	for i, batch := range batches {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			results[i], errs[i] = github.ListPRsForBranches(batch)
+		}()
+	}
+	wg.Wait()
+	var prs []github.PR
+	var failed map[string]error
+	for i, r := range results {
+		prs = append(prs, r...)
+		if errs[i] == nil {
+			continue
+		}
+		if failed == nil {
+			failed = make(map[string]error)
+		}
+		for _, b := range batches[i] {
+			failed[b] = errs[i]
+		}
+	}
+	return prs, failed
+}
+
+// appendNewPR appends pr unless a PR with the same number is already listed.
+func appendNewPR(prs []github.PR, pr github.PR) []github.PR {
+	for _, p := range prs {
+		if p.Number == pr.Number {
+			return prs
+		}
+	}
+	return append(prs, pr)
+}
+
+// prBranches returns the branches wt list looks up PRs for: every checked-out
+// branch except the base branches.
+func prBranches(ctx *cmdContext, worktrees []git.Worktree) []string {
+	var branches []string
+	for _, wt := range worktrees {
+		if wt.Branch != "" && !ctx.isBaseBranch(wt.Branch) {
+			branches = append(branches, wt.Branch)
+		}
+	}
+	return branches
+}
+
+type listJSONArchived struct {
+	Branch     string `json:"branch"`
+	Tag        string `json:"tag,omitempty"`
+	ArchivedAt string `json:"archived_at"`
+}
+
+// listArchived returns branches shelved by wt archive that still exist and
+// haven't been checked out into a worktree again.
+func listArchived(worktrees []git.Worktree) []git.ArchivedBranch {
+	archived, err := git.ListArchivedBranches()
+	if err != nil {
+		return nil
+	}
+	checkedOut := make(map[string]bool)
+	for _, wt := range worktrees {
+		checkedOut[wt.Branch] = true
+	}
+	var live []git.ArchivedBranch
+	for _, a := range archived {
+		if !checkedOut[a.Branch] && git.BranchExists(a.Branch) {
+			live = append(live, a)
+		}
+	}
+	return live
+}
+
+func runListJSON(ctx *cmdContext, cwd string, worktrees []git.Worktree, withPRs bool) error {
+	infos, _ := collectWorktreeInfos(ctx, cwd, worktrees, true)
 
 	// Fetch PR data (no spinner, no terminal output)
 	var openPRs, mergedPRs, closedPRs []github.PR
-	if github.IsAvailable() {
-		openPRs, mergedPRs, closedPRs, _ = fetchPRData()
+	var prErr error
+	if withPRs {
+		openPRs, mergedPRs, closedPRs, prErr = fetchPRData(prBranches(ctx, worktrees))
 	}
 
 	entries := make([]listJSONEntry, 0, len(infos))
@@ -201,16 +506,25 @@ func runListJSON(ctx *cmdContext, cwd string, worktrees []git.Worktree) error {
 			Name:       info.ShortName,
 			Path:       info.Path,
 			Branch:     info.Branch,
+			Detached:   info.Detached,
 			Current:    info.IsCurrent,
 			BaseBranch: isBase,
 			Age:        info.Age,
 			Dirty:      info.DirtyCount,
 			Behind:     info.Behind,
 			Ahead:      info.Ahead,
+			Commits:    info.Ahead,
+			Additions:  info.Additions,
+			Deletions:  info.Deletions,
+		}
+
+		if info.Detached {
+			entry.Head = info.Head
 		}
 
 		if !isBase {
-			entry.PR = findPRJSON(info.Branch, openPRs, mergedPRs, closedPRs)
+			entry.PR = findPRJSON(info.Branch, info.prMatchHead(), openPRs, mergedPRs, closedPRs)
+			entry.PRUnknown = (entry.PR == nil || entry.PR.State != "open") && openUnknown(prErr, info.Branch)
 			if info.Behind > 0 {
 				hasBehind = true
 			}
@@ -222,7 +536,20 @@ func runListJSON(ctx *cmdContext, cwd string, worktrees []git.Worktree) error {
 		entries = append(entries, entry)
 	}
 
-	data, err := json.MarshalIndent(listJSONOutput{Worktrees: entries, CTA: deriveListCTA(hasStale, hasBehind)}, "", "  ")
+	var archived []listJSONArchived
+	for _, a := range listArchived(worktrees) {
+		archived = append(archived, listJSONArchived{
+			Branch:     a.Branch,
+			Tag:        a.Tag,
+			ArchivedAt: a.ArchivedAt.Format(time.RFC3339),
+		})
+	}
+
+	data, err := json.MarshalIndent(listJSONOutput{
+		Worktrees: entries,
+		Archived:  archived,
+		CTA:       deriveListCTA(hasStale, hasBehind),
+	}, "", "  ")
 	if err != nil {
 		return err
 	}
@@ -230,12 +557,13 @@ func runListJSON(ctx *cmdContext, cwd string, worktrees []git.Worktree) error {
 	return nil
 }
 
-func runListTOON(ctx *cmdContext, cwd string, worktrees []git.Worktree) error {
-	infos, _ := collectWorktreeInfos(ctx, cwd, worktrees)
+func runListTOON(ctx *cmdContext, cwd string, worktrees []git.Worktree, withPRs bool) error {
+	infos, _ := collectWorktreeInfos(ctx, cwd, worktrees, true)
 
 	var openPRs, mergedPRs, closedPRs []github.PR
-	if github.IsAvailable() {
-		openPRs, mergedPRs, closedPRs, _ = fetchPRData()
+	var prErr error
+	if withPRs {
+		openPRs, mergedPRs, closedPRs, prErr = fetchPRData(prBranches(ctx, worktrees))
 	}
 
 	hasBehind := false
@@ -247,6 +575,10 @@ func runListTOON(ctx *cmdContext, cwd string, worktrees []git.Worktree) error {
 		fmt.Printf("- name: %s\n", info.ShortName)
 		fmt.Printf("  path: %s\n", info.Path)
 		fmt.Printf("  branch: %s\n", info.Branch)
+		if info.Detached {
+			fmt.Printf("  detached: true\n")
+			fmt.Printf("  head: %s\n", info.Head)
+		}
 		fmt.Printf("  current: %v\n", info.IsCurrent)
 		if isBase {
 			fmt.Printf("  base_branch: true\n")
@@ -257,11 +589,19 @@ func runListTOON(ctx *cmdContext, cwd string, worktrees []git.Worktree) error {
 		fmt.Printf("  dirty: %d\n", info.DirtyCount)
 		fmt.Printf("  behind: %d\n", info.Behind)
 		fmt.Printf("  ahead: %d\n", info.Ahead)
+		if !isBase {
+			fmt.Printf("  commits: %d\n", info.Ahead)
+			fmt.Printf("  additions: %d\n", info.Additions)
+			fmt.Printf("  deletions: %d\n", info.Deletions)
+		}
 		if info.Behind > 0 {
 			hasBehind = true
 		}
 		if !isBase {
-			pr := findPRJSON(info.Branch, openPRs, mergedPRs, closedPRs)
+			pr := findPRJSON(info.Branch, info.prMatchHead(), openPRs, mergedPRs, closedPRs)
+			if (pr == nil || pr.State != "open") && openUnknown(prErr, info.Branch) {
+				fmt.Printf("  pr.unknown: true\n")
+			}
 			if pr != nil {
 				fmt.Printf("  pr.number: %d\n", pr.Number)
 				fmt.Printf("  pr.state: %s\n", pr.State)
@@ -292,7 +632,8 @@ func deriveListCTA(hasStale, hasBehind bool) []string {
 }
 
 // findPRJSON finds the PR for a branch and converts it to JSON format.
-func findPRJSON(branch string, openPRs, mergedPRs, closedPRs []github.PR) *listJSONPR {
+// head, if set, is the fallback SHA for matching merged/closed PRs.
+func findPRJSON(branch, head string, openPRs, mergedPRs, closedPRs []github.PR) *listJSONPR {
 	if pr := github.FindPRForBranch(openPRs, branch); pr != nil {
 		rs := pr.GetReviewSummary()
 		cs := pr.GetCISummary()
@@ -303,69 +644,107 @@ func findPRJSON(branch string, openPRs, mergedPRs, closedPRs []github.PR) *listJ
 			CI:     listJSONCI{Pass: cs.Pass, Fail: cs.Fail, Pending: cs.Pending, Total: cs.Total},
 		}
 	}
-	if pr := github.FindPRForBranch(mergedPRs, branch); pr != nil {
+	if pr := github.FindPRForBranchOrHead(mergedPRs, branch, head); pr != nil {
 		return &listJSONPR{Number: pr.Number, State: "merged"}
 	}
-	if pr := github.FindPRForBranch(closedPRs, branch); pr != nil {
+	if pr := github.FindPRForBranchOrHead(closedPRs, branch, head); pr != nil {
 		return &listJSONPR{Number: pr.Number, State: "closed"}
 	}
 	return nil
 }
 
-func runListTerminal(ctx *cmdContext, cwd string, worktrees []git.Worktree) error {
-	infos, featureBranches := collectWorktreeInfos(ctx, cwd, worktrees)
+// prFetchResult carries fetchPRData's results across a goroutine boundary.
+type prFetchResult struct {
+	open, merged, closed []github.PR
+	err                  error
+}
 
-	// Phase 1: Show worktree names immediately
-	ui.Header("WORKTREES")
+// fetchPRDataAsync starts fetchPRData in the background and returns a channel
+// that receives the result exactly once. Lets network-bound PR fetching overlap
+// with disk-bound worktree scanning.
+func fetchPRDataAsync(branches []string) <-chan prFetchResult {
+	ch := make(chan prFetchResult, 1)
+	go func() {
+		var r prFetchResult
+		r.open, r.merged, r.closed, r.err = fetchPRData(branches)
+		ch <- r
+	}()
+	return ch
+}
 
-	// Calculate column width from longest short name
-	nameWidth := 0
-	for _, info := range infos {
-		if len(info.ShortName) > nameWidth {
-			nameWidth = len(info.ShortName)
-		}
+// warnPRFetchError explains a fetchPRData failure: which data is missing
+// and, with verbose, the underlying errors.
+func warnPRFetchError(err error, verbose bool) {
+	var fe *prFetchError
+	switch {
+	case err == nil:
+		return
+	case errors.Is(err, github.ErrNotAuthenticated):
+		ui.Warn("%v", github.ErrNotAuthenticated)
+		return
+	case errors.As(err, &fe) && fe.Open != nil:
+		ui.Warn("Could not fetch open PRs — PR status shown as ? where unknown")
+	default:
+		ui.Warn("Could not fetch some PR data — status may be incomplete")
+	}
+	if !verbose {
+		ui.DimF("   Run with --verbose for details\n")
+		return
+	}
+	for _, line := range strings.Split(err.Error(), "\n") {
+		ui.DimF("   %s\n", line)
 	}
-	if nameWidth < 8 {
-		nameWidth = 8
+}
+
+func runListTerminal(ctx *cmdContext, cwd string, worktrees []git.Worktree, withPRs, wide, verbose bool) error {
+	// Kick off the PR fetch before scanning worktrees so both run concurrently.
+	// By the time phase 1 has rendered, the PR data is often already in.
+	// With no feature branches there are no PRs to show, so skip gh.
+	var prCh <-chan prFetchResult
+	if branches := prBranches(ctx, worktrees); withPRs && len(branches) > 0 {
+		prCh = fetchPRDataAsync(branches)
 	}
 
-	ui.DimF("  %-*s %s\n", nameWidth+2, "Name", "Branch")
-	ui.DimF("  %s\n", strings.Repeat("─", nameWidth+2+28))
+	infos, featureBranches := collectWorktreeInfos(ctx, cwd, worktrees, wide)
 
+	// Phase 1: Show worktree names immediately
+	ui.Header("WORKTREES")
+
+	names := ui.NewTable("Name", "Branch")
+	if wide {
+		names = ui.NewTable("Name", "Size", "Branch")
+	}
 	for _, info := range infos {
-		if info.IsCurrent {
-			fmt.Printf("%s ", ui.Yellow(ui.Current))
+		branch := ""
+		if info.branchLabel() != info.ShortName {
+			branch = ui.Dim(info.branchLabel())
+		}
+		if wide {
+			names.Row(info.ShortName, branchSize(info, ctx.isBaseBranch(info.Branch)), branch)
 		} else {
-			fmt.Print("  ")
+			names.Row(info.ShortName, branch)
 		}
-
-		fmt.Printf("%-*s ", nameWidth, info.ShortName)
-
-		if info.Branch != info.ShortName {
-			fmt.Print(ui.Dim(info.Branch))
+		if info.IsCurrent {
+			names.Mark(ui.Yellow(ui.Current))
 		}
-
-		fmt.Println()
 	}
+	names.Print()
 
 	// Phase 2: PR status for feature branches
-	if len(featureBranches) > 0 && github.IsAvailable() {
+	if len(featureBranches) > 0 && prCh != nil {
 		fmt.Println()
 		spin := ui.NewSpinner("Loading PR status")
 
-		openPRs, mergedPRs, closedPRs, hasErrors := fetchPRData()
+		prs := <-prCh
+		openPRs, mergedPRs, closedPRs := prs.open, prs.merged, prs.closed
 
 		spin.Stop()
 
-		if hasErrors {
-			ui.Warn("Could not fetch some PR data — status may be incomplete")
-		}
+		warnPRFetchError(prs.err, verbose)
 
 		staleThreshold := ctx.Config.EffectiveStaleThreshold()
 
-		ui.DimF("  %-28s %-4s %-6s %-8s %-6s %-8s %s\n", "Branch", "Age", "Dirty", "Sync", "PR", "Review", "CI")
-		ui.DimF("  %s\n", strings.Repeat("─", 76))
-
+		status := ui.NewTable("Branch", "Age", "Dirty", "Sync", "PR", "Review", "CI", "")
 		hasStale := false
 
 		for _, info := range infos {
@@ -374,73 +753,59 @@ func runListTerminal(ctx *cmdContext, cwd string, worktrees []git.Worktree) erro
 			}
 
 			openPR := github.FindPRForBranch(openPRs, info.Branch)
-			mergedPR := github.FindPRForBranch(mergedPRs, info.Branch)
-			closedPR := github.FindPRForBranch(closedPRs, info.Branch)
+			mergedPR := github.FindPRForBranchOrHead(mergedPRs, info.Branch, info.prMatchHead())
+			closedPR := github.FindPRForBranchOrHead(closedPRs, info.Branch, info.prMatchHead())
+			unknown := openPR == nil && openUnknown(prs.err, info.Branch)
 
-			// Check staleness: old commit + no open PR
-			daysAgo := git.WorktreeAgeDays(info.Path)
-			isStale := daysAgo >= staleThreshold && openPR == nil
+			// Check staleness: old commit + no open PR. When we couldn't
+			// tell whether there's an open PR, don't call it stale.
+			isStale, _ := staleVerdict(git.WorktreeAgeDays(info.Path), staleThreshold, openPR != nil || unknown)
 
 			// Dim the entire row if stale
-			colorize := fmt.Sprintf
+			colorize := fmt.Sprint
 			if isStale {
-				colorize = func(f string, a ...any) string {
-					return ui.Dim(fmt.Sprintf(f, a...))
-				}
+				colorize = ui.Dim
 			}
 
-			branchDisplay := ui.Truncate(info.Branch, 28)
-			fmt.Printf("  %s ", colorize("%-28s", branchDisplay))
-
-			// Age
-			fmt.Printf("%s ", colorize("%-4s", info.Age))
-
-			// Dirty
+			dirty := ui.Dim(ui.Dash)
 			if info.DirtyCount > 0 {
+				dirty = ui.Yellow(info.DirtyCount)
 				if isStale {
-					fmt.Printf("%s ", colorize("%-6d", info.DirtyCount))
-				} else {
-					ui.YellowF("%-6d ", info.DirtyCount)
+					dirty = colorize(info.DirtyCount)
 				}
-			} else {
-				fmt.Printf("%s ", ui.Dim(fmt.Sprintf("%-6s", ui.Dash)))
 			}
 
-			// Sync
 			syncStr := buildSyncStr(info.Behind, info.Ahead)
 			switch {
 			case isStale:
-				fmt.Printf("%s ", colorize("%-8s", syncStr))
+				syncStr = colorize(syncStr)
 			case info.Behind > 0:
-				ui.YellowF("%-8s ", syncStr)
+				syncStr = ui.Yellow(syncStr)
 			default:
-				ui.GreenF("%-8s ", syncStr)
+				syncStr = ui.Green(syncStr)
 			}
 
-			// PR status
+			row := []string{colorize(ui.Truncate(info.branchLabel(), 28)), colorize(info.Age), dirty, syncStr}
 			switch {
 			case openPR != nil:
-				printOpenPRStatus(openPR, info.Path)
+				row = append(row, openPRCells(openPR, info.Path)...)
+			case unknown:
+				// A merged or closed PR doesn't rule out a newer open one.
+				row = append(row, ui.Yellow("?"))
 			case mergedPR != nil:
-				ui.BlueF("%-6s ", fmt.Sprintf("#%d", mergedPR.Number))
-				fmt.Printf("%s   %s", ui.Green("merged"), ui.Yellow("stale"))
+				row = append(row, prNumberCell(mergedPR), ui.Green("merged"), ui.Yellow("stale"))
 				hasStale = true
-				fmt.Println()
 			case closedPR != nil:
-				ui.BlueF("%-6s ", fmt.Sprintf("#%d", closedPR.Number))
-				fmt.Printf("%s  %s", ui.Red("closed"), ui.Yellow("stale"))
+				row = append(row, prNumberCell(closedPR), ui.Red("closed"), ui.Yellow("stale"))
 				hasStale = true
-				fmt.Println()
+			case isStale:
+				row = append(row, ui.Dim(ui.Dash), "\U0001f4a4") // 💤
 			default:
-				if isStale {
-					fmt.Print(ui.Dim(ui.Dash))
-					fmt.Print(" \U0001f4a4") // 💤
-				} else {
-					fmt.Print(ui.Dim(ui.Dash))
-				}
-				fmt.Println()
+				row = append(row, ui.Dim(ui.Dash))
 			}
+			status.Row(row...)
 		}
+		status.Print()
 
 		// Legend
 		fmt.Println()
@@ -451,10 +816,141 @@ func runListTerminal(ctx *cmdContext, cwd string, worktrees []git.Worktree) erro
 		}
 	}
 
+	if archived := listArchived(worktrees); len(archived) > 0 {
+		ui.Header("ARCHIVED")
+		table := ui.NewTable("Branch", "Archived", "")
+		for _, a := range archived {
+			table.Row(ui.Dim(a.Branch), ui.Dim(git.RelativeAge(a.ArchivedAt)), ui.Dim(a.Tag))
+		}
+		table.Print()
+	}
+
 	fmt.Println()
 	return nil
 }
 
+// staleVerdict applies wt list's staleness rule: no activity (last commit or
+// worktree creation) for threshold days and no open PR. days is -1 when
+// the age is unknown, which never counts as stale. why explains the result.
+func staleVerdict(days, threshold int, hasOpenPR bool) (stale bool, why string) {
+	switch {
+	case days < 0:
+		return false, "last activity unknown"
+	case days < threshold:
+		return false, fmt.Sprintf("active within %d day(s) (%d < %d)", threshold, days, threshold)
+	case hasOpenPR:
+		return false, fmt.Sprintf("idle %d day(s), but has an open PR", days)
+	default:
+		return true, fmt.Sprintf("idle %d day(s) (%d ≥ %d) and no open PR", days, days, threshold)
+	}
+}
+
+// runListExplain prints the inputs and verdicts behind wt list's stale
+// dimming and wt prune's removals, per feature worktree (wt list --explain).
+func runListExplain(ctx *cmdContext, cwd string, worktrees []git.Worktree, withPRs, verbose bool) error {
+	var openPRs, mergedPRs, closedPRs []github.PR
+	var prErr error
+	if withPRs {
+		spin := ui.NewSpinner("Loading PR status")
+		openPRs, mergedPRs, closedPRs, prErr = fetchPRData(prBranches(ctx, worktrees))
+		spin.Stop()
+		warnPRFetchError(prErr, verbose)
+	}
+
+	pruneReason := make(map[string]string)
+	skippedBy := make(map[string]staleWorktree)
+	if withPRs {
+		stale, skipped := findStaleWorktrees(ctx, cwd, worktrees, mergedPRs, closedPRs, false)
+		for _, s := range stale {
+			pruneReason[s.Path] = s.Reason
+		}
+		for _, s := range skipped {
+			skippedBy[s.Path] = s
+		}
+	}
+
+	threshold := ctx.Config.EffectiveStaleThreshold()
+	fmt.Printf("stale_threshold: %d day(s)\n", threshold)
+
+	infos, _ := collectWorktreeInfos(ctx, cwd, worktrees, false)
+	for _, info := range infos {
+		if ctx.isBaseBranch(info.Branch) {
+			continue
+		}
+		fmt.Println()
+		fmt.Printf("%s  %s\n", ui.Bold(info.ShortName), ui.Dim(info.branchLabel()))
+
+		days := git.WorktreeAgeDays(info.Path)
+		if days >= 0 {
+			fmt.Printf("  %-14s %d day(s) ago\n", "Last activity", days)
+		} else {
+			fmt.Printf("  %-14s %s\n", "Last activity", ui.Dim("unknown"))
+		}
+
+		openPR := github.FindPRForBranch(openPRs, info.Branch)
+		unknown := withPRs && openPR == nil && openUnknown(prErr, info.Branch)
+		switch {
+		case !withPRs:
+			fmt.Printf("  %-14s %s\n", "Open PR", ui.Dim("unknown (PR data skipped)"))
+		case openPR != nil:
+			fmt.Printf("  %-14s #%d\n", "Open PR", openPR.Number)
+		case unknown:
+			fmt.Printf("  %-14s %s\n", "Open PR", ui.Yellow("unknown (fetch failed)"))
+		default:
+			fmt.Printf("  %-14s none\n", "Open PR")
+		}
+
+		mergedPR := github.FindPRForBranchOrHead(mergedPRs, info.Branch, info.prMatchHead())
+		closedPR := github.FindPRForBranchOrHead(closedPRs, info.Branch, info.prMatchHead())
+		switch {
+		case unknown:
+			fmt.Printf("  %-14s %s %s open PR unknown\n", "wt list", ui.Green("not stale"), ui.Dash)
+		case openPR == nil && mergedPR != nil:
+			fmt.Printf("  %-14s %s %s PR #%d merged\n", "wt list", ui.Yellow("stale"), ui.Dash, mergedPR.Number)
+		case openPR == nil && closedPR != nil:
+			fmt.Printf("  %-14s %s %s PR #%d closed\n", "wt list", ui.Yellow("stale"), ui.Dash, closedPR.Number)
+		case withPRs:
+			stale, why := staleVerdict(days, threshold, openPR != nil)
+			verdict := ui.Green("not stale")
+			if stale {
+				verdict = ui.Yellow("stale (dimmed)")
+			}
+			fmt.Printf("  %-14s %s %s %s\n", "wt list", verdict, ui.Dash, why)
+		default:
+			fmt.Printf("  %-14s %s\n", "wt list", ui.Dim("never dimmed without PR data"))
+		}
+
+		switch {
+		case pruneReason[info.Path] != "":
+			fmt.Printf("  %-14s %s %s %s\n", "wt prune", ui.Yellow("removes it"), ui.Dash, pruneReason[info.Path])
+		case skippedBy[info.Path].Skip == skipDirty:
+			fmt.Printf("  %-14s %s %s %s, but it has uncommitted changes\n", "wt prune", ui.Green("skips it"), ui.Dash, skippedBy[info.Path].Reason)
+		case skippedBy[info.Path].Skip != "":
+			fmt.Printf("  %-14s %s %s %s, but it's %s\n", "wt prune", ui.Green("skips it"), ui.Dash, skippedBy[info.Path].Reason, skippedBy[info.Path].Skip)
+		case info.IsCurrent:
+			fmt.Printf("  %-14s %s %s current worktree\n", "wt prune", ui.Green("skips it"), ui.Dash)
+		case !withPRs:
+			fmt.Printf("  %-14s %s\n", "wt prune", ui.Dim("unknown without PR data"))
+		default:
+			fmt.Printf("  %-14s %s %s no merged or closed PR\n", "wt prune", ui.Green("keeps it"), ui.Dash)
+		}
+	}
+	fmt.Println()
+	return nil
+}
+
+// branchSize renders the Size cell for wt list --wide: commits ahead, then
+// lines added and removed, e.g. "3c +120 -45". Blank for the base branch.
+func branchSize(info worktreeInfo, isBase bool) string {
+	switch {
+	case isBase:
+		return ""
+	case info.Ahead == 0:
+		return ui.Dim(ui.Dash)
+	}
+	return fmt.Sprintf("%dc %s %s", info.Ahead, ui.Green(fmt.Sprintf("+%d", info.Additions)), ui.Red(fmt.Sprintf("-%d", info.Deletions)))
+}
+
 func buildSyncStr(behind, ahead int) string {
 	var parts []string
 	if behind > 0 {
@@ -469,58 +965,56 @@ func buildSyncStr(behind, ahead int) string {
 	return strings.Join(parts, "")
 }
 
-func printOpenPRStatus(pr *github.PR, wtPath string) {
-	// PR number
-	ui.BlueF("%-6s ", fmt.Sprintf("#%d", pr.Number))
+// prNumberCell renders the PR cell: "#123", linked to the PR when the
+// terminal supports hyperlinks.
+func prNumberCell(pr *github.PR) string {
+	return ui.Blue(ui.Link(fmt.Sprintf("#%d", pr.Number), pr.URL))
+}
 
+// openPRCells renders the PR, Review, and CI cells for an open PR, plus an
+// extra cell flagging commits that haven't been pushed.
+func openPRCells(pr *github.PR, wtPath string) []string {
 	// Review glyphs
 	rs := pr.GetReviewSummary()
-	reviewWidth := 8
-	reviewCount := 0
-
+	var review string
 	if rs.Approved > 0 {
-		fmt.Print(ui.Green(strings.Repeat(ui.Pass, rs.Approved)))
-		reviewCount += rs.Approved
+		review += ui.Green(strings.Repeat(ui.Pass, rs.Approved))
 	}
 	if rs.Changes > 0 {
-		fmt.Print(ui.Red(strings.Repeat(ui.Fail, rs.Changes)))
-		reviewCount += rs.Changes
+		review += ui.Red(strings.Repeat(ui.Fail, rs.Changes))
 	}
 	if rs.Pending > 0 {
-		fmt.Print(ui.Blue(strings.Repeat(ui.Pending, rs.Pending)))
-		reviewCount += rs.Pending
-	}
-	if reviewCount == 0 {
-		fmt.Print(ui.Dim(ui.NoReview))
-		reviewCount = 1
+		review += ui.Blue(strings.Repeat(ui.Pending, rs.Pending))
 	}
-
-	// Pad to fixed width
-	if pad := reviewWidth - reviewCount; pad > 0 {
-		fmt.Printf("%*s", pad, "")
+	if review == "" {
+		review = ui.Dim(ui.NoReview)
 	}
-	fmt.Print(" ")
 
 	// CI glyphs
 	cs := pr.GetCISummary()
+	var ci string
 	if cs.Pass > 0 {
-		fmt.Print(ui.Green(strings.Repeat(ui.Pass, cs.Pass)))
+		ci += ui.Green(strings.Repeat(ui.Pass, cs.Pass))
 	}
 	if cs.Fail > 0 {
-		fmt.Print(ui.Red(strings.Repeat(ui.Fail, cs.Fail)))
+		ci += ui.Red(strings.Repeat(ui.Fail, cs.Fail))
 	}
 	if cs.Pending > 0 {
-		fmt.Print(ui.Yellow(strings.Repeat(ui.Pending, cs.Pending)))
+		ci += ui.Yellow(strings.Repeat(ui.Pending, cs.Pending))
 	}
 	if cs.Total == 0 {
-		fmt.Print(ui.Dim(ui.NoReview))
+		ci = ui.Dim(ui.NoReview)
 	}
 
 	// Unpushed indicator
-	unpushed := git.UnpushedCountIn(wtPath)
-	if unpushed > 0 {
-		fmt.Printf(" %s", ui.Magenta(fmt.Sprintf("%s%d", ui.PushUp, unpushed)))
+	var unpushedCell string
+	unpushed, hasUpstream := git.UnpushedCountIn(wtPath)
+	switch {
+	case !hasUpstream:
+		unpushedCell = ui.Magenta("not pushed")
+	case unpushed > 0:
+		unpushedCell = ui.Magenta(fmt.Sprintf("%s%d", ui.PushUp, unpushed))
 	}
 
-	fmt.Println()
+	return []string{prNumberCell(pr), review, ci, unpushedCell}
 }
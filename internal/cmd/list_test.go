@@ -0,0 +1,152 @@
+package cmd
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/mvwi/wt/internal/git"
+	"github.com/mvwi/wt/internal/github"
+	"github.com/spf13/pflag"
+)
+
+func TestStaleVerdict(t *testing.T) {
+	tests := []struct {
+		days      int
+		hasOpenPR bool
+		want      bool
+	}{
+		{-1, false, false}, // age unknown
+		{3, false, false},
+		{7, false, true},
+		{30, false, true},
+		{30, true, false},
+	}
+	for _, tt := range tests {
+		if got, why := staleVerdict(tt.days, 7, tt.hasOpenPR); got != tt.want || why == "" {
+			t.Errorf("staleVerdict(%d, 7, %v) = %v, %q; want %v", tt.days, tt.hasOpenPR, got, why, tt.want)
+		}
+	}
+}
+
+func TestBranchLabel(t *testing.T) {
+	sha := "3f2a1b9c0d8e7f6a5b4c3d2e1f0a9b8c7d6e5f4a"
+	if got := (worktreeInfo{Branch: "mary/api", Head: sha}).branchLabel(); got != "mary/api" {
+		t.Errorf("branchLabel() = %q, want %q", got, "mary/api")
+	}
+	if got := (worktreeInfo{Head: sha, Detached: true}).branchLabel(); got != "(3f2a1b9)" {
+		t.Errorf("detached branchLabel() = %q, want %q", got, "(3f2a1b9)")
+	}
+}
+
+func TestWithoutBare(t *testing.T) {
+	wts := []git.Worktree{{Path: "/src/repo.git", Bare: true}, {Path: "/src/main", Branch: "main"}}
+	got := withoutBare(wts)
+	if len(got) != 1 || got[0].Path != "/src/main" {
+		t.Errorf("withoutBare() = %+v, want only /src/main", got)
+	}
+}
+
+func TestBranchesMissingPRs(t *testing.T) {
+	limit := github.ListLimit
+	github.ListLimit = 2
+	t.Cleanup(func() { github.ListLimit = limit })
+
+	pr := func(n int, branch string) github.PR { return github.PR{Number: n, HeadRefName: branch} }
+	branches := []string{"a", "b", "c"}
+
+	t.Run("nothing capped", func(t *testing.T) {
+		got := branchesMissingPRs(branches, []github.PR{pr(1, "a")}, nil, nil)
+		if len(got) != 0 {
+			t.Errorf("got %v, want none", got)
+		}
+	})
+
+	t.Run("open list capped", func(t *testing.T) {
+		open := []github.PR{pr(1, "a"), pr(2, "x")}
+		got := branchesMissingPRs(branches, open, nil, nil)
+		if want := []string{"b", "c"}; !slices.Equal(got, want) {
+			t.Errorf("got %v, want %v", got, want)
+		}
+	})
+
+	t.Run("merged list capped", func(t *testing.T) {
+		merged := []github.PR{pr(3, "b"), pr(4, "x")}
+		closed := []github.PR{pr(5, "c")}
+		got := branchesMissingPRs(branches, nil, merged, closed)
+		if want := []string{"a"}; !slices.Equal(got, want) {
+			t.Errorf("got %v, want %v", got, want)
+		}
+	})
+}
+
+func TestAppendNewPR(t *testing.T) {
+	prs := []github.PR{{Number: 1}}
+	prs = appendNewPR(prs, github.PR{Number: 1})
+	prs = appendNewPR(prs, github.PR{Number: 2})
+	if len(prs) != 2 || prs[1].Number != 2 {
+		t.Errorf("appendNewPR() = %+v, want PRs 1 and 2", prs)
+	}
+}
+
+func TestPRFetchError(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	t.Run("open failed", func(t *testing.T) {
+		err := error(&prFetchError{Open: github.ErrNotAuthenticated})
+		if !openUnknown(err, "feat") {
+			t.Error("openUnknown() = false, want true when the open fetch failed")
+		}
+		if !errors.Is(err, github.ErrNotAuthenticated) {
+			t.Error("errors.Is(err, ErrNotAuthenticated) = false, want true")
+		}
+	})
+
+	t.Run("merged failed", func(t *testing.T) {
+		err := error(&prFetchError{Merged: errBoom})
+		if openUnknown(err, "feat") {
+			t.Error("openUnknown() = true, want false when only merged failed")
+		}
+		if got, want := err.Error(), "merged PRs: boom"; got != want {
+			t.Errorf("Error() = %q, want %q", got, want)
+		}
+	})
+
+	t.Run("branch lookup failed", func(t *testing.T) {
+		err := error(&prFetchError{Closed: errBoom, Branches: map[string]error{"b": errBoom, "a": errBoom}})
+		if !openUnknown(err, "a") || openUnknown(err, "c") {
+			t.Error("openUnknown() should be true only for branches whose lookup failed")
+		}
+		if got, want := err.Error(), "closed PRs: boom\nPRs for a: boom\nPRs for b: boom"; got != want {
+			t.Errorf("Error() = %q, want %q", got, want)
+		}
+	})
+
+	t.Run("no error", func(t *testing.T) {
+		if openUnknown(nil, "feat") {
+			t.Error("openUnknown(nil) = true, want false")
+		}
+	})
+}
+
+func TestListExplainExclusive(t *testing.T) {
+	resetFlags := func() {
+		listCmd.Flags().VisitAll(func(f *pflag.Flag) {
+			_ = f.Value.Set(f.DefValue)
+			f.Changed = false
+		})
+	}
+	t.Cleanup(resetFlags)
+	for _, args := range [][]string{
+		{"--explain", "--output", "json"},
+		{"--explain", "--json"},
+	} {
+		resetFlags()
+		if err := listCmd.ParseFlags(args); err != nil {
+			t.Fatal(err)
+		}
+		if err := listCmd.ValidateFlagGroups(); err == nil {
+			t.Errorf("wt list %v: no error, want --explain rejected", args)
+		}
+	}
+}
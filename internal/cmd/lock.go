@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/mvwi/wt/internal/git"
+	"github.com/mvwi/wt/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var lockCmd = &cobra.Command{
+	Use:     "lock [name]",
+	GroupID: groupManage,
+	Short:   "Lock a worktree so cleanup leaves it alone",
+	Long: `Lock a worktree with git worktree lock. wt prune and wt close
+--all-merged skip locked worktrees, wt close refuses them, and git won't
+prune, move, or remove them either, e.g. for a worktree on a removable
+drive or one you never want cleaned up.
+
+Without arguments, locks the current worktree. Undo with wt unlock.`,
+	Example: `  wt lock                          Lock current worktree
+  wt lock sidebar --reason "on usb drive"`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeWorktreeNames,
+	RunE:              runLock,
+}
+
+var unlockCmd = &cobra.Command{
+	Use:     "unlock [name]",
+	GroupID: groupManage,
+	Short:   "Unlock a worktree locked with wt lock",
+	Long: `Unlock a worktree with git worktree unlock, so wt prune and wt close
+can remove it again.
+
+Without arguments, unlocks the current worktree.`,
+	Example: `  wt unlock                Unlock current worktree
+  wt unlock sidebar        Unlock the "sidebar" worktree`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeWorktreeNames,
+	RunE:              runUnlock,
+}
+
+var lockReason string
+
+func init() {
+	lockCmd.Flags().StringVar(&lockReason, "reason", "", "why the worktree is locked (shown when it's skipped)")
+	rootCmd.AddCommand(lockCmd)
+	rootCmd.AddCommand(unlockCmd)
+}
+
+func runLock(cmd *cobra.Command, args []string) error {
+	ctx, target, err := lockTarget(args, "lock")
+	if err != nil || target == "" {
+		return err
+	}
+	if locked, _ := git.IsLocked(target); locked {
+		ui.Success("%s is already locked", ctx.shortName(target))
+		return nil
+	}
+	if err := git.LockWorktree(target, lockReason); err != nil {
+		return fmt.Errorf("failed to lock worktree: %w", err)
+	}
+	ui.Success("Locked %s", ctx.shortName(target))
+	fmt.Println(ui.Dim("   wt prune and wt close will skip it until wt unlock"))
+	return nil
+}
+
+func runUnlock(cmd *cobra.Command, args []string) error {
+	ctx, target, err := lockTarget(args, "unlock")
+	if err != nil || target == "" {
+		return err
+	}
+	if locked, _ := git.IsLocked(target); !locked {
+		ui.Success("%s is not locked", ctx.shortName(target))
+		return nil
+	}
+	if err := git.UnlockWorktree(target); err != nil {
+		return fmt.Errorf("failed to unlock worktree: %w", err)
+	}
+	ui.Success("Unlocked %s", ctx.shortName(target))
+	return nil
+}
+
+// lockTarget resolves the worktree wt lock or wt unlock (verb) acts on:
+// the named one, or the current one without args. The main worktree can't
+// be locked. An empty path with no error means the user cancelled.
+func lockTarget(args []string, verb string) (*cmdContext, string, error) {
+	ctx, err := newContext()
+	if err != nil {
+		return nil, "", err
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		return nil, "", fmt.Errorf("cannot determine current directory: %w", err)
+	}
+
+	var target string
+	if len(args) > 0 {
+		worktrees, err := git.ListWorktrees()
+		if err != nil {
+			return nil, "", err
+		}
+		path, fuzzy, err := resolveWorktree(ctx, worktrees, args[0])
+		if err != nil {
+			return nil, "", err
+		}
+		if path == "" {
+			return nil, "", fmt.Errorf("worktree not found: %s\n   Run wt list to see available worktrees", args[0])
+		}
+		if fuzzy && !ui.Confirm(fmt.Sprintf("%s %s?", strings.ToUpper(verb[:1])+verb[1:], ctx.shortName(path)), false) {
+			fmt.Println("Cancelled")
+			return ctx, "", nil
+		}
+		target = path
+	} else {
+		if ctx.inMainWorktree(cwd) {
+			return nil, "", fmt.Errorf("cannot %s the main repository worktree\n   Specify a worktree name: wt %s <name>", verb, verb)
+		}
+		// From a subdirectory, act on the worktree it's in.
+		if target, err = git.TopLevel(); err != nil {
+			return nil, "", err
+		}
+	}
+
+	if target == ctx.MainWorktree {
+		return nil, "", fmt.Errorf("cannot %s the main repository worktree", verb)
+	}
+	return ctx, target, nil
+}
@@ -220,7 +220,10 @@ func resolveOrCreateTarget(ctx *cmdContext, name string) (string, bool, error) {
 
 	// Not found — offer to create
 	branch := ctx.branchName(name)
-	wtPath := ctx.worktreePath(name)
+	wtPath, err := ctx.newWorktreePath(name)
+	if err != nil {
+		return "", false, err
+	}
 
 	fmt.Printf("No worktree '%s' found.\n", name)
 	fmt.Printf("  Branch: %s\n", branch)
@@ -231,27 +234,47 @@ func resolveOrCreateTarget(ctx *cmdContext, name string) (string, bool, error) {
 	}
 	fmt.Println()
 
+	if git.BranchExists(branch) {
+		return "", false, fmt.Errorf("branch already exists: %s\n   Use 'wt new --from %s' first, then 'wt move' to it", branch, branch)
+	}
+	if _, err := createWorktreeFromBase(ctx, name); err != nil {
+		return "", false, err
+	}
+	return wtPath, true, nil
+}
+
+// createWorktreeFromBase creates worktree name on a new branch from the
+// freshly fetched base branch — the no-frills wt new used where another
+// command offers to create a missing worktree (wt move, wt switch --create).
+// Returns the new worktree's path.
+func createWorktreeFromBase(ctx *cmdContext, name string) (string, error) {
+	branch := ctx.branchName(name)
+	wtPath, err := ctx.newWorktreePath(name)
+	if err != nil {
+		return "", err
+	}
+
 	if isDir(wtPath) {
-		return "", false, fmt.Errorf("directory already exists: %s", wtPath)
+		return "", fmt.Errorf("directory already exists: %s", wtPath)
 	}
 	if git.BranchExists(branch) {
-		return "", false, fmt.Errorf("branch already exists: %s\n   Use 'wt new --from %s' first, then 'wt move' to it", branch, branch)
+		return "", fmt.Errorf("branch already exists: %s\n   Use wt new --from %s to create a worktree for it", branch, branch)
 	}
 
-	if err := git.Fetch(ctx.Config.Remote, ctx.Config.BaseBranch); err != nil {
+	if err := ctx.fetchBase(); err != nil {
 		ui.Warn("Fetch failed: %v", err)
 	}
 
 	if err := git.AddWorktree(wtPath, branch, ctx.baseRef()); err != nil {
-		return "", false, fmt.Errorf("failed to create worktree: %w", err)
+		return "", fmt.Errorf("failed to create worktree: %w", err)
 	}
+	_ = git.SaveWorktreeMeta(wtPath, git.WorktreeMeta{Base: ctx.baseRef()})
 
 	ui.Success("Created worktree")
 	fmt.Printf("  Path: %s\n", wtPath)
 	fmt.Printf("  Branch: %s (from %s)\n", branch, ctx.Config.BaseBranch)
 	fmt.Println()
-
-	return wtPath, true, nil
+	return wtPath, nil
 }
 
 func copyFile(src, dst string) error {
@@ -2,6 +2,8 @@ package cmd
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 
@@ -21,11 +23,35 @@ var newCmd = &cobra.Command{
 By default, creates a branch named "<prefix>/<name>" from the base branch
 (configurable in .wt.toml, defaults to "main").
 
-Use --from to create a worktree from an existing branch or PR number.`,
+Use --from to create a worktree from an existing branch or PR number, or a
+GitHub URL for either (.../pull/123 or .../tree/<branch>). --from also
+takes a tag or commit: the worktree is created with a detached HEAD there,
+named after the tag or short SHA unless you give a name.
+Use --base to branch new work from a different base for this invocation only
+(e.g. a release branch) without editing .wt.toml.
+
+Use --no-fetch to skip fetching the base branch and branch from the local
+remote-tracking ref as-is (set fetch_on_new = false to make this the default).
+
+Use --track to push the new branch right away and set its upstream, so
+unpushed-commit counts work before the first wt submit.
+
+Use --pr to also open a draft PR for the new branch, e.g. to get CI running
+before there's any work. GitHub won't open a PR without commits, so an empty
+"Start <name>" commit is added first.`,
 	Example: `  wt new sidebar-card              Create <user>/sidebar-card from base branch
   wt new --from feature/old        Create worktree from existing branch
   wt new fix --from origin/hotfix  Create worktree with custom name from remote
   wt new --from #123               Create worktree from PR #123's branch
+  wt new --from https://github.com/org/repo/pull/123   Same, from a pasted URL
+  wt new --from v2.1.0             Detached worktree at tag v2.1.0
+  wt new bisect --from 3f2c1ab     Detached worktree at a commit
+  wt new hotfix --base release/2.1 Create <user>/hotfix from release/2.1
+  wt new scratch --no-fetch        Skip the fetch (offline / throwaway)
+  wt new login --prefix hotfix     Create hotfix/login instead of <user>/login
+  wt new login --prefix ""         Create login with no prefix
+  wt new api-v2 --track            Push the branch and set upstream now
+  wt new api-v2 --pr               Push the branch and open a draft PR
   wt new feature --init            Create + auto-initialize`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: runNew,
@@ -33,11 +59,26 @@ Use --from to create a worktree from an existing branch or PR number.`,
 
 var (
 	newFromBranch string
+	newBaseBranch string
+	newNoFetch    bool
+	newTrack      bool
+	newPR         bool
 	newDoInit     bool
+	newPrefix     string
 )
 
 func init() {
-	newCmd.Flags().StringVarP(&newFromBranch, "from", "f", "", "base on an existing branch or PR number")
+	newCmd.Flags().StringVarP(&newFromBranch, "from", "f", "", "base on an existing branch, tag, commit, PR number, or GitHub URL")
+	_ = newCmd.RegisterFlagCompletionFunc("from", completeFromRefs)
+	newCmd.Flags().StringVarP(&newBaseBranch, "base", "b", "", "branch from this base instead of the configured base branch")
+	newCmd.MarkFlagsMutuallyExclusive("from", "base")
+	newCmd.Flags().BoolVar(&newNoFetch, "no-fetch", false, "don't fetch the base branch first; use the local ref")
+	newCmd.Flags().BoolVar(&newTrack, "track", false, "push the new branch and set its upstream immediately")
+	newCmd.MarkFlagsMutuallyExclusive("from", "track")
+	newCmd.Flags().BoolVar(&newPR, "pr", false, "push the new branch and open a draft PR for it")
+	newCmd.MarkFlagsMutuallyExclusive("from", "pr")
+	newCmd.Flags().StringVar(&newPrefix, "prefix", "", `branch prefix for this worktree instead of branch_prefix ("" for none)`)
+	newCmd.MarkFlagsMutuallyExclusive("from", "prefix")
 	newCmd.Flags().BoolVarP(&newDoInit, "init", "i", false, "run 'wt init' after creating")
 	rootCmd.AddCommand(newCmd)
 }
@@ -48,6 +89,13 @@ func runNew(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	// --prefix overrides branch_prefix for this invocation; "" means none,
+	// so it's the flag being set that matters, not its value.
+	if cmd.Flags().Changed("prefix") {
+		prefix := strings.Trim(newPrefix, "/")
+		ctx.Config.BranchPrefix = &prefix
+	}
+
 	var name string
 	if len(args) > 0 {
 		name = args[0]
@@ -62,43 +110,68 @@ func runNew(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("name is required\n\nUsage: wt new <name>\n       wt new --from <branch>")
 	}
 
-	return newFromBase(ctx, name)
+	// Check gh up front rather than after the worktree exists
+	if newPR {
+		if !github.IsAvailable() {
+			return fmt.Errorf("gh CLI is required for --pr (brew install gh)")
+		}
+		if err := github.CheckAuth(); err != nil {
+			return err
+		}
+	}
+
+	return newFromBase(ctx, name, newBaseBranch)
 }
 
 func newFromExisting(ctx *cmdContext, name, fromBranch string) error {
+	// A pasted GitHub URL names a PR or a branch
+	if ref, ok := github.ParseRef(fromBranch); ok {
+		if err := checkRefRepo(ctx, ref); err != nil {
+			return err
+		}
+		if ref.Kind == github.RefPR {
+			return newFromPR(ctx, name, ref.Number)
+		}
+		fromBranch = ref.Branch
+	}
+
 	// Check if --from is a PR number (e.g., "#123" or "123")
 	if prNumber, ok := parsePRNumber(fromBranch); ok {
 		return newFromPR(ctx, name, prNumber)
 	}
 
-	// Fetch to get latest refs (needed for ResolveBranch to find remote branches)
-	spin := ui.NewSpinner("Fetching latest refs")
-	if err := git.FetchAll(ctx.Config.Remote); err != nil {
-		spin.Stop()
+	// Fetch to get latest refs (needed for ResolveRef to find remote branches
+	// and tags)
+	if err := fetchWithSpinner("Fetching latest refs", ctx.Config.Remote); err != nil {
 		ui.Warn("Fetch failed: %v", err)
-	} else {
-		spin.Stop()
 	}
 
-	ref, isRemote, err := git.ResolveBranch(fromBranch, ctx.Config.Remote)
+	ref, kind, err := git.ResolveRef(fromBranch, ctx.Config.Remote)
 	if err != nil {
 		return err
 	}
 
+	switch kind {
+	case git.RefTag, git.RefCommit:
+		return newDetachedFromRef(ctx, name, fromBranch, ref, kind)
+	}
+
 	// Derive name from branch if not provided
 	if name == "" {
 		name = nameFromBranch(ref, ctx.Config.Remote)
 	}
 
-	if isRemote {
-		return createWorktreeFromRemote(ctx, name, ref, newDoInit)
+	if kind == git.RefRemoteBranch {
+		return createWorktreeFromRemote(ctx, name, ref, git.WorktreeMeta{From: ref}, newDoInit)
 	}
 
 	// Local branch path
-	wtPath := ctx.worktreePath(name)
-
-	if isDir(wtPath) {
-		return fmt.Errorf("worktree already exists: %s\n   Use wt switch %s to switch to it", wtPath, name)
+	wtPath, err := ctx.newWorktreePath(name)
+	if err != nil {
+		return err
+	}
+	if err := checkNewWorktreePath(name, wtPath); err != nil {
+		return err
 	}
 
 	fmt.Println("Creating worktree from existing branch...")
@@ -109,6 +182,7 @@ func newFromExisting(ctx *cmdContext, name, fromBranch string) error {
 	if err := git.AddWorktreeFromExisting(wtPath, ref); err != nil {
 		return fmt.Errorf("failed to create worktree: %w", err)
 	}
+	_ = git.SaveWorktreeMeta(wtPath, git.WorktreeMeta{From: ref})
 
 	fmt.Println()
 	ui.Success("Created worktree")
@@ -121,6 +195,83 @@ func newFromExisting(ctx *cmdContext, name, fromBranch string) error {
 	return nil
 }
 
+// newDetachedFromRef creates a worktree with HEAD detached at a tag or
+// commit (ref as resolved by git.ResolveRef; from is what the user typed).
+// Without a name, a tag's worktree is named after the tag and a commit's
+// after its short SHA. Start a branch in it with git switch -c.
+func newDetachedFromRef(ctx *cmdContext, name, from, ref string, kind git.RefKind) error {
+	label := "Tag: " + strings.TrimPrefix(ref, "refs/tags/")
+	if kind == git.RefCommit {
+		label = "Commit: " + shortSHA(ref)
+	}
+	if name == "" {
+		if kind == git.RefTag {
+			name = nameFromBranch(strings.TrimPrefix(ref, "refs/tags/"), ctx.Config.Remote)
+		} else {
+			name = shortSHA(ref)
+		}
+	}
+
+	wtPath, err := ctx.newWorktreePath(name)
+	if err != nil {
+		return err
+	}
+	if err := checkNewWorktreePath(name, wtPath); err != nil {
+		return err
+	}
+
+	fmt.Println("Creating detached worktree...")
+	fmt.Printf("  Directory: %s\n", wtPath)
+	fmt.Printf("  %s\n", label)
+	fmt.Println()
+
+	if err := git.AddWorktreeDetached(wtPath, ref); err != nil {
+		return fmt.Errorf("failed to create worktree: %w", err)
+	}
+	_ = git.SaveWorktreeMeta(wtPath, git.WorktreeMeta{From: from})
+
+	fmt.Println()
+	ui.Success("Created worktree (detached HEAD)")
+	fmt.Println(ui.Dim("   Start a branch there with git switch -c <branch>"))
+	fmt.Println()
+
+	if newDoInit {
+		return runInitIn(wtPath, ctx)
+	}
+	printSwitchHint(name)
+	return nil
+}
+
+// checkRefRepo errors when a pasted GitHub URL belongs to a different
+// repository than this one: wt looks up the URL's PR number or branch here,
+// so it would check out something unrelated. The configured remote matches,
+// as does the repo gh resolves PRs against — with a fork as the remote,
+// that's the upstream repo. If gh can't say, an "upstream" remote stands
+// in for it.
+func checkRefRepo(ctx *cmdContext, ref github.Ref) error {
+	slug := remoteSlug(ctx.Config.Remote)
+	if slug == "" || strings.EqualFold(slug, ref.Slug) {
+		return nil
+	}
+	other, err := github.RepoSlug()
+	if err != nil || other == "" {
+		other = remoteSlug("upstream")
+	}
+	if strings.EqualFold(other, ref.Slug) {
+		return nil
+	}
+	return fmt.Errorf("URL is for %s, but %s is %s\n   Run this from a clone of %s", ref.Slug, ctx.Config.Remote, slug, ref.Slug)
+}
+
+// remoteSlug returns the GitHub owner/repo of remote, or "" if it has none.
+func remoteSlug(remote string) string {
+	u, err := git.RemoteURL(remote)
+	if err != nil {
+		return ""
+	}
+	return git.RemoteSlug(u)
+}
+
 // parsePRNumber checks if s looks like a PR number ("#123" or "123")
 // and returns the parsed number if so.
 func parsePRNumber(s string) (int, bool) {
@@ -136,12 +287,15 @@ func newFromPR(ctx *cmdContext, name string, number int) error {
 	if !github.IsAvailable() {
 		return fmt.Errorf("gh CLI is required to resolve PR numbers (brew install gh)")
 	}
+	if err := github.CheckAuth(); err != nil {
+		return err
+	}
 
 	spin := ui.NewSpinner(fmt.Sprintf("Fetching PR #%d", number))
 	pr, err := github.GetPRByNumber(number)
 	spin.Stop()
 	if err != nil {
-		return fmt.Errorf("failed to fetch PR #%d: %w", number, err)
+		return prNumberError(number, err)
 	}
 
 	fmt.Printf("PR #%d: %s\n", pr.Number, pr.Title)
@@ -151,7 +305,8 @@ func newFromPR(ctx *cmdContext, name string, number int) error {
 		name = nameFromBranch(pr.HeadRefName, ctx.Config.Remote)
 	}
 
-	return createWorktreeFromRemote(ctx, name, pr.HeadRefName, newDoInit)
+	meta := git.WorktreeMeta{PR: pr.Number, Fork: pr.IsCrossRepository}
+	return createWorktreeFromRemote(ctx, name, pr.HeadRefName, meta, newDoInit)
 }
 
 // nameFromBranch derives a short worktree name from a branch ref.
@@ -168,39 +323,52 @@ func nameFromBranch(ref, remote string) string {
 
 // createWorktreeFromRemote handles the common flow for checking out a remote
 // branch into a new worktree: check if it already exists, fetch, create, and
-// optionally run init. Used by both `wt new --from` and `wt pr`.
-func createWorktreeFromRemote(ctx *cmdContext, name, remoteBranch string, doInit bool) error {
-	wtPath := ctx.worktreePath(name)
-
-	if isDir(wtPath) {
-		return fmt.Errorf("worktree already exists: %s\n   Use wt switch %s to switch to it", wtPath, name)
+// optionally run init. Used by both `wt new --from` and `wt pr`. meta records
+// where the worktree came from.
+//
+// Only the one branch is fetched, with git's progress on the spinner, so a
+// big repo doesn't pay for a full fetch. An existing local branch is checked
+// out as is; otherwise a tracking branch is created from <remote>/<branch>.
+func createWorktreeFromRemote(ctx *cmdContext, name, remoteBranch string, meta git.WorktreeMeta, doInit bool) error {
+	wtPath, err := ctx.newWorktreePath(name)
+	if err != nil {
+		return err
 	}
-
-	// Fetch to get latest refs
-	spin := ui.NewSpinner("Fetching latest refs")
-	if err := git.FetchAll(ctx.Config.Remote); err != nil {
-		spin.Stop()
-		ui.Warn("Fetch failed: %v", err)
-	} else {
-		spin.Stop()
+	if err := checkNewWorktreePath(name, wtPath); err != nil {
+		return err
 	}
 
 	localBranch := strings.TrimPrefix(remoteBranch, ctx.Config.Remote+"/")
 	remoteRef := ctx.Config.Remote + "/" + localBranch
+	hasLocal := git.BranchExists(localBranch)
+
+	// A fork PR's branch lives on the fork, not on our remote.
+	if !meta.Fork {
+		err := fetchWithSpinner("Fetching "+remoteRef, ctx.Config.Remote, git.TrackingRefspec(ctx.Config.Remote, localBranch))
+		if err != nil && !hasLocal {
+			return fmt.Errorf("could not fetch %s: %w", remoteRef, err)
+		}
+		if err != nil {
+			ui.Warn("Fetch failed, using local branch %s: %v", localBranch, err)
+		}
+	} else if !hasLocal {
+		return fmt.Errorf("PR #%d's branch %s is on a fork, not %s\n   Review it with wt pr %d --detached", meta.PR, localBranch, ctx.Config.Remote, meta.PR)
+	}
 
 	fmt.Println("Creating worktree from existing branch...")
 	fmt.Printf("  Directory: %s\n", wtPath)
 	fmt.Printf("  Branch: %s\n", localBranch)
 	fmt.Println()
 
-	err := git.AddWorktreeFromRemote(wtPath, localBranch, remoteRef)
-	if err != nil {
-		// Branch might already exist locally
+	if hasLocal {
 		err = git.AddWorktreeFromExisting(wtPath, localBranch)
+	} else {
+		err = git.AddWorktreeFromRemote(wtPath, localBranch, remoteRef)
 	}
 	if err != nil {
 		return fmt.Errorf("failed to create worktree: %w", err)
 	}
+	_ = git.SaveWorktreeMeta(wtPath, meta)
 
 	fmt.Println()
 	ui.Success("Created worktree")
@@ -213,6 +381,46 @@ func createWorktreeFromRemote(ctx *cmdContext, name, remoteBranch string, doInit
 	return nil
 }
 
+// checkNewWorktreePath refuses to create a worktree at wtPath when one
+// already exists there, or when an existing directory differs only by case
+// (e.g. "Feature" vs "feature") — on case-insensitive filesystems (macOS,
+// Windows) the two would be the same directory.
+func checkNewWorktreePath(name, wtPath string) error {
+	var existing []string
+	if worktrees, err := git.ListWorktrees(); err == nil {
+		for _, wt := range worktrees {
+			existing = append(existing, wt.Path)
+		}
+	}
+	parent := filepath.Dir(wtPath)
+	if entries, err := os.ReadDir(parent); err == nil {
+		for _, e := range entries {
+			if e.IsDir() {
+				existing = append(existing, filepath.Join(parent, e.Name()))
+			}
+		}
+	}
+
+	if other := caseCollision(wtPath, existing); other != "" {
+		return fmt.Errorf("%s differs only by case from existing %s\n   These are the same directory on case-insensitive filesystems; pick a different name", wtPath, other)
+	}
+	if isDir(wtPath) {
+		return fmt.Errorf("worktree already exists: %s\n   Use wt switch %s to switch to it", wtPath, name)
+	}
+	return nil
+}
+
+// caseCollision returns the first path in existing that equals target
+// case-insensitively but not exactly, or "" if there is none.
+func caseCollision(target string, existing []string) string {
+	for _, p := range existing {
+		if p != target && strings.EqualFold(p, target) {
+			return p
+		}
+	}
+	return ""
+}
+
 // printSwitchHint prints the next-step command and offers to copy it to the clipboard.
 func printSwitchHint(name string) {
 	switchCmd := fmt.Sprintf("wt switch %s && wt init", name)
@@ -230,38 +438,79 @@ func printSwitchHint(name string) {
 	}
 }
 
-func newFromBase(ctx *cmdContext, name string) error {
+// newFromBase creates a new branch and worktree from base. An empty base
+// means the configured base branch.
+func newFromBase(ctx *cmdContext, name, base string) error {
 	branch := ctx.branchName(name)
-	wtPath := ctx.worktreePath(name)
-
-	if isDir(wtPath) {
-		return fmt.Errorf("worktree already exists: %s\n   Use wt switch %s to switch to it", wtPath, name)
+	wtPath, err := ctx.newWorktreePath(name)
+	if err != nil {
+		return err
+	}
+	if err := checkNewWorktreePath(name, wtPath); err != nil {
+		return err
 	}
 
 	if git.BranchExists(branch) {
 		return fmt.Errorf("branch already exists: %s\n   Use wt new --from %s to create a worktree for it\n   Or wt switch %s if the worktree already exists", branch, branch, name)
 	}
 
+	if base == "" {
+		base = ctx.Config.BaseBranch
+	}
+
+	fetch := ctx.Config.EffectiveFetchOnNew() && !newNoFetch
+	if fetch {
+		spin := ui.NewSpinner(fmt.Sprintf("Fetching %s", base))
+		if err := git.FetchBranch(ctx.Config.Remote, strings.TrimPrefix(base, ctx.Config.Remote+"/")); err != nil {
+			spin.Stop()
+			ui.Warn("Fetch failed: %v", err)
+		} else {
+			spin.Stop()
+		}
+	}
+
+	startRef := ctx.baseRef()
+	if base == ctx.Config.BaseBranch && !git.RemoteBranchExists(startRef) {
+		return fmt.Errorf("%s not found\n   Run wt fetch --base to fetch it, or check base_branch with wt whereami", startRef)
+	}
+	if base != ctx.Config.BaseBranch {
+		ref, err := resolveNewBase(ctx, base, fetch)
+		if err != nil {
+			return err
+		}
+		startRef = ref
+	}
+
+	if !fetch {
+		warnStaleTrackingRef(ctx, startRef)
+	}
+
 	fmt.Println("Creating worktree...")
 	fmt.Printf("  Directory: %s\n", wtPath)
-	fmt.Printf("  Branch: %s (from %s)\n", branch, ctx.Config.BaseBranch)
+	fmt.Printf("  Branch: %s (from %s)\n", branch, startRef)
 	fmt.Println()
 
-	// Fetch latest base branch
-	spin := ui.NewSpinner(fmt.Sprintf("Fetching %s", ctx.Config.BaseBranch))
-	if err := git.Fetch(ctx.Config.Remote, ctx.Config.BaseBranch); err != nil {
-		spin.Stop()
-		ui.Warn("Fetch failed: %v", err)
-	} else {
-		spin.Stop()
+	if err := git.AddWorktree(wtPath, branch, startRef); err != nil {
+		return fmt.Errorf("failed to create worktree: %w", err)
 	}
 
-	if err := git.AddWorktree(wtPath, branch, ctx.baseRef()); err != nil {
-		return fmt.Errorf("failed to create worktree: %w", err)
+	// Branching from another local feature branch starts a stack; remember
+	// it so wt submit rebases onto the parent rather than base.
+	meta := git.WorktreeMeta{Base: startRef}
+	if parent := strings.TrimPrefix(base, ctx.Config.Remote+"/"); !ctx.isBaseBranch(parent) && git.BranchExists(parent) {
+		meta.Parent = parent
 	}
+	_ = git.SaveWorktreeMeta(wtPath, meta)
 
 	fmt.Println()
 	ui.Success("Created worktree")
+
+	switch {
+	case newPR:
+		openDraftPR(ctx, name, wtPath, branch, startRef)
+	case newTrack:
+		pushNewBranch(ctx, branch)
+	}
 	fmt.Println()
 
 	if newDoInit {
@@ -270,3 +519,78 @@ func newFromBase(ctx *cmdContext, name string) error {
 	printSwitchHint(name)
 	return nil
 }
+
+// pushNewBranch publishes branch and sets its upstream (wt new --track).
+// Failure is only a warning: the worktree exists either way.
+func pushNewBranch(ctx *cmdContext, branch string) bool {
+	spin := ui.NewSpinner(fmt.Sprintf("Pushing %s", branch))
+	err := git.PushNewBranch(ctx.Config.Remote, branch)
+	spin.Stop()
+	if err != nil {
+		ui.Warn("Could not push %s: %v", branch, err)
+		fmt.Println("   The worktree is ready; wt submit will push it later")
+		return false
+	}
+	ui.Success("Tracking %s/%s", ctx.Config.Remote, branch)
+	return true
+}
+
+// openDraftPR pushes a freshly created branch and opens a draft PR for it
+// against startRef (wt new --pr). Like --track, failures are warnings.
+func openDraftPR(ctx *cmdContext, name, wtPath, branch, startRef string) {
+	if n, err := git.CountCommits(startRef, branch); err == nil && n == 0 {
+		if err := git.CommitEmptyIn(wtPath, "Start "+name); err != nil {
+			ui.Warn("Could not create the initial commit: %v", err)
+			return
+		}
+	}
+	if !pushNewBranch(ctx, branch) {
+		return
+	}
+
+	base := strings.TrimPrefix(startRef, ctx.Config.Remote+"/")
+	spin := ui.NewSpinner("Opening draft PR")
+	url, err := github.CreatePR(branch, base, name, "", true, nil)
+	spin.Stop()
+	if err != nil {
+		ui.Warn("Could not open PR: %v", err)
+		fmt.Printf("   Open it manually: %s\n", ui.Cyan("gh pr create --draft"))
+		return
+	}
+	ui.Success("Opened draft PR")
+	fmt.Printf("   %s\n", url)
+}
+
+// resolveNewBase picks the start point for --base. After a fetch the
+// remote-tracking ref is the fresh one, so it wins over a local branch of the
+// same name — unless the local branch has commits the remote doesn't, as when
+// stacking on an unpushed parent.
+func resolveNewBase(ctx *cmdContext, base string, fetched bool) (string, error) {
+	if fetched {
+		local := strings.TrimPrefix(base, ctx.Config.Remote+"/")
+		remoteRef := ctx.Config.Remote + "/" + local
+		if git.RemoteBranchExists(remoteRef) {
+			if local == base && git.BranchExists(local) {
+				if n, err := git.CountCommits(remoteRef, local); err == nil && n > 0 {
+					return local, nil
+				}
+			}
+			return remoteRef, nil
+		}
+	}
+	ref, _, err := git.ResolveBranch(base, ctx.Config.Remote)
+	return ref, err
+}
+
+// warnStaleTrackingRef warns when a remote-tracking ref we're about to branch
+// from without fetching is behind the local branch of the same name — a sign
+// the last fetch is out of date. No-op for local refs.
+func warnStaleTrackingRef(ctx *cmdContext, ref string) {
+	local, ok := strings.CutPrefix(ref, ctx.Config.Remote+"/")
+	if !ok || !git.BranchExists(local) {
+		return
+	}
+	if n, err := git.CountCommits(ref, local); err == nil && n > 0 {
+		ui.Warn("%s is %d commit(s) behind local %s — fetch to update it", ref, n, local)
+	}
+}
@@ -0,0 +1,25 @@
+package cmd
+
+import "testing"
+
+func TestCaseCollision(t *testing.T) {
+	existing := []string{"/src/wt-repo/feature", "/src/wt-repo/api"}
+
+	tests := []struct {
+		name   string
+		target string
+		want   string
+	}{
+		{"different case", "/src/wt-repo/Feature", "/src/wt-repo/feature"},
+		{"exact match is not a collision", "/src/wt-repo/feature", ""},
+		{"no match", "/src/wt-repo/sidebar", ""},
+		{"case differs in parent", "/src/WT-repo/api", "/src/wt-repo/api"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := caseCollision(tt.target, existing); got != tt.want {
+				t.Errorf("caseCollision(%q) = %q, want %q", tt.target, got, tt.want)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"os/exec"
 	"strconv"
@@ -37,50 +38,96 @@ func runOpen(cmd *cobra.Command, args []string) error {
 	if !github.IsAvailable() {
 		return fmt.Errorf("gh CLI is required for this command (brew install gh)")
 	}
+	if err := github.CheckAuth(); err != nil {
+		return err
+	}
 
 	ctx, err := newContext()
 	if err != nil {
 		return err
 	}
 
-	var branch string
+	var name string
+	if len(args) > 0 {
+		name = args[0]
+	}
+	pr, _, err := resolveTargetPR(ctx, name)
+	if err != nil {
+		return err
+	}
 
-	if len(args) == 0 {
-		// Current branch
-		branch, err = git.CurrentBranch()
-		if err != nil {
-			return fmt.Errorf("not in a git repository or detached HEAD\n   Run this from inside a worktree")
-		}
-	} else {
-		// Resolve worktree by name
+	if pr.State != "OPEN" {
+		ui.Warn("PR #%d is %s", pr.Number, strings.ToLower(pr.State))
+	}
+
+	return exec.Command("gh", "pr", "view", strconv.Itoa(pr.Number), "--web").Run()
+}
+
+// resolveTargetPR finds the PR for the current worktree, or for the
+// worktree called name when non-empty. A detached worktree (wt pr
+// --detached) has no branch to look up, so its recorded PR number is used,
+// and name may also be a PR number ("123" or "#123") that matches no
+// worktree. Returns the PR and the branch it was found for (empty when
+// looked up by number); errors when there's no PR. Shared by commands that
+// act on "this worktree's PR" (open, comment, approve, ...).
+func resolveTargetPR(ctx *cmdContext, name string) (*github.PR, string, error) {
+	dir := ""
+	if name != "" {
 		worktrees, err := git.ListWorktrees()
 		if err != nil {
-			return err
+			return nil, "", err
+		}
+		target, _, resolveErr := resolveWorktree(ctx, worktrees, name)
+		if target == "" {
+			if number, ok := parsePRNumber(name); ok {
+				return prByNumber(number)
+			}
 		}
-		target, _, resolveErr := resolveWorktree(ctx, worktrees, args[0])
 		if resolveErr != nil {
-			return resolveErr
+			return nil, "", resolveErr
 		}
 		if target == "" {
-			return fmt.Errorf("worktree not found: %s\n   Run wt list to see available worktrees", args[0])
+			return nil, "", fmt.Errorf("worktree not found: %s\n   Run wt list to see available worktrees", name)
 		}
-		branch, err = git.CurrentBranchIn(target)
-		if err != nil {
-			return fmt.Errorf("could not determine branch for worktree: %s", args[0])
+		dir = target
+	}
+
+	branch, err := git.CurrentBranchIn(dir)
+	if err != nil {
+		if name == "" {
+			return nil, "", fmt.Errorf("not in a git repository\n   Run this from inside a worktree")
+		}
+		return nil, "", fmt.Errorf("could not determine branch for worktree: %s", name)
+	}
+	if branch == "HEAD" {
+		if dir == "" {
+			dir, _ = git.TopLevel()
+		}
+		if meta, err := git.LoadWorktreeMeta(dir); err == nil && meta.PR > 0 {
+			return prByNumber(meta.PR)
 		}
+		return nil, "", fmt.Errorf("detached HEAD with no recorded PR\n   Name the PR by number instead, e.g. #123")
 	}
 
 	pr, err := github.GetPRForBranch(branch)
 	if err != nil {
-		return fmt.Errorf("failed to check for PR: %w", err)
+		return nil, "", fmt.Errorf("failed to check for PR: %w", err)
 	}
 	if pr == nil {
-		return fmt.Errorf("no PR found for branch: %s\n   Run wt submit to push and create one", branch)
+		return nil, "", fmt.Errorf("no PR found for branch: %s\n   Run wt submit to push and create one", branch)
 	}
+	return pr, branch, nil
+}
 
-	if pr.State != "OPEN" {
-		ui.Warn("PR #%d is %s", pr.Number, strings.ToLower(pr.State))
+// prByNumber is resolveTargetPR's lookup for a PR with no branch to go by.
+func prByNumber(number int) (*github.PR, string, error) {
+	pr, err := github.GetPR(number)
+	if err != nil {
+		var notFound *github.PRNotFoundError
+		if errors.As(err, &notFound) {
+			return nil, "", err
+		}
+		return nil, "", fmt.Errorf("failed to check for PR: %w", err)
 	}
-
-	return exec.Command("gh", "pr", "view", strconv.Itoa(pr.Number), "--web").Run()
+	return pr, "", nil
 }
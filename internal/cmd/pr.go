@@ -1,33 +1,47 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
-	"strconv"
 
+	"github.com/mvwi/wt/internal/git"
 	"github.com/mvwi/wt/internal/github"
+	"github.com/mvwi/wt/internal/ui"
 	"github.com/spf13/cobra"
 )
 
 var prCmd = &cobra.Command{
-	Use:     "pr <number>",
+	Use:     "pr <number|url>",
 	GroupID: groupWorkflow,
 	Short:   "Checkout a PR into a worktree",
 	Long: `Fetch a GitHub pull request into its own worktree.
 
-Looks up the PR by number, resolves the branch, and creates a worktree
-for it — useful for code review workflows.
+Looks up the PR by number (or a pasted GitHub PR URL), resolves the
+branch, and creates a worktree for it — useful for code review workflows.
+
+With --detached, checks out the PR's head commit in detached HEAD instead,
+in a worktree named review-pr-<number>. No local branch is created, so
+read-only reviews don't clutter 'git branch', and closing it has no branch
+to delete. Works for PRs from forks too.
 
 Requires the GitHub CLI (gh) to be installed.`,
 	Example: `  wt pr 123          Checkout PR #123 into a worktree
-  wt pr 123 --init   Checkout + auto-initialize`,
-	Args: cobra.ExactArgs(1),
-	RunE: runPR,
+  wt pr 123 --init   Checkout + auto-initialize
+  wt pr 123 -d       Review PR #123 in detached HEAD (no local branch)
+  wt pr https://github.com/org/repo/pull/123`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeOpenPRs,
+	RunE:              runPR,
 }
 
-var prDoInit bool
+var (
+	prDoInit   bool
+	prDetached bool
+)
 
 func init() {
 	prCmd.Flags().BoolVarP(&prDoInit, "init", "i", false, "run 'wt init' after creating")
+	prCmd.Flags().BoolVarP(&prDetached, "detached", "d", false, "check out the PR head in detached HEAD, without a local branch")
 	rootCmd.AddCommand(prCmd)
 }
 
@@ -35,10 +49,8 @@ func runPR(cmd *cobra.Command, args []string) error {
 	if !github.IsAvailable() {
 		return fmt.Errorf("gh CLI is required for this command (brew install gh)")
 	}
-
-	number, err := strconv.Atoi(args[0])
-	if err != nil {
-		return fmt.Errorf("invalid PR number: %s", args[0])
+	if err := github.CheckAuth(); err != nil {
+		return err
 	}
 
 	ctx, err := newContext()
@@ -46,19 +58,99 @@ func runPR(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	number, ok := parsePRNumber(args[0])
+	if !ok {
+		ref, isRef := github.ParseRef(args[0])
+		if !isRef || ref.Kind != github.RefPR {
+			return fmt.Errorf("invalid PR number or URL: %s", args[0])
+		}
+		if err := checkRefRepo(ctx, ref); err != nil {
+			return err
+		}
+		number = ref.Number
+	}
+
 	pr, err := github.GetPRByNumber(number)
 	if err != nil {
-		return fmt.Errorf("failed to fetch PR #%d: %w", number, err)
+		return prNumberError(number, err)
 	}
 
 	if pr.State != "OPEN" {
 		return fmt.Errorf("PR #%d is %s", number, pr.State)
 	}
 
+	fmt.Printf("PR #%d: %s\n", pr.Number, pr.Title)
+	fmt.Println()
+
+	if prDetached {
+		return createReviewWorktree(ctx, pr)
+	}
+
 	name := nameFromBranch(pr.HeadRefName, ctx.Config.Remote)
+	meta := git.WorktreeMeta{PR: pr.Number, Fork: pr.IsCrossRepository}
+	return createWorktreeFromRemote(ctx, name, pr.HeadRefName, meta, prDoInit)
+}
 
-	fmt.Printf("PR #%d: %s\n", pr.Number, pr.Title)
+// createReviewWorktree checks out a PR's head commit into a detached-HEAD
+// worktree named review-pr-<n>. Fetches refs/pull/<n>/head, which GitHub
+// publishes for every PR, so fork PRs work without adding a remote.
+func createReviewWorktree(ctx *cmdContext, pr *github.PRInfo) error {
+	name := fmt.Sprintf("review-pr-%d", pr.Number)
+	wtPath, err := ctx.newWorktreePath(name)
+	if err != nil {
+		return err
+	}
+	if err := checkNewWorktreePath(name, wtPath); err != nil {
+		return err
+	}
+
+	err = fetchWithSpinner(fmt.Sprintf("Fetching PR #%d", pr.Number), ctx.Config.Remote, fmt.Sprintf("pull/%d/head", pr.Number))
+	if err != nil {
+		return fmt.Errorf("failed to fetch PR #%d head: %w", pr.Number, err)
+	}
+
+	// Pin to the SHA gh reported rather than FETCH_HEAD, which a concurrent
+	// fetch could overwrite.
+	ref := pr.HeadRefOid
+	if ref == "" {
+		ref = "FETCH_HEAD"
+	}
+
+	fmt.Println("Creating review worktree (detached HEAD)...")
+	fmt.Printf("  Directory: %s\n", wtPath)
+	fmt.Printf("  Commit: %s (%s)\n", shortSHA(ref), pr.HeadRefName)
+	fmt.Println()
+
+	if err := git.AddWorktreeDetached(wtPath, ref); err != nil {
+		return fmt.Errorf("failed to create worktree: %w", err)
+	}
+	_ = git.SaveWorktreeMeta(wtPath, git.WorktreeMeta{PR: pr.Number, Fork: pr.IsCrossRepository})
+
+	fmt.Println()
+	ui.Success("Created review worktree")
 	fmt.Println()
 
-	return createWorktreeFromRemote(ctx, name, pr.HeadRefName, prDoInit)
+	if prDoInit {
+		return runInitIn(wtPath, ctx)
+	}
+	printSwitchHint(name)
+	return nil
+}
+
+// prNumberError wraps a github.GetPRByNumber failure for display, with a
+// hint when the number simply doesn't name a PR.
+func prNumberError(number int, err error) error {
+	var notFound *github.PRNotFoundError
+	if errors.As(err, &notFound) {
+		return fmt.Errorf("PR #%d not found\n   Check the number with gh pr list", number)
+	}
+	return fmt.Errorf("failed to fetch PR #%d: %w", number, err)
+}
+
+// shortSHA abbreviates a full commit SHA for display; other refs pass through.
+func shortSHA(ref string) string {
+	if len(ref) == 40 {
+		return ref[:7]
+	}
+	return ref
 }
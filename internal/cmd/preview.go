@@ -0,0 +1,154 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/mvwi/wt/internal/git"
+	"github.com/mvwi/wt/internal/github"
+	"github.com/mvwi/wt/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// previewCmd renders the fzf preview pane for `wt switch`. Hidden because
+// it's an implementation detail of the picker, not a user-facing command.
+var previewCmd = &cobra.Command{
+	Use:    "_preview <path>",
+	Short:  "Print a worktree preview for the switch picker",
+	Hidden: true,
+	Args:   cobra.ExactArgs(1),
+	RunE:   runPreview,
+}
+
+// previewMaxFiles caps the dirty-file list so the commits and PR sections
+// stay visible in a normal-height preview window.
+const previewMaxFiles = 8
+
+func init() {
+	rootCmd.AddCommand(previewCmd)
+}
+
+func runPreview(cmd *cobra.Command, args []string) error {
+	// fzf pipes our stdout, which would otherwise disable colors.
+	ui.ForceColor()
+
+	ctx, err := newContext()
+	if err != nil {
+		return err
+	}
+
+	path := args[0]
+	if !isDir(path) {
+		return fmt.Errorf("worktree not found: %s", path)
+	}
+
+	branch, _ := git.CurrentBranchIn(path)
+	isBase := ctx.isBaseBranch(branch)
+
+	fmt.Printf("%s %s", ui.Yellow(ui.Current), ctx.shortName(path))
+	if branch != "" && branch != ctx.shortName(path) {
+		fmt.Printf("  %s", ui.Dim(branch))
+	}
+	fmt.Println()
+
+	// Sync status
+	if !isBase {
+		if ab, err := git.GetAheadBehindIn(path, ctx.baseRef()); err == nil {
+			var parts []string
+			if ab.Behind > 0 {
+				parts = append(parts, ui.Yellow(fmt.Sprintf("%s%d behind %s", ui.ArrowDown, ab.Behind, ctx.Config.BaseBranch)))
+			}
+			if ab.Ahead > 0 {
+				parts = append(parts, ui.Green(fmt.Sprintf("%s%d ahead", ui.ArrowUp, ab.Ahead)))
+			}
+			if len(parts) > 0 {
+				fmt.Printf("  %s\n", strings.Join(parts, "  "))
+			} else {
+				fmt.Printf("  %s\n", ui.Green("up to date with "+ctx.Config.BaseBranch))
+			}
+		}
+	}
+
+	// Uncommitted changes
+	if changes, err := git.StatusPorcelainIn(path); err == nil && len(changes) > 0 {
+		fmt.Println()
+		fmt.Printf("  %s\n", ui.Yellow(fmt.Sprintf("%d uncommitted change(s)", len(changes))))
+		for i, c := range changes {
+			if i == previewMaxFiles {
+				fmt.Printf("    %s\n", ui.Dim(fmt.Sprintf("… %d more", len(changes)-previewMaxFiles)))
+				break
+			}
+			fmt.Printf("    %s %s\n", ui.Dim(c.Status), c.Path)
+		}
+	}
+
+	// Recent commits on the branch
+	if !isBase {
+		if entries, err := git.LogOnelineIn(path, ctx.baseRef(), 5); err == nil && len(entries) > 0 {
+			fmt.Println()
+			fmt.Printf("  %s\n", ui.Bold("Commits"))
+			for _, e := range entries {
+				fmt.Printf("    %s %s %s\n", ui.Yellow(e.Hash), e.Subject, ui.Dim(e.Age))
+			}
+		}
+	}
+
+	// PR + CI
+	if !isBase && branch != "" && github.IsAvailable() {
+		if ws, err := github.GetWatchStatus(branch); err == nil {
+			fmt.Println()
+			fmt.Printf("  %s %s\n", ui.Blue(fmt.Sprintf("PR #%d", ws.Number)), previewPRState(ws))
+			if ws.Title != "" {
+				fmt.Printf("    %s\n", ui.Dim(ws.Title))
+			}
+		}
+	}
+
+	return nil
+}
+
+// previewPRState summarises a PR's state, reviews, and CI on one line.
+func previewPRState(ws *github.WatchStatus) string {
+	switch ws.State {
+	case "MERGED":
+		return ui.Magenta("merged")
+	case "CLOSED":
+		return ui.Red("closed")
+	}
+
+	var parts []string
+	rs := ws.GetReviewSummary()
+	if rs.Approved > 0 {
+		parts = append(parts, ui.Green(fmt.Sprintf("%s%d", ui.Pass, rs.Approved)))
+	}
+	if rs.Changes > 0 {
+		parts = append(parts, ui.Red(fmt.Sprintf("%s%d", ui.Fail, rs.Changes)))
+	}
+	if rs.Pending > 0 {
+		parts = append(parts, ui.Blue(fmt.Sprintf("%s%d", ui.Pending, rs.Pending)))
+	}
+
+	cs := ws.GetCISummary()
+	switch {
+	case cs.Fail > 0:
+		parts = append(parts, ui.Red(fmt.Sprintf("CI failing (%d/%d)", cs.Fail, cs.Total)))
+	case cs.Pending > 0:
+		parts = append(parts, ui.Yellow(fmt.Sprintf("CI pending (%d/%d)", cs.Pending, cs.Total)))
+	case cs.Pass > 0:
+		parts = append(parts, ui.Green("CI passing"))
+	}
+	return strings.Join(parts, "  ")
+}
+
+// previewCommand returns the fzf --preview command line that re-invokes this
+// binary. Uses the resolved executable path so the preview works even when
+// `wt` is a shell function wrapping the binary.
+func previewCommand() string {
+	exe, err := os.Executable()
+	if err != nil {
+		exe = "wt"
+	}
+	// {3} is the path field of the "|"-delimited picker line; fzf quotes it.
+	return fmt.Sprintf("%s _preview {3}", shellQuoteInit(exe))
+}
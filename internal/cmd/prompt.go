@@ -0,0 +1,164 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/mvwi/wt/internal/git"
+	"github.com/mvwi/wt/internal/github"
+	"github.com/mvwi/wt/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var promptCmd = &cobra.Command{
+	Use:     "prompt",
+	GroupID: groupManage,
+	Short:   "Print a one-line status for shell prompts",
+	Long: `Print the current worktree's status as one compact line for PROMPT,
+RPROMPT, or a starship custom module, e.g.:
+
+  api ⇣2⇡1 ±3 #45✓
+
+Fields: worktree name, commits behind/ahead of the base branch, number of
+uncommitted changes, and with --pr the open PR and its CI state (✓ pass,
+✗ fail, ◐ pending). Fields with nothing to report are left out.
+
+Only local git state is read unless --pr is given, so it's fast enough to
+run on every prompt. Outside a repo it prints nothing and exits 0.
+
+Colors follow --color: auto (only when stdout is a terminal, which it
+isn't inside $(...)), always, or never. NO_COLOR is respected.`,
+	Example: `  wt prompt                       api ⇣2 ±3
+  wt prompt --pr                  api ⇣2 ±3 #45✓
+  RPROMPT='$(wt prompt --max-width 24)'   zsh (with setopt prompt_subst)`,
+	Args: cobra.NoArgs,
+	RunE: runPrompt,
+}
+
+var (
+	promptPR       bool
+	promptColor    string
+	promptMaxWidth int
+)
+
+func init() {
+	promptCmd.Flags().BoolVar(&promptPR, "pr", false, "include the open PR and its CI state (calls GitHub)")
+	promptCmd.Flags().StringVar(&promptColor, "color", "auto", "colorize output: auto, always, or never")
+	promptCmd.Flags().IntVar(&promptMaxWidth, "max-width", 40, "truncate the worktree name to keep the line within this many columns (0 for no limit)")
+	_ = promptCmd.RegisterFlagCompletionFunc("color", cobra.FixedCompletions([]string{"auto", "always", "never"}, cobra.ShellCompDirectiveNoFileComp))
+	rootCmd.AddCommand(promptCmd)
+}
+
+// promptStatus is what wt prompt reports about the current worktree.
+type promptStatus struct {
+	Name          string
+	Behind, Ahead int // vs the base branch; zero on the base branch itself
+	Dirty         int // uncommitted changes
+	PR            int // open PR number, 0 for none (or not fetched)
+	CI            github.CISummary
+}
+
+func runPrompt(cmd *cobra.Command, args []string) error {
+	switch promptColor {
+	case "auto":
+	case "always":
+		color.NoColor = os.Getenv("NO_COLOR") != ""
+	case "never":
+		color.NoColor = true
+	default:
+		return fmt.Errorf("invalid --color %q (use auto, always, or never)", promptColor)
+	}
+
+	// A prompt must never show an error: outside a repo (or on any failure
+	// to read it) print nothing.
+	if !git.IsInsideWorkTree() {
+		return nil
+	}
+	ctx, err := newContext()
+	if err != nil {
+		return nil
+	}
+	// Name the worktree, not whichever subdirectory the shell is in.
+	top, err := git.TopLevel()
+	if err != nil {
+		return nil
+	}
+	branch, err := git.CurrentBranch()
+	if err != nil {
+		return nil
+	}
+
+	st := promptStatus{Name: ctx.shortName(top)}
+	if !ctx.inMainWorktree(top) && !ctx.isBaseBranch(branch) {
+		if ab, err := git.GetAheadBehind(ctx.baseRef()); err == nil {
+			st.Behind, st.Ahead = ab.Behind, ab.Ahead
+		}
+	}
+	if changes, err := git.StatusPorcelainIn(top); err == nil {
+		st.Dirty = len(changes)
+	}
+	if promptPR {
+		if pr, _ := github.GetPRForBranch(branch); pr != nil && pr.State == "OPEN" {
+			st.PR = pr.Number
+			st.CI = pr.GetCISummary()
+		}
+	}
+
+	fmt.Println(formatPrompt(st, promptMaxWidth))
+	return nil
+}
+
+// formatPrompt renders st as wt prompt's line. When maxWidth > 0 and the
+// line would be wider, the worktree name is truncated to fit; the status
+// fields are kept whole since they're what the prompt is for.
+func formatPrompt(st promptStatus, maxWidth int) string {
+	var fields []string
+	if sync := promptSync(st.Behind, st.Ahead); sync != "" {
+		fields = append(fields, sync)
+	}
+	if st.Dirty > 0 {
+		fields = append(fields, ui.Yellow(fmt.Sprintf("±%d", st.Dirty)))
+	}
+	if st.PR > 0 {
+		fields = append(fields, ui.Blue(fmt.Sprintf("#%d", st.PR))+promptCI(st.CI))
+	}
+
+	name := st.Name
+	if maxWidth > 0 {
+		rest := 0
+		for _, f := range fields {
+			rest += 1 + ui.Width(f)
+		}
+		name = ui.Truncate(name, max(maxWidth-rest, 1))
+	}
+	return strings.Join(append([]string{name}, fields...), " ")
+}
+
+// promptSync renders commits behind/ahead of base as "⇣2⇡1", or "" when
+// in sync.
+func promptSync(behind, ahead int) string {
+	var s string
+	if behind > 0 {
+		s += ui.Yellow(fmt.Sprintf("%s%d", ui.ArrowDown, behind))
+	}
+	if ahead > 0 {
+		s += ui.Green(fmt.Sprintf("%s%d", ui.ArrowUp, ahead))
+	}
+	return s
+}
+
+// promptCI renders a PR's CI state as a single glyph: failures win over
+// pending, which wins over pass. "" when the PR has no checks.
+func promptCI(cs github.CISummary) string {
+	switch {
+	case cs.Fail > 0:
+		return ui.Red(ui.Fail)
+	case cs.Pending > 0:
+		return ui.Yellow(ui.Pending)
+	case cs.Pass > 0:
+		return ui.Green(ui.Pass)
+	}
+	return ""
+}
@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/fatih/color"
+	"github.com/mvwi/wt/internal/github"
+)
+
+func TestFormatPrompt(t *testing.T) {
+	noColor := color.NoColor
+	color.NoColor = true
+	t.Cleanup(func() { color.NoColor = noColor })
+
+	tests := []struct {
+		name     string
+		st       promptStatus
+		maxWidth int
+		want     string
+	}{
+		{"clean", promptStatus{Name: "api"}, 40, "api"},
+		{"sync and dirty", promptStatus{Name: "api", Behind: 2, Ahead: 1, Dirty: 3}, 40, "api ⇣2⇡1 ±3"},
+		{"pr passing", promptStatus{Name: "api", Dirty: 3, PR: 45, CI: github.CISummary{Pass: 2, Total: 2}}, 40, "api ±3 #45✓"},
+		{"pr failing beats pending", promptStatus{Name: "api", PR: 45, CI: github.CISummary{Fail: 1, Pending: 1, Total: 2}}, 40, "api #45✗"},
+		{"pr without checks", promptStatus{Name: "api", PR: 45}, 40, "api #45"},
+		{"name truncated to fit", promptStatus{Name: "sidebar-redesign", Behind: 2}, 12, "sidebar-… ⇣2"},
+		{"no limit", promptStatus{Name: "sidebar-redesign", Behind: 2}, 0, "sidebar-redesign ⇣2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatPrompt(tt.st, tt.maxWidth); got != tt.want {
+				t.Errorf("formatPrompt() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunPromptFromSubdirectory(t *testing.T) {
+	root, err := filepath.EvalSymlinks(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	repo, api := filepath.Join(root, "repo"), filepath.Join(root, "wt-repo", "api")
+	for _, args := range [][]string{
+		{"init", "-q", "-b", "main", repo},
+		{"-C", repo, "-c", "user.name=t", "-c", "user.email=t@example.com", "commit", "-q", "--allow-empty", "-m", "init"},
+		{"-C", repo, "worktree", "add", "-q", "-b", "api", api},
+	} {
+		if out, err := exec.Command("git", args...).CombinedOutput(); err != nil {
+			t.Skipf("git %v failed: %v\n%s", args, err, out)
+		}
+	}
+	sub := filepath.Join(api, "src", "components")
+	if err := os.MkdirAll(sub, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("HOME", t.TempDir())
+	t.Chdir(sub)
+	origColor, noColor := promptColor, color.NoColor
+	promptColor = "never"
+	t.Cleanup(func() { promptColor, color.NoColor = origColor, noColor })
+
+	out := captureStdout(t, func() {
+		if err := runPrompt(nil, nil); err != nil {
+			t.Error(err)
+		}
+	})
+	if got := strings.TrimSpace(out); got != "api" {
+		t.Errorf("wt prompt from %s = %q, want %q", sub, got, "api")
+	}
+}
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -19,21 +20,45 @@ var pruneCmd = &cobra.Command{
 	Short:   "Clean up stale worktrees (merged/closed PRs)",
 	Long: `Interactively remove worktrees whose PRs have been merged or closed.
 
+With --merged-local, also remove worktrees whose branch has no commits
+beyond the base branch (fast-forwarded or merged locally, no PR needed).
+This includes freshly created branches with no commits yet.
+
+With --dry-run --output json, prints the stale worktrees as a JSON array
+of {path, branch, reason, dirty, locked} objects for scripting, and
+removes nothing.
+
+With --force, stale worktrees with uncommitted changes are offered for
+removal too, one at a time, after listing what would be lost. The changes
+are discarded; --yes accepts every prompt.
+
 Skips:
   - Main worktree
   - Current worktree
   - Worktrees with uncommitted changes
+  - Locked worktrees (wt lock)
   - Base/main branches`,
 	Example: `  wt prune                 Interactively remove stale worktrees
   wt prune --dry-run       Show what would be removed
+  wt prune --dry-run --output json   Stale worktrees as JSON
+  wt prune --merged-local  Also catch branches merged into base without a PR
+  wt prune --force         Also offer to remove worktrees with uncommitted changes
   wt prune --yes           Remove all stale worktrees without prompts`,
 	RunE: runPrune,
 }
 
-var pruneDryRun bool
+var (
+	pruneDryRun      bool
+	pruneMergedLocal bool
+	pruneOutput      string
+	pruneForce       bool
+)
 
 func init() {
 	pruneCmd.Flags().BoolVar(&pruneDryRun, "dry-run", false, "show what would be removed without removing anything")
+	pruneCmd.Flags().BoolVar(&pruneMergedLocal, "merged-local", false, "also prune branches fully merged into the base branch")
+	pruneCmd.Flags().StringVar(&pruneOutput, "output", "", "Output format with --dry-run: json")
+	pruneCmd.Flags().BoolVar(&pruneForce, "force", false, "also offer to remove stale worktrees with uncommitted changes (discarding them)")
 	rootCmd.AddCommand(pruneCmd)
 }
 
@@ -41,11 +66,55 @@ type staleWorktree struct {
 	Path   string
 	Branch string
 	Reason string
+	// PRHead is the head commit of the merged or closed PR, if any: what
+	// was published when the PR ended.
+	PRHead string
+	// Skip is why a stale worktree is left alone (skipDirty or a lock),
+	// or "" when it's safe to remove.
+	Skip string
+}
+
+// skipDirty is staleWorktree.Skip for a worktree with uncommitted changes.
+const skipDirty = "has uncommitted changes"
+
+// lockedSkip is staleWorktree.Skip for a worktree locked with wt lock.
+func lockedSkip(reason string) string {
+	if reason == "" {
+		return "locked"
+	}
+	return "locked: " + reason
+}
+
+type pruneJSONEntry struct {
+	Path   string `json:"path"`
+	Branch string `json:"branch"`
+	Reason string `json:"reason"`
+	Dirty  bool   `json:"dirty"`  // has uncommitted changes, so prune would skip it
+	Locked bool   `json:"locked"` // locked with wt lock, so prune would skip it
 }
 
 func runPrune(cmd *cobra.Command, args []string) error {
-	if !github.IsAvailable() {
-		return fmt.Errorf("gh CLI is required for prune (brew install gh)")
+	switch {
+	case pruneOutput != "" && pruneOutput != "json":
+		return fmt.Errorf("unknown output format: %s (supported: json)", pruneOutput)
+	case pruneOutput != "" && !pruneDryRun:
+		return fmt.Errorf("--output json requires --dry-run")
+	}
+	jsonOut := pruneOutput == "json"
+
+	// --merged-local works from git alone; PR detection needs gh.
+	ghAvailable := github.IsAvailable()
+	if !ghAvailable && !pruneMergedLocal {
+		return fmt.Errorf("gh CLI is required for prune (brew install gh)\n   Or use wt prune --merged-local for git-only detection")
+	}
+	if ghAvailable {
+		if err := github.CheckAuth(); err != nil {
+			if !pruneMergedLocal {
+				return fmt.Errorf("%w\n   Or use wt prune --merged-local for git-only detection", err)
+			}
+			ui.Warn("%v — checking local merges only", err)
+			ghAvailable = false
+		}
 	}
 
 	ctx, err := newContext()
@@ -58,82 +127,52 @@ func runPrune(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("cannot determine current directory: %w", err)
 	}
 
-	spin := ui.NewSpinner("Scanning for stale worktrees")
-
-	var mergedPRs, closedPRs []github.PR
-	var mergedErr, closedErr error
-	var wg sync.WaitGroup
-	wg.Add(2)
-	go func() { defer wg.Done(); mergedPRs, mergedErr = github.ListPRs("merged") }()
-	go func() { defer wg.Done(); closedPRs, closedErr = github.ListPRs("closed") }()
-	wg.Wait()
-
-	if mergedErr != nil && closedErr != nil {
-		spin.Stop()
-		return fmt.Errorf("could not fetch PR data: %v", mergedErr)
+	// The spinner prints to stdout when it isn't a TTY, which would corrupt
+	// JSON output.
+	stopSpin := func() {}
+	if !jsonOut {
+		stopSpin = ui.NewSpinner("Scanning for stale worktrees").Stop
 	}
 
 	worktrees, err := git.ListWorktrees()
 	if err != nil {
-		spin.Stop()
+		stopSpin()
 		return err
 	}
 
-	var stale []staleWorktree
-	var skippedDirty []staleWorktree
-
-	for _, wt := range worktrees {
-		// Skip main
-		if wt.Path == ctx.MainWorktree {
-			continue
-		}
-		// Skip current
-		if wt.Path == cwd || isSubpath(cwd, wt.Path) {
-			continue
-		}
-
-		branch := wt.Branch
-		if branch == "" {
-			branch, _ = git.CurrentBranchIn(wt.Path)
-		}
-		if ctx.isBaseBranch(branch) {
-			continue
-		}
-
-		// Check PR status
-		mergedPR := github.FindPRForBranch(mergedPRs, branch)
-		closedPR := github.FindPRForBranch(closedPRs, branch)
-
-		var reason string
-		switch {
-		case mergedPR != nil:
-			reason = fmt.Sprintf("PR #%d merged", mergedPR.Number)
-		case closedPR != nil:
-			reason = fmt.Sprintf("PR #%d closed", closedPR.Number)
-		default:
-			continue
-		}
-
-		// Stale but has uncommitted changes — track separately
-		if git.HasChangesIn(wt.Path) {
-			skippedDirty = append(skippedDirty, staleWorktree{wt.Path, branch, reason})
-			continue
+	var mergedPRs, closedPRs []github.PR
+	var mergedErr, closedErr, lookupErr error
+	if ghAvailable {
+		var wg sync.WaitGroup
+		wg.Add(2)
+		go func() { defer wg.Done(); mergedPRs, mergedErr = github.ListPRs("merged") }()
+		go func() { defer wg.Done(); closedPRs, closedErr = github.ListPRs("closed") }()
+		wg.Wait()
+
+		if mergedErr != nil && closedErr != nil && !pruneMergedLocal {
+			stopSpin()
+			return fmt.Errorf("could not fetch PR data: %v", mergedErr)
 		}
-
-		stale = append(stale, staleWorktree{wt.Path, branch, reason})
+		mergedPRs, closedPRs, lookupErr = completeDonePRs(prBranches(ctx, worktrees), mergedPRs, closedPRs)
 	}
 
+	stale, skipped := findStaleWorktrees(ctx, cwd, worktrees, mergedPRs, closedPRs, pruneMergedLocal)
+
 	git.PruneWorktrees()
 
-	spin.Stop()
+	stopSpin()
 
-	if mergedErr != nil || closedErr != nil {
+	if mergedErr != nil || closedErr != nil || lookupErr != nil {
 		ui.Warn("Could not fetch some PR data — results may be incomplete")
 	}
 
+	if jsonOut {
+		return printPruneJSON(stale, skipped)
+	}
+
 	if len(stale) == 0 {
 		ui.Success("No stale worktrees found")
-		printSkippedDirty(skippedDirty)
+		finishSkipped(skipped)
 		return nil
 	}
 
@@ -146,7 +185,7 @@ func runPrune(cmd *cobra.Command, args []string) error {
 			fmt.Printf("  %s  %s\n", ui.Yellow(short), s.Reason)
 			fmt.Printf("     %s\n", ui.Dim(s.Branch))
 		}
-		printSkippedDirty(skippedDirty)
+		finishSkipped(skipped)
 		fmt.Println()
 		fmt.Println("No changes made (--dry-run)")
 		return nil
@@ -159,41 +198,177 @@ func runPrune(cmd *cobra.Command, args []string) error {
 	}
 	if cancelled {
 		fmt.Println("Cancelled")
+		finishSkipped(skipped)
 		return nil
 	}
 
 	// Phase 2: execute removals
 	if len(toRemove) == 0 {
 		fmt.Println("No worktrees to remove")
+		finishSkipped(skipped)
 		return nil
 	}
 
 	fmt.Printf("Removing %d worktree(s)...\n", len(toRemove))
 
+	selected := make([]staleWorktree, len(toRemove))
+	for n, i := range toRemove {
+		selected[n] = stale[i]
+	}
+	removedCount := removeStaleWorktrees(selected)
+
+	fmt.Println()
+	ui.Success("Removed %d worktree(s)", removedCount)
+	finishSkipped(skipped)
+	ui.PrintCTA("wt list")
+	return nil
+}
+
+// findStaleWorktrees returns the worktrees whose branch has a merged or
+// closed PR (or, with mergedLocal, no commits beyond base), split into
+// those safe to remove and those skipped because they're locked or have
+// uncommitted changes (see staleWorktree.Skip). The main worktree, the one
+// containing cwd, and base branches are never stale.
+func findStaleWorktrees(ctx *cmdContext, cwd string, worktrees []git.Worktree, mergedPRs, closedPRs []github.PR, mergedLocal bool) (stale, skipped []staleWorktree) {
+	for _, wt := range worktrees {
+		// Skip main
+		if wt.Path == ctx.MainWorktree {
+			continue
+		}
+		// Skip current
+		if wt.Path == cwd || isSubpath(cwd, wt.Path) {
+			continue
+		}
+		// Directory is gone; git worktree prune below drops the entry.
+		if wt.Prunable {
+			continue
+		}
+
+		branch := wt.Branch
+		if branch == "" {
+			branch, _ = git.CurrentBranchIn(wt.Path)
+		}
+		if ctx.isBaseBranch(branch) {
+			continue
+		}
+
+		// Check PR status — by name, falling back to the head SHA for
+		// branches renamed after their PR was opened. The SHA fallback is
+		// skipped for branches with no commits beyond base (see prMatchHead).
+		mergedPR := github.FindPRForBranch(mergedPRs, branch)
+		closedPR := github.FindPRForBranch(closedPRs, branch)
+		if mergedPR == nil && closedPR == nil && wt.Head != "" {
+			if n, err := git.CountCommits(ctx.baseRef(), wt.Head); err == nil && n > 0 {
+				mergedPR = github.FindPRForBranchOrHead(mergedPRs, "", wt.Head)
+				closedPR = github.FindPRForBranchOrHead(closedPRs, "", wt.Head)
+			}
+		}
+
+		var reason, prHead string
+		switch {
+		case mergedPR != nil:
+			reason = fmt.Sprintf("PR #%d merged", mergedPR.Number)
+			prHead = mergedPR.HeadRefOid
+		case closedPR != nil:
+			reason = fmt.Sprintf("PR #%d closed", closedPR.Number)
+			prHead = closedPR.HeadRefOid
+		case mergedLocal && isMergedLocally(ctx, branch):
+			reason = "merged into " + ctx.baseRef()
+		default:
+			continue
+		}
+
+		// Stale but locked or has uncommitted changes — track separately
+		s := staleWorktree{Path: wt.Path, Branch: branch, Reason: reason, PRHead: prHead}
+		switch {
+		case wt.Locked:
+			s.Skip = lockedSkip(wt.LockReason)
+		case git.HasChangesIn(wt.Path):
+			s.Skip = skipDirty
+		}
+		if s.Skip != "" {
+			skipped = append(skipped, s)
+			continue
+		}
+
+		stale = append(stale, s)
+	}
+
+	return stale, skipped
+}
+
+// removeStaleWorktrees removes each worktree and deletes its local branch,
+// printing a line per worktree. Returns how many were removed.
+func removeStaleWorktrees(stale []staleWorktree) int {
+	// One for-each-ref up front instead of a show-ref fork per branch. If
+	// that fails, fall back to checking each branch, so none are left behind.
+	branchExists := git.BranchExists
+	if names, err := git.ListLocalBranches(); err == nil {
+		localBranches := make(map[string]bool, len(names))
+		for _, n := range names {
+			localBranches[n] = true
+		}
+		branchExists = func(name string) bool { return localBranches[name] }
+	}
+
 	removedCount := 0
-	for _, i := range toRemove {
-		s := stale[i]
+	for _, s := range stale {
 		short := filepath.Base(s.Path)
 
 		if err := git.RemoveWorktree(s.Path); err != nil {
 			fmt.Printf("  %s %s (failed)\n", ui.Red(ui.Fail), short)
 			continue
 		}
-
-		if git.BranchExists(s.Branch) {
-			_ = git.DeleteBranch(s.Branch)
+		_ = git.DeleteWorktreeMeta(s.Path)
+
+		if s.Branch != "" && branchExists(s.Branch) {
+			if err := git.DeleteBranch(s.Branch); err != nil {
+				fmt.Printf("  %s %s %s\n", ui.Yellow("!"), short, ui.Dim("(branch "+s.Branch+" kept: "+err.Error()+")"))
+				removedCount++
+				continue
+			}
 		}
 		fmt.Printf("  %s %s\n", ui.Green(ui.Pass), short)
 		removedCount++
 	}
 
-	fmt.Println()
-	ui.Success("Removed %d worktree(s)", removedCount)
-	printSkippedDirty(skippedDirty)
-	ui.PrintCTA("wt list")
+	return removedCount
+}
+
+// printPruneJSON prints stale worktrees, removable and skipped alike, as a
+// JSON array for prune --dry-run --output json.
+func printPruneJSON(stale, skipped []staleWorktree) error {
+	entries := make([]pruneJSONEntry, 0, len(stale)+len(skipped))
+	for _, s := range stale {
+		entries = append(entries, pruneJSONEntry{Path: s.Path, Branch: s.Branch, Reason: s.Reason})
+	}
+	for _, s := range skipped {
+		entries = append(entries, pruneJSONEntry{
+			Path:   s.Path,
+			Branch: s.Branch,
+			Reason: s.Reason,
+			Dirty:  s.Skip == skipDirty,
+			Locked: s.Skip != skipDirty,
+		})
+	}
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(data))
 	return nil
 }
 
+// isMergedLocally reports whether branch has no commits beyond the base ref.
+// Detached or unknown branches never count as merged.
+func isMergedLocally(ctx *cmdContext, branch string) bool {
+	if branch == "" {
+		return false
+	}
+	merged, err := git.IsMergedInto(branch, ctx.baseRef())
+	return err == nil && merged
+}
+
 // selectPruneTargets returns the indices of stale worktrees the user wants to remove.
 //
 //   - With --yes: all stale worktrees.
@@ -225,13 +400,60 @@ func selectPruneTargets(stale []staleWorktree) (indices []int, cancelled bool, e
 	return result.Selected, result.Cancelled, nil
 }
 
-func printSkippedDirty(skipped []staleWorktree) {
+// finishSkipped reports the stale worktrees prune left alone. Dirty ones
+// get a --force hint, or with --force (and not --dry-run) are offered for
+// removal one at a time.
+func finishSkipped(skipped []staleWorktree) {
+	printSkipped(skipped)
+
+	var dirty []staleWorktree
+	for _, s := range skipped {
+		if s.Skip == skipDirty {
+			dirty = append(dirty, s)
+		}
+	}
+	switch {
+	case len(dirty) == 0:
+		return
+	case pruneDryRun && pruneForce:
+		fmt.Printf("     %s\n", ui.Dim("wt prune --force would ask to remove these, discarding their changes"))
+		return
+	case !pruneForce:
+		fmt.Printf("     %s\n", ui.Dim("Use wt prune --force to remove them anyway (you're asked for each)"))
+		return
+	}
+
+	removed := 0
+	for _, s := range dirty {
+		short := filepath.Base(s.Path)
+		changes, _ := git.StatusPorcelainIn(s.Path)
+		fmt.Println()
+		ui.Warn("%s has %d uncommitted change(s) that will be lost:", short, len(changes))
+		for i, c := range changes {
+			if i == previewMaxFiles {
+				fmt.Printf("    %s\n", ui.Dim(fmt.Sprintf("… %d more", len(changes)-previewMaxFiles)))
+				break
+			}
+			fmt.Printf("    %s %s\n", ui.Dim(c.Status), c.Path)
+		}
+		if !ui.Confirm(fmt.Sprintf("Discard changes and remove %s?", short), false) {
+			continue
+		}
+		removed += removeStaleWorktrees([]staleWorktree{s})
+	}
+	if removed > 0 {
+		fmt.Println()
+		ui.Success("Removed %d worktree(s) with uncommitted changes", removed)
+	}
+}
+
+func printSkipped(skipped []staleWorktree) {
 	if len(skipped) == 0 {
 		return
 	}
 	fmt.Println()
 	for _, s := range skipped {
 		short := filepath.Base(s.Path)
-		fmt.Printf("  %s %s — %s, skipped (has uncommitted changes)\n", ui.Yellow("!"), short, s.Reason)
+		fmt.Printf("  %s %s — %s, skipped (%s)\n", ui.Yellow("!"), short, s.Reason, s.Skip)
 	}
 }
@@ -0,0 +1,135 @@
+package cmd
+
+import (
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mvwi/wt/internal/ui"
+)
+
+// captureStdout returns what fn prints to stdout.
+func captureStdout(t *testing.T, fn func()) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	orig := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = orig }()
+
+	done := make(chan string)
+	go func() {
+		b, _ := io.ReadAll(r)
+		done <- string(b)
+	}()
+	fn()
+	w.Close()
+	return <-done
+}
+
+func setPruneFlags(t *testing.T, dryRun, force bool) {
+	t.Helper()
+	origDryRun, origForce := pruneDryRun, pruneForce
+	pruneDryRun, pruneForce = dryRun, force
+	t.Cleanup(func() { pruneDryRun, pruneForce = origDryRun, origForce })
+}
+
+func TestFinishSkippedHints(t *testing.T) {
+	dirty := staleWorktree{Path: "/repos/app-feat", Branch: "feat", Reason: "PR #12 merged", Skip: skipDirty}
+	locked := staleWorktree{Path: "/repos/app-old", Branch: "old", Reason: "PR #9 closed", Skip: "locked"}
+
+	tests := []struct {
+		name    string
+		dryRun  bool
+		force   bool
+		skipped []staleWorktree
+		want    []string
+		notWant []string
+	}{
+		{
+			name:    "dirty suggests --force",
+			skipped: []staleWorktree{dirty},
+			want:    []string{"app-feat — PR #12 merged, skipped (has uncommitted changes)", "Use wt prune --force"},
+		},
+		{
+			name:    "dry run with --force",
+			dryRun:  true,
+			force:   true,
+			skipped: []staleWorktree{dirty},
+			want:    []string{"app-feat — PR #12 merged", "wt prune --force would ask to remove these"},
+			notWant: []string{"Use wt prune --force"},
+		},
+		{
+			name:    "locked only has no hint",
+			skipped: []staleWorktree{locked},
+			want:    []string{"app-old — PR #9 closed, skipped (locked)"},
+			notWant: []string{"--force"},
+		},
+		{
+			name:    "nothing skipped",
+			notWant: []string{"skipped", "--force"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setPruneFlags(t, tt.dryRun, tt.force)
+			out := captureStdout(t, func() { finishSkipped(tt.skipped) })
+			for _, w := range tt.want {
+				if !strings.Contains(out, w) {
+					t.Errorf("output missing %q:\n%s", w, out)
+				}
+			}
+			for _, w := range tt.notWant {
+				if strings.Contains(out, w) {
+					t.Errorf("output contains %q:\n%s", w, out)
+				}
+			}
+		})
+	}
+}
+
+func TestFinishSkippedForce(t *testing.T) {
+	root, err := filepath.EvalSymlinks(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	repo := filepath.Join(root, "app")
+	wtPath := filepath.Join(root, "app-feat")
+	for _, args := range [][]string{
+		{"init", "-q", "-b", "main", repo},
+		{"-C", repo, "-c", "user.name=t", "-c", "user.email=t@example.com", "commit", "-q", "--allow-empty", "-m", "init"},
+		{"-C", repo, "worktree", "add", "-q", "-b", "feat", wtPath},
+	} {
+		if out, err := exec.Command("git", args...).CombinedOutput(); err != nil {
+			t.Skipf("git %v failed: %v\n%s", args, err, out)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(wtPath, "notes.txt"), []byte("wip\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(repo)
+	setPruneFlags(t, false, true)
+	origYes := ui.YesFlag
+	ui.YesFlag = true
+	t.Cleanup(func() { ui.YesFlag = origYes })
+
+	skipped := []staleWorktree{{Path: wtPath, Branch: "feat", Reason: "PR #12 merged", Skip: skipDirty}}
+	out := captureStdout(t, func() { finishSkipped(skipped) })
+
+	for _, w := range []string{"?? notes.txt", "Removed 1 worktree(s) with uncommitted changes"} {
+		if !strings.Contains(out, w) {
+			t.Errorf("output missing %q:\n%s", w, out)
+		}
+	}
+	if _, err := os.Stat(wtPath); !os.IsNotExist(err) {
+		t.Errorf("worktree %s still exists", wtPath)
+	}
+	if err := exec.Command("git", "-C", repo, "show-ref", "--verify", "--quiet", "refs/heads/feat").Run(); err == nil {
+		t.Error("branch feat still exists")
+	}
+}
@@ -43,12 +43,8 @@ func runPull(cmd *cobra.Command, args []string) error {
 	}
 
 	// Fetch to get latest refs
-	spin := ui.NewSpinner("Fetching latest refs")
-	if err := git.FetchAll(ctx.Config.Remote); err != nil {
-		spin.Stop()
+	if err := fetchWithSpinner("Fetching latest refs", ctx.Config.Remote); err != nil {
 		ui.Warn("Fetch failed: %v", err)
-	} else {
-		spin.Stop()
 	}
 
 	ref, isRemote, err := git.ResolveBranch(branch, ctx.Config.Remote)
@@ -61,14 +57,16 @@ func runPull(cmd *cobra.Command, args []string) error {
 	}
 
 	if isRemote {
-		return createWorktreeFromRemote(ctx, name, ref, pullDoInit)
+		return createWorktreeFromRemote(ctx, name, ref, git.WorktreeMeta{From: ref}, pullDoInit)
 	}
 
 	// Local branch — same path as wt new --from with a local branch
-	wtPath := ctx.worktreePath(name)
-
-	if isDir(wtPath) {
-		return fmt.Errorf("worktree already exists: %s\n   Use wt switch %s to switch to it", wtPath, name)
+	wtPath, err := ctx.newWorktreePath(name)
+	if err != nil {
+		return err
+	}
+	if err := checkNewWorktreePath(name, wtPath); err != nil {
+		return err
 	}
 
 	fmt.Println("Creating worktree from existing branch...")
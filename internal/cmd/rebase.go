@@ -20,7 +20,8 @@ var rebaseCmd = &cobra.Command{
 For feature branches: stash → fetch → rebase → restore stash.
 For base/main branches: fast-forward merge only.
 
-Use --all to rebase all worktrees at once.`,
+Use --all to rebase all worktrees at once. It skips worktrees with
+uncommitted changes or whose directory is missing, and marks locked ones.`,
 	Example: `  wt rebase               Rebase current branch onto base branch
   wt rebase --all          Rebase all worktrees at once
   wt rebase --continue     Resume after resolving conflicts
@@ -47,6 +48,7 @@ type rebaseOpts struct {
 	continueRebase bool
 	abort          bool
 	all            bool
+	onto           string // local branch to rebase onto instead of base (stacked branches)
 }
 
 func runRebase(cmd *cobra.Command, args []string) error {
@@ -88,10 +90,14 @@ func runRebaseWith(opts rebaseOpts) error {
 		return rebaseBaseBranch(ctx, branch)
 	}
 
-	return rebaseFeatureBranch(ctx, branch)
+	return rebaseFeatureBranch(ctx, branch, opts.onto, "")
 }
 
-func rebaseFeatureBranch(ctx *cmdContext, branch string) error {
+// rebaseFeatureBranch rebases branch onto the remote base branch, or onto
+// onto when non-empty (a stacked branch's parent, or a PR's new base). With
+// upstream set, only the commits after upstream are replayed (git rebase
+// --onto), which moves a branch off its old base without its history.
+func rebaseFeatureBranch(ctx *cmdContext, branch, onto, upstream string) error {
 	inProgress, _ := git.IsRebaseInProgress()
 	if inProgress {
 		return fmt.Errorf("rebase already in progress\n   Resolve conflicts and run: wt rebase --continue\n   Or abort with: wt rebase --abort")
@@ -118,9 +124,14 @@ func rebaseFeatureBranch(ctx *cmdContext, branch string) error {
 	}
 	_ = git.SaveStateFile(stateFileName, stashState+":"+preRef)
 
+	target, targetName := ctx.baseRef(), ctx.Config.BaseBranch
+	if onto != "" {
+		target, targetName = onto, onto
+	}
+
 	// Fetch base branch
 	spin := ui.NewSpinner(fmt.Sprintf("Fetching %s", ctx.baseRef()))
-	if err := git.Fetch(ctx.Config.Remote, ctx.Config.BaseBranch); err != nil {
+	if err := ctx.fetchBase(); err != nil {
 		spin.Stop()
 		restoreStash(didStash)
 		git.RemoveStateFile(stateFileName)
@@ -129,17 +140,18 @@ func rebaseFeatureBranch(ctx *cmdContext, branch string) error {
 
 	spin.Stop()
 
-	// Check if up to date
-	ab, err := git.GetAheadBehind(ctx.baseRef())
-	if err == nil && ab.Behind == 0 {
+	// Check if up to date. Moving off upstream changes history even when
+	// nothing new is on target.
+	ab, err := git.GetAheadBehind(target)
+	if err == nil && ab.Behind == 0 && upstream == "" {
 		restoreStash(didStash)
 		git.RemoveStateFile(stateFileName)
-		ui.Success("Already up to date with %s", ctx.Config.BaseBranch)
+		ui.Success("Already up to date with %s", targetName)
 		return nil
 	}
 
 	// Conflict preview
-	conflicts, conflictErr := git.PotentialConflicts(ctx.baseRef())
+	conflicts, conflictErr := git.PotentialConflicts(target)
 	if conflictErr == nil && len(conflicts) > 0 {
 		fmt.Println()
 		ui.Warn("These files were modified on both branches:")
@@ -157,9 +169,22 @@ func rebaseFeatureBranch(ctx *cmdContext, branch string) error {
 	}
 
 	// Rebase
-	fmt.Printf("Rebasing onto %s (%d commit(s) behind)...\n\n", ctx.baseRef(), ab.Behind)
+	rebase := func() error { return git.Rebase(target) }
+	if upstream != "" {
+		fmt.Printf("Moving commits from %s onto %s...\n\n", upstream, target)
+		rebase = func() error { return git.RebaseOnto(target, upstream) }
+	} else {
+		fmt.Printf("Rebasing onto %s (%d commit(s) behind)...\n\n", target, ab.Behind)
+	}
 
-	if err := git.Rebase(ctx.baseRef()); err != nil {
+	if err := rebase(); err != nil {
+		// A rebase that never started (bad ref, hook refusal) isn't a
+		// conflict to resolve.
+		if paused, _ := git.IsRebaseInProgress(); !paused {
+			restoreStash(didStash)
+			git.RemoveStateFile(stateFileName)
+			return fmt.Errorf("rebase onto %s failed: %w", target, err)
+		}
 		fmt.Println()
 		ui.Warn("Rebase paused due to conflicts")
 		fmt.Println()
@@ -182,7 +207,7 @@ func rebaseFeatureBranch(ctx *cmdContext, branch string) error {
 	git.RemoveStateFile(stateFileName)
 	autoInstallIfNeeded(ctx, preRef)
 	fmt.Println()
-	ui.Success("Synced with %s", ctx.Config.BaseBranch)
+	ui.Success("Synced with %s", targetName)
 	return nil
 }
 
@@ -212,7 +237,7 @@ func rebaseBaseBranch(ctx *cmdContext, branch string) error {
 
 	// Fetch
 	spin := ui.NewSpinner(fmt.Sprintf("Fetching %s/%s", ctx.Config.Remote, branch))
-	if err := git.Fetch(ctx.Config.Remote, branch); err != nil {
+	if err := git.FetchBranch(ctx.Config.Remote, branch); err != nil {
 		spin.Stop()
 		restoreStash(didStash)
 		return fmt.Errorf("failed to fetch from remote: %w", err)
@@ -304,7 +329,7 @@ func rebaseAll(ctx *cmdContext) error {
 	fmt.Println()
 
 	spin := ui.NewSpinner(fmt.Sprintf("Fetching %s", ctx.baseRef()))
-	if err := git.Fetch(ctx.Config.Remote, ctx.Config.BaseBranch); err != nil {
+	if err := ctx.fetchBase(); err != nil {
 		spin.Stop()
 		ui.Warn("Fetch failed: %v", err)
 	} else {
@@ -315,24 +340,34 @@ func rebaseAll(ctx *cmdContext) error {
 	var rebased, skipped, uptodate, failed int
 
 	for _, wt := range worktrees {
+		if wt.Prunable || (wt.Locked && !isDir(wt.Path)) {
+			// Deleted, or locked on a drive that isn't mounted.
+			fmt.Printf("  %-25s %s%s\n", ctx.shortName(wt.Path), ui.Yellow("⚠ directory missing (skipped)"), lockedNote(wt))
+			skipped++
+			continue
+		}
+
 		branch, _ := git.CurrentBranchIn(wt.Path)
 		if ctx.isBaseBranch(branch) {
 			short := ctx.shortName(wt.Path)
 			fmt.Printf("  %-25s ", short)
-			remoteRef := ctx.Config.Remote + "/" + ctx.Config.BaseBranch
+			// Fast-forward each default branch from its own remote ref:
+			// a main worktree on "main" must not pick up origin/staging.
+			remoteRef := ctx.Config.Remote + "/" + branch
 			ab, err := git.GetAheadBehindIn(wt.Path, remoteRef)
 			if err != nil || ab.Behind == 0 {
-				fmt.Printf("%s\n", ui.Green("✓ up to date"))
+				fmt.Printf("%s%s\n", ui.Green("✓ up to date"), lockedNote(wt))
 				uptodate++
 			} else {
 				preRef, _ := git.RevParseHeadIn(wt.Path)
 				if err := git.MergeFFIn(wt.Path, remoteRef); err != nil {
-					fmt.Printf("%s\n", ui.Red("✗ fast-forward failed"))
+					fmt.Printf("%s%s\n", ui.Red("✗ fast-forward failed"), lockedNote(wt))
+					printGitFailure(err)
 					failed++
 				} else {
 					msg := fmt.Sprintf("✓ fast-forwarded (%d commits)", ab.Behind)
 					suffix := lockfileChangedSuffix(wt.Path, preRef)
-					fmt.Printf("%s%s\n", ui.Green(msg), suffix)
+					fmt.Printf("%s%s%s\n", ui.Green(msg), suffix, lockedNote(wt))
 					rebased++
 				}
 			}
@@ -343,14 +378,14 @@ func rebaseAll(ctx *cmdContext) error {
 		fmt.Printf("  %-25s ", short)
 
 		if git.HasChangesIn(wt.Path) {
-			fmt.Printf("%s\n", ui.Yellow("⚠ has uncommitted changes (skipped)"))
+			fmt.Printf("%s%s\n", ui.Yellow("⚠ has uncommitted changes (skipped)"), lockedNote(wt))
 			skipped++
 			continue
 		}
 
 		ab, err := git.GetAheadBehindIn(wt.Path, ctx.baseRef())
 		if err != nil || ab.Behind == 0 {
-			fmt.Printf("%s\n", ui.Green("✓ up to date"))
+			fmt.Printf("%s%s\n", ui.Green("✓ up to date"), lockedNote(wt))
 			uptodate++
 			continue
 		}
@@ -358,12 +393,13 @@ func rebaseAll(ctx *cmdContext) error {
 		preRef, _ := git.RevParseHeadIn(wt.Path)
 		if err := git.RebaseIn(wt.Path, ctx.baseRef()); err != nil {
 			git.RebaseAbortIn(wt.Path)
-			fmt.Printf("%s\n", ui.Red("✗ conflicts (aborted, rebase manually)"))
+			fmt.Printf("%s%s\n", ui.Red("✗ conflicts (aborted, rebase manually)"), lockedNote(wt))
+			printGitFailure(err)
 			failed++
 		} else {
 			msg := fmt.Sprintf("✓ rebased (%d commits from %s)", ab.Behind, ctx.Config.BaseBranch)
 			suffix := lockfileChangedSuffix(wt.Path, preRef)
-			fmt.Printf("%s%s\n", ui.Green(msg), suffix)
+			fmt.Printf("%s%s%s\n", ui.Green(msg), suffix, lockedNote(wt))
 			rebased++
 		}
 	}
@@ -377,7 +413,7 @@ func rebaseAll(ctx *cmdContext) error {
 		fmt.Printf("  ✓ %d already up to date\n", uptodate)
 	}
 	if skipped > 0 {
-		fmt.Printf("  %s\n", ui.Yellow(fmt.Sprintf("⚠ %d skipped (uncommitted changes)", skipped)))
+		fmt.Printf("  %s\n", ui.Yellow(fmt.Sprintf("⚠ %d skipped (uncommitted changes or missing)", skipped)))
 	}
 	if failed > 0 {
 		fmt.Printf("  %s\n", ui.Red(fmt.Sprintf("✗ %d failed (conflicts)", failed)))
@@ -457,8 +493,40 @@ func autoInstallIfNeeded(ctx *cmdContext, preRef string) {
 	fmt.Println()
 	fmt.Printf("Lockfile changed (%s) — running install...\n", lockfile)
 	fmt.Printf("  %s %s\n", ui.Dim("$"), command)
-	if err := runShellString(cwd, command); err != nil {
+	if err := ctx.runInitCommand(cwd, command); err != nil {
 		fmt.Println()
 		ui.Warn("Install failed — run manually: %s", command)
 	}
 }
+
+// printGitFailure prints what git said about a failed rebase or merge
+// (the commit that didn't apply, CONFLICT lines) under a wt rebase --all
+// status line, up to previewMaxFiles lines.
+func printGitFailure(err error) {
+	msg := err.Error()
+	if strings.HasPrefix(msg, "git ") {
+		if _, rest, ok := strings.Cut(msg, ": "); ok {
+			msg = rest
+		}
+	}
+	lines := strings.Split(msg, "\n")
+	for i, line := range lines {
+		if i == previewMaxFiles {
+			fmt.Printf("    %s\n", ui.Dim(fmt.Sprintf("… %d more", len(lines)-previewMaxFiles)))
+			break
+		}
+		fmt.Printf("    %s\n", ui.Dim(line))
+	}
+}
+
+// lockedNote marks a locked worktree's line in rebase --all output, so a
+// lock set with wt lock or git worktree lock isn't a surprise.
+func lockedNote(wt git.Worktree) string {
+	if !wt.Locked {
+		return ""
+	}
+	if wt.LockReason != "" {
+		return ui.Dim(" (locked: " + wt.LockReason + ")")
+	}
+	return ui.Dim(" (locked)")
+}
@@ -0,0 +1,148 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mvwi/wt/internal/git"
+	"github.com/mvwi/wt/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var relocateCmd = &cobra.Command{
+	Use:     "relocate <name> <dest>",
+	GroupID: groupManage,
+	Short:   "Move a worktree's directory to a new path",
+	Long: `Move a worktree's directory on disk (git worktree move). The branch,
+its commits, and any uncommitted changes come along untouched.
+
+If <dest> is an existing directory, the worktree is moved into it under
+its current directory name; otherwise <dest> is the new path. A leading
+~/ is expanded, and relative paths are taken from the current directory.
+
+Not to be confused with:
+  - wt move (mv): moves uncommitted changes between worktrees
+  - wt rename:    renames the branch and directory to match conventions
+
+wt finds worktrees through git, so a relocated worktree still shows up in
+wt list and wt switch, under its new directory name.`,
+	Example: `  wt relocate sidebar ~/scratch             Move into ~/scratch/<dir>
+  wt relocate sidebar ../wt-app/sidebar-v1  Move to an exact path`,
+	Args: cobra.ExactArgs(2),
+	ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return completeWorktreeNames(cmd, args, toComplete)
+		}
+		return nil, cobra.ShellCompDirectiveFilterDirs
+	},
+	RunE: runRelocate,
+}
+
+func init() {
+	rootCmd.AddCommand(relocateCmd)
+}
+
+func runRelocate(cmd *cobra.Command, args []string) error {
+	ctx, err := newContext()
+	if err != nil {
+		return err
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("cannot determine current directory: %w", err)
+	}
+	worktrees, err := git.ListWorktrees()
+	if err != nil {
+		return err
+	}
+
+	src, fuzzy, err := resolveWorktree(ctx, worktrees, args[0])
+	if err != nil {
+		return err
+	}
+	if src == "" {
+		return fmt.Errorf("worktree not found: %s\n   Run wt list to see available worktrees", args[0])
+	}
+	if src == ctx.MainWorktree {
+		return fmt.Errorf("cannot relocate the main repository worktree")
+	}
+
+	dest, err := relocateTarget(src, args[1], cwd)
+	if err != nil {
+		return err
+	}
+	if dest == src {
+		ui.Success("Already at %s", dest)
+		return nil
+	}
+	if isSubpath(dest, src) {
+		return fmt.Errorf("cannot move a worktree inside itself: %s", dest)
+	}
+	if fileExists(dest) {
+		return fmt.Errorf("destination already exists: %s\n   Choose a new path, or an existing directory to move into", dest)
+	}
+
+	if fuzzy && !ui.Confirm(fmt.Sprintf("Relocate %s to %s?", ctx.shortName(src), dest), false) {
+		fmt.Println("Cancelled")
+		return nil
+	}
+
+	if err := git.MoveWorktree(src, dest); err != nil {
+		return fmt.Errorf("failed to move worktree: %w", err)
+	}
+	_ = git.MoveWorktreeMeta(src, dest)
+	if prev, err := git.ReadSharedStateFile(prevWorktreeStateFile); err == nil {
+		if moved, ok := relocatedPath(strings.TrimSpace(prev), src, dest); ok {
+			savePreviousWorktree(moved)
+		}
+	}
+
+	ui.Success("Moved %s → %s", ctx.shortName(src), dest)
+	if moved, ok := relocatedPath(cwd, src, dest); ok {
+		ui.PrintCdHint(moved)
+	}
+	return nil
+}
+
+// relocateTarget resolves wt relocate's <dest> for the worktree at src to
+// an absolute path, the way mv does: an existing directory gets the
+// worktree moved into it under its current name.
+func relocateTarget(src, dest, cwd string) (string, error) {
+	if dest == "" {
+		return "", fmt.Errorf("destination is empty")
+	}
+	if dest == "~" || strings.HasPrefix(dest, "~/") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("cannot expand ~: %w", err)
+		}
+		dest = filepath.Join(home, dest[1:])
+	}
+	if !filepath.IsAbs(dest) {
+		dest = filepath.Join(cwd, dest)
+	}
+	dest = filepath.Clean(dest)
+	if isDir(dest) && dest != src {
+		dest = filepath.Join(dest, filepath.Base(src))
+	}
+	return dest, nil
+}
+
+// relocatedPath maps path, if it's src or inside it, to the same place
+// under dest.
+func relocatedPath(path, src, dest string) (string, bool) {
+	if path == src {
+		return dest, true
+	}
+	if !isSubpath(path, src) {
+		return "", false
+	}
+	rel, err := filepath.Rel(src, path)
+	if err != nil {
+		return "", false
+	}
+	return filepath.Join(dest, rel), true
+}
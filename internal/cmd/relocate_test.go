@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRelocateTarget(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	root := t.TempDir()
+	src := filepath.Join(root, "wt-repo", "feat")
+	existing := filepath.Join(root, "scratch")
+	if err := os.MkdirAll(existing, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		dest string
+		want string
+	}{
+		{dest: filepath.Join(root, "elsewhere"), want: filepath.Join(root, "elsewhere")},
+		{dest: existing, want: filepath.Join(existing, "feat")},
+		{dest: "scratch", want: filepath.Join(existing, "feat")},
+		{dest: "../x/../y", want: filepath.Join(filepath.Dir(root), "y")},
+		{dest: "~/feat", want: filepath.Join(home, "feat")},
+		{dest: "~", want: filepath.Join(home, "feat")},
+	}
+	for _, tt := range tests {
+		got, err := relocateTarget(src, tt.dest, root)
+		if err != nil {
+			t.Errorf("relocateTarget(%q) error: %v", tt.dest, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("relocateTarget(%q) = %q, want %q", tt.dest, got, tt.want)
+		}
+	}
+
+	if _, err := relocateTarget(src, "", root); err == nil {
+		t.Error("relocateTarget(\"\") succeeded, want error")
+	}
+}
+
+func TestRelocatedPath(t *testing.T) {
+	src := filepath.FromSlash("/src/wt-repo/feat")
+	dest := filepath.FromSlash("/tmp/feat")
+
+	tests := []struct {
+		path string
+		want string
+		ok   bool
+	}{
+		{path: src, want: dest, ok: true},
+		{path: filepath.Join(src, "pkg", "a"), want: filepath.Join(dest, "pkg", "a"), ok: true},
+		{path: filepath.FromSlash("/src/wt-repo/feature"), ok: false},
+		{path: filepath.FromSlash("/src/repo"), ok: false},
+	}
+	for _, tt := range tests {
+		got, ok := relocatedPath(tt.path, src, dest)
+		if ok != tt.ok || got != tt.want {
+			t.Errorf("relocatedPath(%q) = %q, %v; want %q, %v", tt.path, got, ok, tt.want, tt.ok)
+		}
+	}
+}
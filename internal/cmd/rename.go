@@ -21,19 +21,27 @@ var renameCmd = &cobra.Command{
 Renames:
   - Local branch: <old> → <prefix>/<name>
   - Worktree directory: wt-<repo>/<old> → wt-<repo>/<name>
-  - Remote branch: origin/<old> → origin/<prefix>/<name> (recreates open PRs)`,
+  - Remote branch: origin/<old> → origin/<prefix>/<name> (recreates open PRs)
+
+With --dry-run, prints the plan and exits without prompting or changing
+anything.`,
 	Example: `  wt rename sidebar-v2     Rename branch, directory, and remote
   wt rename fix --local    Rename locally only (skip remote)
+  wt rename fix --dry-run  Show the rename plan without changing anything
   wt rename fix --yes      Rename without confirmation prompt`,
 	Args:              cobra.ExactArgs(1),
 	ValidArgsFunction: completeWorktreeNames,
 	RunE:              runRename,
 }
 
-var renameLocalOnly bool
+var (
+	renameLocalOnly bool
+	renameDryRun    bool
+)
 
 func init() {
 	renameCmd.Flags().BoolVar(&renameLocalOnly, "local", false, "only rename locally (skip remote)")
+	renameCmd.Flags().BoolVar(&renameDryRun, "dry-run", false, "show the rename plan without changing anything")
 	rootCmd.AddCommand(renameCmd)
 }
 
@@ -53,7 +61,7 @@ func runRename(cmd *cobra.Command, args []string) error {
 	}
 
 	// Safety: must be in a worktree, not main repo
-	if cwd == ctx.MainWorktree || isSubpath(cwd, ctx.MainWorktree) {
+	if ctx.inMainWorktree(cwd) {
 		return fmt.Errorf("cannot rename the main repository\n   Switch to a worktree first: wt switch <name>")
 	}
 	if ctx.isBaseBranch(currentBranch) {
@@ -67,14 +75,17 @@ func runRename(cmd *cobra.Command, args []string) error {
 	if ctx.Config.BranchPrefix != nil {
 		prefix = *ctx.Config.BranchPrefix
 	} else {
-		prefix = ctx.Username
+		prefix = ctx.Username()
 	}
 	if prefix != "" {
 		newName = strings.TrimPrefix(newName, prefix+"/")
 	}
 
 	newBranch := ctx.branchName(newName)
-	newPath := ctx.worktreePath(newName)
+	newPath, err := ctx.newWorktreePath(newName)
+	if err != nil {
+		return err
+	}
 
 	// Already correct?
 	if currentBranch == newBranch && cwd == newPath {
@@ -132,6 +143,10 @@ func runRename(cmd *cobra.Command, args []string) error {
 	}
 
 	fmt.Println()
+	if renameDryRun {
+		fmt.Println("No changes made (--dry-run)")
+		return nil
+	}
 	if !ui.Confirm("Proceed?", false) {
 		fmt.Println("Cancelled")
 		return nil
@@ -144,6 +159,7 @@ func runRename(cmd *cobra.Command, args []string) error {
 		if err := git.RenameBranch(currentBranch, newBranch); err != nil {
 			return fmt.Errorf("failed to rename branch: %w", err)
 		}
+		_ = git.ReparentWorktreeMeta(currentBranch, newBranch)
 	}
 
 	// Step 2: Move worktree directory
@@ -156,9 +172,11 @@ func runRename(cmd *cobra.Command, args []string) error {
 				if rbErr := git.RenameBranch(newBranch, currentBranch); rbErr != nil {
 					return fmt.Errorf("failed to move worktree: %w (rollback also failed: %v)", err, rbErr)
 				}
+				_ = git.ReparentWorktreeMeta(newBranch, currentBranch)
 			}
 			return fmt.Errorf("failed to move worktree: %w", err)
 		}
+		_ = git.MoveWorktreeMeta(cwd, newPath)
 		ui.PrintCdHint(newPath)
 	}
 
@@ -191,6 +209,7 @@ func runRename(cmd *cobra.Command, args []string) error {
 					fmt.Printf("   Create manually: %s\n", ui.Cyan("gh pr create"))
 				} else {
 					fmt.Printf("   %s\n", url)
+					restorePRAssignments(ctx, url, prDetails)
 				}
 			}
 		}
@@ -209,3 +228,35 @@ func runRename(cmd *cobra.Command, args []string) error {
 	}
 	return nil
 }
+
+// restorePRAssignments asks the old PR's reviewers for review again and
+// re-adds its assignees on the recreated PR at url. The author can't review
+// their own PR, so they're left out of reviewers.
+func restorePRAssignments(ctx *cmdContext, url string, old *github.PRDetails) {
+	ref, ok := github.ParseRef(url)
+	if !ok || ref.Kind != github.RefPR {
+		return
+	}
+
+	me, _ := github.CurrentUser(ctx.remoteHost())
+	var reviewers, assignees []string
+	for _, r := range old.Reviewers() {
+		if !containsLogin([]string{r}, me) {
+			reviewers = append(reviewers, r)
+		}
+	}
+	for _, a := range old.Assignees {
+		assignees = append(assignees, a.Login)
+	}
+	if len(reviewers) == 0 && len(assignees) == 0 {
+		return
+	}
+
+	if err := github.AssignPR(ref.Number, reviewers, assignees); err != nil {
+		ui.Warn("Could not restore reviewers and assignees: %v", err)
+		return
+	}
+	if len(reviewers) > 0 {
+		fmt.Printf("   Re-requested review from %s\n", strings.Join(reviewers, ", "))
+	}
+}
@@ -0,0 +1,146 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/mvwi/wt/internal/git"
+	"github.com/mvwi/wt/internal/github"
+	"github.com/mvwi/wt/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var reopenCmd = &cobra.Command{
+	Use:     "reopen <number|url|name>",
+	GroupID: groupWorkflow,
+	Short:   "Reopen a closed PR and recreate its worktree",
+	Long: `Reopen a closed pull request, and recreate a worktree for its branch if
+there isn't one anymore.
+
+Takes a PR number or URL, or a worktree or branch name whose PR was closed.
+GitHub can't reopen a PR whose branch was deleted, so the branch has to be
+pushed again (or restored from the PR page) first. Merged PRs can't be
+reopened.
+
+Requires the GitHub CLI (gh) to be installed.`,
+	Example: `  wt reopen 123         Reopen PR #123 and recreate its worktree
+  wt reopen sidebar     Reopen the closed PR for the sidebar branch`,
+	Args: cobra.ExactArgs(1),
+	RunE: runReopen,
+}
+
+func init() {
+	rootCmd.AddCommand(reopenCmd)
+}
+
+func runReopen(cmd *cobra.Command, args []string) error {
+	if !github.IsAvailable() {
+		return fmt.Errorf("gh CLI is required for this command (brew install gh)")
+	}
+	if err := github.CheckAuth(); err != nil {
+		return err
+	}
+
+	ctx, err := newContext()
+	if err != nil {
+		return err
+	}
+
+	number, err := resolveReopenTarget(ctx, args[0])
+	if err != nil {
+		return err
+	}
+
+	pr, err := github.GetPRByNumber(number)
+	if err != nil {
+		return prNumberError(number, err)
+	}
+	fmt.Printf("PR #%d: %s\n", pr.Number, pr.Title)
+	fmt.Println()
+
+	switch pr.State {
+	case "MERGED":
+		return fmt.Errorf("PR #%d is merged and can't be reopened\n   Start new work with: wt new --from %s", pr.Number, pr.HeadRefName)
+	case "OPEN":
+		ui.Info("PR #%d is already open", pr.Number)
+	default:
+		// A PR's head branch must exist for GitHub to reopen it. Prune so a
+		// branch deleted on the remote doesn't linger as a stale ref.
+		if !pr.IsCrossRepository {
+			if err := fetchWithSpinner("Fetching latest refs", "--prune", ctx.Config.Remote); err != nil {
+				ui.Warn("Fetch failed: %v", err)
+			}
+			if !git.RemoteBranchExists(ctx.Config.Remote + "/" + pr.HeadRefName) {
+				if git.BranchExists(pr.HeadRefName) {
+					return fmt.Errorf("branch %s was deleted from %s, so PR #%d can't be reopened\n   Push it again first: git push -u %s %s",
+						pr.HeadRefName, ctx.Config.Remote, pr.Number, ctx.Config.Remote, pr.HeadRefName)
+				}
+				return fmt.Errorf("branch %s was deleted from %s, so PR #%d can't be reopened\n   Restore it with the \"Restore branch\" button on the PR page, then run this again",
+					pr.HeadRefName, ctx.Config.Remote, pr.Number)
+			}
+		}
+		if err := github.ReopenPR(pr.Number); err != nil {
+			return fmt.Errorf("failed to reopen PR #%d: %w", pr.Number, err)
+		}
+		ui.Success("Reopened PR #%d", pr.Number)
+	}
+
+	// Recreate the worktree unless the branch still has one
+	worktrees, err := git.ListWorktrees()
+	if err != nil {
+		return err
+	}
+	for _, wt := range worktrees {
+		if wt.Branch == pr.HeadRefName {
+			fmt.Println()
+			fmt.Printf("  %s\n", ui.Dim("Worktree still exists: "+ctx.shortName(wt.Path)))
+			ui.PrintCTA("wt switch " + ctx.shortName(wt.Path))
+			return nil
+		}
+	}
+	fmt.Println()
+
+	name := nameFromBranch(pr.HeadRefName, ctx.Config.Remote)
+	meta := git.WorktreeMeta{PR: pr.Number, Fork: pr.IsCrossRepository}
+	return createWorktreeFromRemote(ctx, name, pr.HeadRefName, meta, false)
+}
+
+// resolveReopenTarget returns the PR number for a wt reopen argument: a PR
+// number or URL, or a worktree or branch name whose PR was closed.
+func resolveReopenTarget(ctx *cmdContext, arg string) (int, error) {
+	if n, ok := parsePRNumber(arg); ok {
+		return n, nil
+	}
+	if ref, ok := github.ParseRef(arg); ok && ref.Kind == github.RefPR {
+		if err := checkRefRepo(ctx, ref); err != nil {
+			return 0, err
+		}
+		return ref.Number, nil
+	}
+
+	// A name: the branch of a worktree that still exists, or a branch name
+	// as given or built from the configured prefix
+	branches := []string{arg, ctx.branchName(arg)}
+	if worktrees, err := git.ListWorktrees(); err == nil {
+		if path, fuzzy, _ := resolveWorktree(ctx, worktrees, arg); path != "" && !fuzzy {
+			if b, err := git.CurrentBranchIn(path); err == nil {
+				branches = append([]string{b}, branches...)
+			}
+		}
+	}
+
+	// Look the branches up by head rather than scanning the capped list of
+	// recently closed PRs, which misses older ones in busy repos.
+	spin := ui.NewSpinner("Finding closed PR")
+	prs, err := github.ListPRsForBranches(branches)
+	spin.Stop()
+	if err != nil {
+		return 0, fmt.Errorf("could not fetch closed PRs: %w", err)
+	}
+	_, _, closed := addPRsByState(prs, nil, nil, nil)
+	for _, b := range branches {
+		if pr := github.FindPRForBranch(closed, b); pr != nil {
+			return pr.Number, nil
+		}
+	}
+	return 0, fmt.Errorf("no closed PR found for %s\n   Pass the PR number instead: wt reopen <number>", arg)
+}
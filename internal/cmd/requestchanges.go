@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"github.com/mvwi/wt/internal/github"
+	"github.com/spf13/cobra"
+)
+
+var requestChangesCmd = &cobra.Command{
+	Use:     "request-changes [name|#PR] [-- reason]",
+	GroupID: groupWorkflow,
+	Short:   "Request changes on a worktree's PR",
+	Long: `Submit a "request changes" review on the pull request for a worktree.
+
+Without a name, reviews the current worktree's PR, including a detached
+one from wt pr --detached. A PR number works in place of a name. The
+reason follows "--"; without one, it's read from stdin when piped, or
+composed in $EDITOR.
+
+Requires the GitHub CLI (gh) to be installed.`,
+	Example: `  wt request-changes -- "Needs a test for the empty case"
+  wt request-changes sidebar -- "Breaks dark mode"
+  wt request-changes sidebar                       Compose in $EDITOR`,
+	ValidArgsFunction: completeWorktreeNames,
+	RunE:              runRequestChanges,
+}
+
+func init() {
+	rootCmd.AddCommand(requestChangesCmd)
+}
+
+func runRequestChanges(cmd *cobra.Command, args []string) error {
+	return runReview(cmd, args, github.ReviewRequestChanges)
+}
@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mvwi/wt/internal/git"
+	"github.com/mvwi/wt/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var restoreCmd = &cobra.Command{
+	Use:     "restore <branch> [name]",
+	GroupID: groupManage,
+	Short:   "Recreate a worktree for a local branch",
+	Long: `Recreate a worktree for an existing local branch that has none, e.g.
+after wt archive or wt close --keep-branch.
+
+The worktree goes back to its conventional path. Branches shelved with
+wt archive get their old name and metadata (stack parent, PR) back.
+Errors if the branch already has a worktree.`,
+	Example: `  wt restore michael/sidebar           Restore as "sidebar"
+  wt restore michael/sidebar side      Restore with a custom name
+  wt restore michael/sidebar -i        Restore and run wt init`,
+	Args:              cobra.RangeArgs(1, 2),
+	ValidArgsFunction: completeBranchesWithoutWorktrees,
+	RunE:              runRestore,
+}
+
+var restoreDoInit bool
+
+func init() {
+	restoreCmd.Flags().BoolVarP(&restoreDoInit, "init", "i", false, "run 'wt init' after restoring")
+	rootCmd.AddCommand(restoreCmd)
+}
+
+func runRestore(cmd *cobra.Command, args []string) error {
+	ctx, err := newContext()
+	if err != nil {
+		return err
+	}
+
+	branch := args[0]
+	if !git.BranchExists(branch) {
+		return fmt.Errorf("no local branch named %s\n   For a remote branch, use wt pull %s", branch, branch)
+	}
+
+	worktrees, err := git.ListWorktrees()
+	if err != nil {
+		return err
+	}
+	for _, wt := range worktrees {
+		if wt.Branch == branch {
+			return fmt.Errorf("%s already has a worktree: %s\n   Run wt switch %s", branch, wt.Path, ctx.shortName(wt.Path))
+		}
+	}
+
+	// Archived branches come back under their old name, with their metadata
+	var archived *git.ArchivedBranch
+	if all, err := git.ListArchivedBranches(); err == nil {
+		for i := range all {
+			if all[i].Branch == branch {
+				archived = &all[i]
+				break
+			}
+		}
+	}
+
+	name := nameFromBranch(branch, ctx.Config.Remote)
+	meta := git.WorktreeMeta{From: branch}
+	if archived != nil {
+		if archived.Path != "" {
+			name = ctx.shortName(archived.Path)
+		}
+		meta = archived.Meta
+		meta.Init = nil // a fresh checkout needs init again
+	}
+	if len(args) > 1 {
+		name = args[1]
+	}
+
+	wtPath, err := ctx.newWorktreePath(name)
+	if err != nil {
+		return err
+	}
+	if err := checkNewWorktreePath(name, wtPath); err != nil {
+		return err
+	}
+
+	fmt.Println("Restoring worktree...")
+	fmt.Printf("  Directory: %s\n", wtPath)
+	fmt.Printf("  Branch: %s\n", branch)
+	fmt.Println()
+
+	if err := git.AddWorktreeFromExisting(wtPath, branch); err != nil {
+		return fmt.Errorf("failed to create worktree: %w", err)
+	}
+	_ = git.SaveWorktreeMeta(wtPath, meta)
+	if archived != nil {
+		_ = git.DeleteArchivedBranch(branch)
+	}
+
+	fmt.Println()
+	ui.Success("Restored worktree")
+	fmt.Println()
+
+	if restoreDoInit {
+		return runInitIn(wtPath, ctx)
+	}
+	printSwitchHint(name)
+	return nil
+}
+
+// completeBranchesWithoutWorktrees suggests local branches that aren't
+// checked out in any worktree.
+func completeBranchesWithoutWorktrees(_ *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	branches, err := git.ListLocalBranches()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	worktrees, err := git.ListWorktrees()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	checkedOut := make(map[string]bool)
+	for _, wt := range worktrees {
+		checkedOut[wt.Branch] = true
+	}
+	var names []string
+	for _, b := range branches {
+		if !checkedOut[b] && strings.HasPrefix(b, toComplete) {
+			names = append(names, b)
+		}
+	}
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
@@ -5,10 +5,14 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"runtime"
+	"strconv"
 	"strings"
 
+	"github.com/mvwi/wt/internal/config"
 	"github.com/mvwi/wt/internal/git"
 	"github.com/mvwi/wt/internal/github"
+	"github.com/mvwi/wt/internal/trace"
 	"github.com/mvwi/wt/internal/ui"
 	"github.com/mvwi/wt/internal/update"
 	"github.com/spf13/cobra"
@@ -22,6 +26,17 @@ var errSilent = errors.New("")
 // in PersistentPreRunE before any subcommand reads cwd.
 var cwdOverride string
 
+// verbose and debug are set by the persistent --verbose and --debug
+// flags. Either one builds tracer, which the git and github packages log
+// their commands through.
+var (
+	verbose bool
+	debug   bool
+	tracer  *trace.Logger
+	// debugLog is the open --debug log file, if any.
+	debugLog *os.File
+)
+
 // Version is set at build time via -ldflags.
 var Version = "dev"
 
@@ -60,9 +75,17 @@ const (
 
 // Execute runs the root command.
 func Execute() {
-	update.CheckInBackground()
+	checkUpdates := updateChecksEnabled()
+	if checkUpdates {
+		update.CheckInBackground()
+	}
 
-	if err := rootCmd.Execute(); err != nil {
+	cmd, err := rootCmd.ExecuteC()
+	closeDebugLog(err)
+	if err != nil {
+		if errors.Is(err, errInterrupted) {
+			os.Exit(130)
+		}
 		if !errors.Is(err, errSilent) {
 			// Cobra includes "Did you mean this?" in the error message
 			// when SuggestionsMinimumDistance is set. Since we silence
@@ -72,16 +95,14 @@ func Execute() {
 		os.Exit(1)
 	}
 
-	// Suppress update banner for shell-infrastructure commands that run
-	// during shell init (their stderr is visible even though stdout is piped).
-	if !isShellInitCommand() {
+	if checkUpdates && showUpdateBanner(cmd) {
 		if info := update.GetUpdateInfo(Version); info != nil {
 			fmt.Fprintf(os.Stderr, "\n%s %s → %s\n",
 				ui.Cyan(ui.PushUp+" Update available:"),
 				ui.Dim(info.Current),
 				ui.Cyan(info.Latest),
 			)
-			fmt.Fprintf(os.Stderr, "  %s\n", ui.Dim("brew upgrade wt"))
+			fmt.Fprintf(os.Stderr, "  %s\n", ui.Dim(update.UpgradeHint()))
 		}
 	}
 }
@@ -92,8 +113,13 @@ func init() {
 	rootCmd.PersistentFlags().BoolVarP(&ui.YesFlag, "yes", "y", false, "skip confirmation prompts (answer yes to all)")
 	rootCmd.PersistentFlags().StringVarP(&cwdOverride, "directory", "C", "", "run as if started in the given directory (like `git -C`)")
 	_ = rootCmd.MarkPersistentFlagDirname("directory")
+	rootCmd.PersistentFlags().BoolVar(&verbose, "verbose", false, "log the git and gh commands run, and their stderr when they fail")
+	rootCmd.PersistentFlags().BoolVar(&debug, "debug", false, "write a timestamped trace of this run to ~/.config/wt/logs/")
 
-	rootCmd.PersistentPreRunE = applyCwdOverride
+	rootCmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		setupTracing()
+		return applyCwdOverride(cmd, args)
+	}
 
 	rootCmd.AddGroup(
 		&cobra.Group{ID: groupWorkflow, Title: "Workflow:"},
@@ -102,6 +128,66 @@ func init() {
 	)
 }
 
+// updateChecksEnabled reports whether wt may check for a new release and
+// show the update banner. WT_NO_UPDATE_CHECK wins, then check_updates in the
+// global config. An unreadable config leaves checks on; the command that
+// runs next reports the config error.
+func updateChecksEnabled() bool {
+	if v, err := strconv.ParseBool(os.Getenv("WT_NO_UPDATE_CHECK")); err == nil {
+		return !v
+	}
+	cfg, err := config.LoadGlobal()
+	if err != nil {
+		return true
+	}
+	return cfg.EffectiveCheckUpdates()
+}
+
+// setupTracing builds tracer from --verbose and --debug and injects it into
+// the git and github packages. A debug log that can't be opened is a
+// warning, not a failure: the command itself should still run.
+func setupTracing() {
+	if !verbose && !debug {
+		return
+	}
+	tracer = &trace.Logger{}
+	if verbose {
+		tracer.Verbose = os.Stderr
+	}
+	if debug {
+		f, err := trace.OpenFile()
+		if err != nil {
+			ui.Warn("Could not open debug log: %v", err)
+		} else {
+			debugLog = f
+			tracer.File = f
+			cwd, _ := os.Getwd()
+			tracer.Printf("wt %s (%s/%s) in %s", Version, runtime.GOOS, runtime.GOARCH, cwd)
+			tracer.Printf("args: %s", strings.Join(os.Args[1:], " "))
+		}
+	}
+	git.Logger = tracer
+	github.Logger = tracer
+}
+
+// closeDebugLog records how the run ended in the --debug log, closes it,
+// and says where it is.
+func closeDebugLog(err error) {
+	if debugLog == nil {
+		return
+	}
+	switch {
+	case err == nil:
+		tracer.Printf("done")
+	case errors.Is(err, errSilent):
+		tracer.Printf("failed (error already shown)")
+	default:
+		tracer.Printf("failed: %v", err)
+	}
+	_ = debugLog.Close()
+	fmt.Fprintf(os.Stderr, "%s\n", ui.Dim("Debug log: "+debugLog.Name()))
+}
+
 // applyCwdOverride chdir's into the path passed via -C/--directory before any
 // subcommand runs. All commands read cwd via os.Getwd() and run git from there,
 // so a single chdir at startup propagates transparently to every command.
@@ -119,19 +205,58 @@ func applyCwdOverride(_ *cobra.Command, _ []string) error {
 	return nil
 }
 
-// isShellInitCommand returns true for commands that run during shell startup
-// (init-shell, completion) where stderr output would be disruptive.
-func isShellInitCommand() bool {
-	if len(os.Args) < 2 {
+// showUpdateBanner reports whether the update banner may follow cmd's
+// output. It goes to stderr, so it needs stderr to be a terminal: otherwise
+// it would land in logs or captured output. It's also left out after:
+//   - machine-readable output (--json, --output, --quiet), so a forgotten
+//     2>&1 doesn't corrupt it
+//   - commands that run during shell startup, prompt rendering, or
+//     completion (init-shell, completion, prompt, __complete, _preview),
+//     whose stderr is visible even though stdout is captured
+//   - wt upgrade, where Version still names the old binary, and
+//     wt version, which reports updates itself
+func showUpdateBanner(cmd *cobra.Command) bool {
+	if !ui.IsStderrTTY() || machineReadable(cmd) {
 		return false
 	}
-	switch os.Args[1] {
-	case "init-shell", "completion":
-		return true
+	switch topLevelName(cmd) {
+	case "init-shell", "completion", "prompt", cobra.ShellCompRequestCmd, cobra.ShellCompNoDescRequestCmd, "_preview",
+		"upgrade", "version":
+		return false
+	}
+	return true
+}
+
+// machineReadable reports whether cmd was asked for machine-readable or
+// quiet output via a --json, --output, or --quiet flag.
+func machineReadable(cmd *cobra.Command) bool {
+	if cmd == nil {
+		return false
+	}
+	for _, name := range []string{"json", "output", "quiet"} {
+		f := cmd.Flags().Lookup(name)
+		if f == nil || !f.Changed {
+			continue
+		}
+		if v := f.Value.String(); v != "" && v != "false" {
+			return true
+		}
 	}
 	return false
 }
 
+// topLevelName returns the name of cmd's ancestor just below the root (or
+// cmd itself), so `wt completion zsh` counts as "completion".
+func topLevelName(cmd *cobra.Command) string {
+	for cmd != nil && cmd.HasParent() && cmd.Parent().HasParent() {
+		cmd = cmd.Parent()
+	}
+	if cmd == nil {
+		return ""
+	}
+	return cmd.Name()
+}
+
 // runStatus is called when `wt` is run with no subcommand.
 // Shows a quick status of the current worktree, or help if not in a repo.
 func runStatus(cmd *cobra.Command, args []string) error {
@@ -155,7 +280,7 @@ func runStatus(cmd *cobra.Command, args []string) error {
 
 	// Worktree name
 	short := ctx.shortName(cwd)
-	isMain := cwd == ctx.MainWorktree || isSubpath(cwd, ctx.MainWorktree)
+	isMain := ctx.inMainWorktree(cwd)
 
 	fmt.Printf("%s %s", ui.Yellow(ui.Current), short)
 	if branch != short {
@@ -193,8 +318,14 @@ func runStatus(cmd *cobra.Command, args []string) error {
 	}
 
 	// Unpushed commits
-	unpushed := git.UnpushedCountIn(cwd)
-	if unpushed > 0 {
+	unpushed, hasUpstream := git.UnpushedCountIn(cwd)
+	switch {
+	case !hasUpstream:
+		// Everything ahead of base is local-only
+		if err == nil && ab.Ahead > 0 {
+			fmt.Printf("  %s\n", ui.Cyan(fmt.Sprintf("%s%d commit(s) not pushed (no upstream)", ui.PushUp, ab.Ahead)))
+		}
+	case unpushed > 0:
 		fmt.Printf("  %s\n", ui.Cyan(fmt.Sprintf("%s%d unpushed commit(s)", ui.PushUp, unpushed)))
 	}
 
@@ -205,7 +336,7 @@ func runStatus(cmd *cobra.Command, args []string) error {
 			rs := pr.GetReviewSummary()
 			cs := pr.GetCISummary()
 
-			prStr := fmt.Sprintf("PR #%d", pr.Number)
+			prStr := "PR " + ui.Link(fmt.Sprintf("#%d", pr.Number), pr.URL)
 			var details []string
 			if rs.Approved > 0 {
 				details = append(details, fmt.Sprintf("%d approved", rs.Approved))
@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestMachineReadable(t *testing.T) {
+	tests := []struct {
+		args []string
+		want bool
+	}{
+		{nil, false},
+		{[]string{"--json"}, true},
+		{[]string{"--json=false"}, false},
+		{[]string{"--output", "json"}, true},
+		{[]string{"--quiet"}, true},
+		{[]string{"--wide"}, false},
+	}
+	for _, tt := range tests {
+		cmd := &cobra.Command{Use: "list"}
+		cmd.Flags().Bool("json", false, "")
+		cmd.Flags().String("output", "", "")
+		cmd.Flags().Bool("quiet", false, "")
+		cmd.Flags().Bool("wide", false, "")
+		if err := cmd.ParseFlags(tt.args); err != nil {
+			t.Fatal(err)
+		}
+		if got := machineReadable(cmd); got != tt.want {
+			t.Errorf("machineReadable(%v) = %v, want %v", tt.args, got, tt.want)
+		}
+	}
+	if machineReadable(nil) {
+		t.Error("machineReadable(nil) = true, want false")
+	}
+}
+
+func TestTopLevelName(t *testing.T) {
+	root := &cobra.Command{Use: "wt"}
+	completion := &cobra.Command{Use: "completion"}
+	zsh := &cobra.Command{Use: "zsh"}
+	root.AddCommand(completion)
+	completion.AddCommand(zsh)
+
+	for _, tt := range []struct {
+		cmd  *cobra.Command
+		want string
+	}{
+		{zsh, "completion"},
+		{completion, "completion"},
+		{root, "wt"},
+		{nil, ""},
+	} {
+		if got := topLevelName(tt.cmd); got != tt.want {
+			t.Errorf("topLevelName(%v) = %q, want %q", tt.cmd, got, tt.want)
+		}
+	}
+}
+
+func TestVersionShorthand(t *testing.T) {
+	// cobra only adds -v for --version when no other flag has claimed it.
+	rootCmd.InitDefaultVersionFlag()
+	f := rootCmd.Flags().ShorthandLookup("v")
+	if f == nil || f.Name != "version" {
+		t.Errorf("-v is %v, want the --version shorthand", f)
+	}
+}
@@ -2,12 +2,13 @@ package cmd
 
 import (
 	"fmt"
+	"os"
 
 	"github.com/spf13/cobra"
 )
 
 var shellCmd = &cobra.Command{
-	Use:   "init-shell <fish|bash|zsh>",
+	Use:   "init-shell <fish|bash|zsh|nu|powershell|cmd>",
 	Short: "Print shell integration wrapper",
 	Long: `Print a shell wrapper function that enables 'cd' integration.
 
@@ -15,12 +16,24 @@ Add to your shell config:
   Fish:  wt init-shell fish | source
   Bash:  eval "$(wt init-shell bash)"
   Zsh:   eval "$(wt init-shell zsh)"
+  Nu:    wt init-shell nu | save -f ~/.config/nushell/wt.nu
+         then add to config.nu: source ~/.config/nushell/wt.nu
+  PowerShell:
+         Invoke-Expression (& wt init-shell powershell | Out-String)
+  cmd:   wt init-shell cmd > "%USERPROFILE%\bin\wt.cmd"
+         (any directory on PATH ahead of wt.exe)
 
-The wrapper passes a temp file path via WT_CD_FILE. Commands that
-need to change directory write the target path to that file, and
-the wrapper reads it after the command exits.`,
+The wrapper passes a temp file path via WT_CD_FILE. Commands write
+line-based directives to that file, and the wrapper applies them
+after the command exits:
+
+  cd <path>        change directory
+  env KEY=VALUE    export an environment variable
+  echo <message>   print a message
+
+A line with no recognized prefix is treated as a path to cd into.`,
 	Args:      cobra.ExactArgs(1),
-	ValidArgs: []string{"fish", "bash", "zsh"},
+	ValidArgs: []string{"fish", "bash", "zsh", "nu", "powershell", "cmd"},
 	RunE:      runShell,
 }
 
@@ -29,17 +42,27 @@ func init() {
 }
 
 func runShell(cmd *cobra.Command, args []string) error {
+	var wrapper string
 	switch args[0] {
 	case "fish":
-		fmt.Print(fishWrapper)
+		wrapper = fishWrapper
 	case "bash":
-		fmt.Print(bashWrapper)
+		wrapper = bashWrapper
 	case "zsh":
-		fmt.Print(zshWrapper)
+		wrapper = zshWrapper
+	case "nu":
+		wrapper = nuWrapper
+	case "powershell":
+		wrapper = powershellWrapper
+	case "cmd":
+		wrapper = cmdWrapper
 	default:
-		return fmt.Errorf("unsupported shell: %s (use fish, bash, or zsh)", args[0])
+		return fmt.Errorf("unsupported shell: %s (use fish, bash, zsh, nu, powershell, or cmd)", args[0])
 	}
-	return nil
+	// WriteString, not fmt.Print: the wrappers contain literal % sequences
+	// (printf formats, %VAR%) that vet would flag as format verbs.
+	_, err := os.Stdout.WriteString(wrapper)
+	return err
 }
 
 const fishWrapper = `# wt shell integration (fish)
@@ -55,8 +78,19 @@ function wt --wraps wt --description "Git worktree manager"
     WT_CD_FILE=$cdfile command wt $argv
     set -l exit_code $status
     if test -s $cdfile
-        read -l target < $cdfile
-        and cd $target
+        while read -l line
+            switch $line
+                case 'cd *'
+                    cd (string sub -s 4 -- $line)
+                case 'env *'
+                    set -l kv (string split -m 1 = -- (string sub -s 5 -- $line))
+                    set -gx $kv[1] $kv[2]
+                case 'echo *'
+                    echo (string sub -s 6 -- $line)
+                case '?*'
+                    cd $line
+            end
+        end < $cdfile
     end
     rm -f $cdfile
     return $exit_code
@@ -81,7 +115,15 @@ wt() {
     WT_CD_FILE="$cdfile" command wt "$@"
     local exit_code=$?
     if [ -s "$cdfile" ]; then
-        cd "$(cat "$cdfile")" || true
+        local _wt_line
+        while IFS= read -r _wt_line || [ -n "$_wt_line" ]; do
+            case "$_wt_line" in
+                "cd "*) cd "${_wt_line#cd }" || true ;;
+                "env "*) export "${_wt_line#env }" ;;
+                "echo "*) printf '%s\n' "${_wt_line#echo }" ;;
+                ?*) cd "$_wt_line" || true ;;
+            esac
+        done < "$cdfile"
     fi
     rm -f "$cdfile"
     if [ -n "$_wt_prev_trap" ]; then
@@ -110,7 +152,15 @@ wt() {
     WT_CD_FILE="$cdfile" command wt "$@"
     local exit_code=$?
     if [ -s "$cdfile" ]; then
-        cd "$(cat "$cdfile")" || true
+        local _wt_line
+        while IFS= read -r _wt_line || [ -n "$_wt_line" ]; do
+            case "$_wt_line" in
+                "cd "*) cd "${_wt_line#cd }" || true ;;
+                "env "*) export "${_wt_line#env }" ;;
+                "echo "*) printf '%s\n' "${_wt_line#echo }" ;;
+                ?*) cd "$_wt_line" || true ;;
+            esac
+        done < "$cdfile"
     fi
     rm -f "$cdfile"
     if [ -n "$_wt_prev_trap" ]; then
@@ -123,3 +173,116 @@ wt() {
 
 # Generate completions with: eval "$(wt completion zsh)"
 `
+
+// nuWrapper can't be eval'd like the POSIX wrappers: nushell parses sourced
+// files at startup, so the user saves it to a file and sources that.
+const nuWrapper = `# wt shell integration (nushell)
+# Save and source from config.nu:
+#   wt init-shell nu | save -f ~/.config/nushell/wt.nu
+#   source ~/.config/nushell/wt.nu
+
+def --env --wrapped wt [...args] {
+    let cdfile = (mktemp -t wt-cd.XXXXXXXX)
+    # try: a non-zero exit from wt must not skip the cleanup below.
+    # $env.LAST_EXIT_CODE still reflects wt's exit status afterwards.
+    try {
+        with-env { WT_CD_FILE: $cdfile } { ^wt ...$args }
+    }
+    let directives = (open --raw $cdfile | lines | where $it != "")
+    rm -f $cdfile
+
+    # Applied by kind rather than in file order: env changes made inside a
+    # for loop don't escape it, so collect first and apply once.
+    for line in ($directives | where ($it | str starts-with "echo ")) {
+        print ($line | str substring 5..)
+    }
+    let vars = ($directives
+        | where ($it | str starts-with "env ")
+        | each {|line| $line | str substring 4.. | split row -n 2 "=" }
+        | reduce -f {} {|kv, acc| $acc | upsert $kv.0 ($kv | get -i 1 | default "") })
+    load-env $vars
+    let targets = ($directives | where {|line|
+        not (($line | str starts-with "env ") or ($line | str starts-with "echo "))
+    })
+    if ($targets | is-not-empty) {
+        let target = ($targets | last)
+        cd (if ($target | str starts-with "cd ") { $target | str substring 3.. } else { $target })
+    }
+}
+
+# Nushell completions are not generated by wt; use an external completer
+# (e.g. carapace) if you want tab completion.
+`
+
+const powershellWrapper = `# wt shell integration (PowerShell)
+# Add to your $PROFILE:
+#   Invoke-Expression (& wt init-shell powershell | Out-String)
+
+function wt {
+    $wtExe = Get-Command wt -CommandType Application -ErrorAction SilentlyContinue | Select-Object -First 1
+    if (-not $wtExe) {
+        Write-Error "wt: executable not found on PATH"
+        return
+    }
+    $cdfile = New-TemporaryFile
+    $prevCdFile = $env:WT_CD_FILE
+    $env:WT_CD_FILE = $cdfile.FullName
+    try {
+        & $wtExe @args
+        $exitCode = $LASTEXITCODE
+    } finally {
+        $env:WT_CD_FILE = $prevCdFile
+    }
+    $directives = Get-Content -LiteralPath $cdfile.FullName -ErrorAction SilentlyContinue
+    Remove-Item -LiteralPath $cdfile.FullName -Force -ErrorAction SilentlyContinue
+    foreach ($line in $directives) {
+        if ($line.StartsWith('cd ')) {
+            Set-Location -LiteralPath $line.Substring(3)
+        } elseif ($line.StartsWith('env ')) {
+            $kv = $line.Substring(4).Split('=', 2)
+            Set-Item -LiteralPath "Env:$($kv[0])" -Value $kv[1]
+        } elseif ($line.StartsWith('echo ')) {
+            Write-Host $line.Substring(5)
+        } elseif ($line) {
+            Set-Location -LiteralPath $line
+        }
+    }
+    $global:LASTEXITCODE = $exitCode
+}
+
+# Generate completions with: wt completion powershell | Out-String | Invoke-Expression
+`
+
+// cmdWrapper is a batch file rather than a function: cmd.exe has no functions,
+// and doskey macros can't run anything after the command exits. Installed as
+// wt.cmd ahead of wt.exe on PATH, it calls wt.exe explicitly to avoid recursing.
+const cmdWrapper = `@echo off
+rem wt shell integration (cmd)
+rem Save as wt.cmd in a directory that comes before wt.exe on PATH:
+rem   wt init-shell cmd > "%USERPROFILE%\bin\wt.cmd"
+
+rem No setlocal: cd and env directives must outlive this script. That also
+rem rules out delayed expansion, so each line is split with for variables,
+rem which cmd expands after parsing special characters and only once.
+set "WT_CD_FILE=%TEMP%\wt-cd-%RANDOM%%RANDOM%.txt"
+wt.exe %*
+set "WT_EXIT=%ERRORLEVEL%"
+if exist "%WT_CD_FILE%" (
+    for /f "usebackq delims=" %%l in ("%WT_CD_FILE%") do (
+        for /f "tokens=1,* delims= " %%a in ("%%l") do (
+            if "%%a"=="cd" (
+                cd /d "%%b"
+            ) else if "%%a"=="env" (
+                set "%%b"
+            ) else if "%%a"=="echo" (
+                echo(%%b
+            ) else (
+                cd /d "%%l"
+            )
+        )
+    )
+    del "%WT_CD_FILE%" >nul 2>&1
+)
+set "WT_CD_FILE="
+set "WT_EXIT=" & exit /b %WT_EXIT%
+`
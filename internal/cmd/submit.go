@@ -16,7 +16,11 @@ var submitCmd = &cobra.Command{
 	Long: `Rebase the current branch onto the base branch, then push to remote.
 
 Uses --force-with-lease for safety (fails if remote has unexpected commits).
-Cannot submit the base branch (use git push directly).`,
+Cannot submit the base branch (use git push directly).
+
+Stacked branches (built on another feature branch, see wt tree) are
+detected, and you're offered a rebase onto the parent branch instead of
+the base branch, which would pull the parent's commits into this one.`,
 	Example: `  wt submit               Rebase + push current branch
   wt submit --continue     Resume after resolving rebase conflicts
   wt submit --abort        Abort rebase and cancel push`,
@@ -49,10 +53,27 @@ func runSubmit(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("cannot submit the base branch (%s)\n   Switch to a feature worktree, or use git push directly", branch)
 	}
 
+	// A stacked branch rebased onto base would absorb its parent's commits
+	// and flatten the stack, so offer to rebase onto the parent instead.
+	var onto string
+	if !submitContinueFlag && !submitAbortFlag {
+		if parent := stackParent(ctx, branch); parent != "" {
+			ui.Warn("%s is stacked on %s", branch, parent)
+			if ui.Confirm(fmt.Sprintf("Rebase onto %s instead of %s?", parent, ctx.baseRef()), true) {
+				onto = parent
+				rememberStackParent(parent)
+			} else {
+				ui.Warn("Rebasing onto %s %s %s's commits will be included in this branch", ctx.baseRef(), ui.Dash, parent)
+			}
+			fmt.Println()
+		}
+	}
+
 	// Delegate to rebase first
 	if err := runRebaseWith(rebaseOpts{
 		continueRebase: submitContinueFlag,
 		abort:          submitAbortFlag,
+		onto:           onto,
 	}); err != nil {
 		return err
 	}
@@ -80,7 +101,7 @@ func runSubmit(cmd *cobra.Command, args []string) error {
 			fmt.Println()
 			ui.Warn("Push rejected %s remote tracking info is stale", ui.Dash)
 			fmt.Println("  Fetching latest remote state...")
-			if fetchErr := git.Fetch(ctx.Config.Remote, branch); fetchErr != nil {
+			if fetchErr := git.FetchBranch(ctx.Config.Remote, branch); fetchErr != nil {
 				return fmt.Errorf("failed to fetch: %w", fetchErr)
 			}
 			if !ui.Confirm("Retry push?", true) {
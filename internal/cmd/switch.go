@@ -12,6 +12,8 @@ import (
 	"github.com/spf13/cobra"
 )
 
+// prevWorktreeStateFile holds the worktree wt switch - returns to. It's
+// shared across worktrees, so - works from wherever you switched to.
 const prevWorktreeStateFile = "wt-prev-worktree"
 
 var switchCmd = &cobra.Command{
@@ -23,24 +25,41 @@ var switchCmd = &cobra.Command{
 
 Without arguments, opens an interactive picker (requires fzf).
 With a name, resolves the worktree using fuzzy matching.
-Use "-" to switch back to the previous worktree.
+Use "-" to switch back to the previous worktree; it works from any
+worktree of the repo, not just the one you switched into.
+
+With --create, a name that doesn't match a worktree exactly (fuzzy
+matches don't count) is created from the base branch, like wt new, and
+switched to.
 
 Resolution order:
   1. Exact match: wt-<repo>-<name>
-  2. Main repo: 'main', base branch name, or repo name
-  3. Branch name: exact match against the git branch
+  2. Branch name: exact match against the git branch
+  3. Main repo: 'main', base branch name, or repo name
   4. Suffix match: any worktree ending with -<name>
-  5. Fuzzy match: worktree or branch containing the search term`,
+  5. Fuzzy match: worktree or branch containing the search term, ranked
+     exact > prefix > substring, shortest name first; only a tie is
+     reported as ambiguous`,
 	Example: `  wt switch               Interactive picker (requires fzf)
   wt switch sidebar        Switch to "sidebar" worktree (fuzzy match)
   wt switch -              Switch back to previous worktree
-  wt switch main           Switch to main repository`,
+  wt switch main           Switch to main repository
+  wt switch -c sidebar     Switch to "sidebar", creating it if missing
+  cd "$(wt switch --print api)"   Print the path only (for scripts)`,
 	Args:              cobra.MaximumNArgs(1),
 	ValidArgsFunction: completeWorktreeNames,
 	RunE:              runSwitch,
 }
 
+var (
+	switchPrintFlag  bool
+	switchCreateFlag bool
+)
+
 func init() {
+	switchCmd.Flags().BoolVarP(&switchPrintFlag, "print", "p", false, "print the resolved path instead of switching")
+	switchCmd.Flags().BoolVarP(&switchCreateFlag, "create", "c", false, "create the worktree from the base branch if it doesn't exist")
+	switchCmd.MarkFlagsMutuallyExclusive("print", "create")
 	rootCmd.AddCommand(switchCmd)
 }
 
@@ -54,12 +73,21 @@ func runSwitch(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	worktrees = withoutBare(worktrees)
 
 	cwd, err := os.Getwd()
 	if err != nil {
 		return fmt.Errorf("cannot determine current directory: %w", err)
 	}
 
+	if switchPrintFlag {
+		return switchPrint(ctx, worktrees, args)
+	}
+
+	if switchCreateFlag && (len(args) == 0 || args[0] == "-") {
+		return fmt.Errorf("--create requires a worktree name")
+	}
+
 	// Handle "wt switch -" — toggle to previous worktree
 	if len(args) == 1 && args[0] == "-" {
 		return switchPrevious(cwd)
@@ -74,7 +102,7 @@ func runSwitch(cmd *cobra.Command, args []string) error {
 }
 
 func switchPrevious(cwd string) error {
-	prev, err := git.ReadStateFile(prevWorktreeStateFile)
+	prev, err := git.ReadSharedStateFile(prevWorktreeStateFile)
 	if err != nil {
 		return fmt.Errorf("no previous worktree to switch to\n   Use wt switch <name> to specify one")
 	}
@@ -89,8 +117,41 @@ func switchPrevious(cwd string) error {
 	return nil
 }
 
+// switchPrint writes only the resolved absolute path to stdout — no cd
+// directive, summary, or previous-worktree bookkeeping — so it can be used
+// as `cd "$(wt switch --print name)"` without the shell wrapper.
+func switchPrint(ctx *cmdContext, worktrees []git.Worktree, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("--print requires a worktree name")
+	}
+
+	var target string
+	if args[0] == "-" {
+		prev, err := git.ReadSharedStateFile(prevWorktreeStateFile)
+		if err != nil {
+			return fmt.Errorf("no previous worktree")
+		}
+		target = strings.TrimSpace(prev)
+		if !isDir(target) {
+			return fmt.Errorf("previous worktree no longer exists: %s", target)
+		}
+	} else {
+		var err error
+		target, _, err = resolveWorktree(ctx, worktrees, args[0])
+		if err != nil {
+			return err
+		}
+		if target == "" {
+			return fmt.Errorf("worktree not found: %s", args[0])
+		}
+	}
+
+	fmt.Println(target)
+	return nil
+}
+
 func savePreviousWorktree(cwd string) {
-	_ = git.SaveStateFile(prevWorktreeStateFile, cwd)
+	_ = git.SaveSharedStateFile(prevWorktreeStateFile, cwd)
 }
 
 func switchInteractive(ctx *cmdContext, worktrees []git.Worktree, cwd string) error {
@@ -105,7 +166,12 @@ func switchInteractive(ctx *cmdContext, worktrees []git.Worktree, cwd string) er
 	var lines []string
 	for _, wt := range worktrees {
 		short := ctx.shortName(wt.Path)
-		branch, _ := git.CurrentBranchIn(wt.Path)
+		branch := wt.Branch
+		if wt.Detached {
+			branch = "(" + shortSHA(wt.Head) + ")"
+		} else if branch == "" {
+			branch, _ = git.CurrentBranchIn(wt.Path)
+		}
 		marker := "  "
 		if wt.Path == cwd || isSubpath(cwd, wt.Path) {
 			marker = ui.Current + " "
@@ -119,7 +185,8 @@ func switchInteractive(ctx *cmdContext, worktrees []git.Worktree, cwd string) er
 		"--header=↑↓ navigate  enter select  esc cancel",
 		"--delimiter=|",
 		"--with-nth=1,2",
-		"--preview-window=hidden")
+		"--preview="+previewCommand(),
+		"--preview-window=right,50%,wrap")
 	fzfCmd.Stdin = strings.NewReader(input)
 	fzfCmd.Stderr = os.Stderr
 
@@ -149,12 +216,25 @@ func switchInteractive(ctx *cmdContext, worktrees []git.Worktree, cwd string) er
 }
 
 func switchByName(ctx *cmdContext, worktrees []git.Worktree, cwd, name string) error {
-	target, _, err := resolveWorktree(ctx, worktrees, name)
-	if err != nil {
-		return err
+	var target string
+	if switchCreateFlag {
+		// Only an exact match counts: "wt switch -c api" must not land in api-v2.
+		target = resolveWorktreeExact(ctx, worktrees, name)
+	} else {
+		var err error
+		if target, _, err = resolveWorktree(ctx, worktrees, name); err != nil {
+			return err
+		}
 	}
 	if target == "" {
-		return fmt.Errorf("worktree not found: %s\n   Run wt list to see available worktrees", name)
+		if !switchCreateFlag {
+			return fmt.Errorf("worktree not found: %s\n   Use wt switch --create %s to create it, or wt list to see available worktrees", name, name)
+		}
+		created, err := createWorktreeFromBase(ctx, name)
+		if err != nil {
+			return err
+		}
+		target = created
 	}
 
 	savePreviousWorktree(cwd)
@@ -170,59 +250,130 @@ func switchByName(ctx *cmdContext, worktrees []git.Worktree, cwd, name string) e
 // or ("", false, error) when the match is ambiguous (error message already
 // printed; returns errSilent).
 func resolveWorktree(ctx *cmdContext, worktrees []git.Worktree, name string) (string, bool, error) {
+	if path := resolveWorktreeExact(ctx, worktrees, name); path != "" {
+		tracer.Printf("resolve %q: exact match %s", name, path)
+		return path, false, nil
+	}
+
+	// 5. Fuzzy match (contains) — checks both short name and branch, ranked
+	// so the best candidate wins instead of bailing on every multi-hit.
+	var fuzzyMatches []git.Worktree
+	var best fuzzyScore
+	for _, wt := range worktrees {
+		score, ok := scoreFuzzy(name, ctx.shortName(wt.Path), wt.Branch)
+		if !ok {
+			continue
+		}
+		switch {
+		case len(fuzzyMatches) == 0 || score.betterThan(best):
+			best = score
+			fuzzyMatches = []git.Worktree{wt}
+		case !best.betterThan(score):
+			fuzzyMatches = append(fuzzyMatches, wt)
+		}
+	}
+
+	tracer.Printf("resolve %q: %d fuzzy match(es)", name, len(fuzzyMatches))
+	if len(fuzzyMatches) == 1 {
+		short := ctx.shortName(fuzzyMatches[0].Path)
+		// stderr: keeps stdout clean for `wt switch --print`.
+		fmt.Fprintln(os.Stderr, ui.Dim(fmt.Sprintf("Fuzzy matched: %s → %s", name, short)))
+		return fuzzyMatches[0].Path, true, nil
+	}
+	if len(fuzzyMatches) > 1 {
+		fmt.Fprintf(os.Stderr, "Multiple matches for '%s':\n\n", name)
+		for _, m := range fuzzyMatches {
+			fmt.Fprintf(os.Stderr, "  %s\n", ctx.shortName(m.Path))
+		}
+		fmt.Fprintln(os.Stderr)
+		fmt.Fprintln(os.Stderr, "Be more specific, or use 'wt switch' for interactive picker")
+		return "", false, errSilent
+	}
+
+	return "", false, nil
+}
+
+// resolveWorktreeExact runs the non-fuzzy steps (1–4) of the resolution
+// chain, returning "" when none match.
+func resolveWorktreeExact(ctx *cmdContext, worktrees []git.Worktree, name string) string {
 	// 1. Exact match with configured prefix
 	exact := ctx.worktreePath(name)
 	if isDir(exact) {
-		return exact, false, nil
+		return exact
 	}
 
-	// 2. Main repo match (base branch name, "main", "master", or repo name)
-	if name == ctx.RepoName || ctx.isBaseBranch(name) {
-		return ctx.MainWorktree, false, nil
-	}
-
-	// 3. Branch name match (exact match against git branch)
+	// 2. Branch name match (exact match against git branch). Checked before
+	// the base-branch names so "wt switch staging" finds the worktree that
+	// has staging checked out even when the main worktree is elsewhere.
 	for _, wt := range worktrees {
 		if wt.Branch == name {
-			return wt.Path, false, nil
+			return wt.Path
 		}
 	}
 
+	// 3. Main repo match (base branch name, "main", "master", or repo name).
+	// In a bare clone that's the base branch's worktree, if there is one.
+	if (name == ctx.RepoName || ctx.isBaseBranch(name)) && ctx.HomeWorktree != "" {
+		return ctx.HomeWorktree
+	}
+
 	// 4. Suffix match
 	for _, wt := range worktrees {
 		base := filepath.Base(wt.Path)
 		if strings.HasSuffix(base, "-"+name) || strings.HasSuffix(base, "/"+name) {
-			return wt.Path, false, nil
+			return wt.Path
 		}
 	}
 
-	// 5. Fuzzy match (contains) — checks both short name and branch
-	var fuzzyMatches []git.Worktree
-	nameLower := strings.ToLower(name)
-	for _, wt := range worktrees {
-		short := ctx.shortName(wt.Path)
-		if strings.Contains(strings.ToLower(short), nameLower) ||
-			strings.Contains(strings.ToLower(wt.Branch), nameLower) {
-			fuzzyMatches = append(fuzzyMatches, wt)
-		}
-	}
+	return ""
+}
 
-	if len(fuzzyMatches) == 1 {
-		short := ctx.shortName(fuzzyMatches[0].Path)
-		ui.DimF("Fuzzy matched: %s → %s\n", name, short)
-		return fuzzyMatches[0].Path, true, nil
+// Fuzzy match tiers, best first.
+const (
+	fuzzyContains = iota + 1
+	fuzzyPrefix
+	fuzzyExact
+)
+
+// fuzzyScore ranks a fuzzy candidate: higher tier wins, then shorter length
+// (the candidate with the least unmatched text is the likelier target).
+type fuzzyScore struct {
+	tier   int
+	length int
+}
+
+func (a fuzzyScore) betterThan(b fuzzyScore) bool {
+	if a.tier != b.tier {
+		return a.tier > b.tier
 	}
-	if len(fuzzyMatches) > 1 {
-		fmt.Printf("Multiple matches for '%s':\n\n", name)
-		for _, m := range fuzzyMatches {
-			fmt.Printf("  %s\n", ctx.shortName(m.Path))
+	return a.length < b.length
+}
+
+// scoreFuzzy scores query against each of a worktree's names (short name,
+// branch) case-insensitively and returns the best. ok is false when none of
+// them contain the query.
+func scoreFuzzy(query string, names ...string) (score fuzzyScore, ok bool) {
+	q := strings.ToLower(query)
+	for _, n := range names {
+		n = strings.ToLower(n)
+		var s fuzzyScore
+		switch {
+		case n == "":
+			continue
+		case n == q:
+			s = fuzzyScore{fuzzyExact, len(n)}
+		case strings.HasPrefix(n, q):
+			s = fuzzyScore{fuzzyPrefix, len(n)}
+		case strings.Contains(n, q):
+			s = fuzzyScore{fuzzyContains, len(n)}
+		default:
+			continue
+		}
+		if !ok || s.betterThan(score) {
+			score, ok = s, true
 		}
-		fmt.Println()
-		fmt.Println("Be more specific, or use 'wt switch' for interactive picker")
-		return "", false, errSilent
 	}
-
-	return "", false, nil
+	return score, ok
 }
 
 func showSwitchSummary(path string, ctx *cmdContext) {
@@ -0,0 +1,193 @@
+package cmd
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mvwi/wt/internal/config"
+	"github.com/mvwi/wt/internal/git"
+)
+
+func TestScoreFuzzy(t *testing.T) {
+	t.Run("no match", func(t *testing.T) {
+		if _, ok := scoreFuzzy("xyz", "api", "user/api"); ok {
+			t.Error("ok = true, want false")
+		}
+	})
+
+	t.Run("exact beats prefix", func(t *testing.T) {
+		exact, _ := scoreFuzzy("api", "api", "user/api")
+		prefix, _ := scoreFuzzy("api", "api-v2", "user/api-v2")
+		if !exact.betterThan(prefix) {
+			t.Errorf("exact %+v should beat prefix %+v", exact, prefix)
+		}
+	})
+
+	t.Run("prefix beats shorter substring", func(t *testing.T) {
+		prefix, _ := scoreFuzzy("side", "sidebar-card", "")
+		contains, _ := scoreFuzzy("side", "a-side", "")
+		if !prefix.betterThan(contains) {
+			t.Errorf("prefix %+v should beat contains %+v", prefix, contains)
+		}
+	})
+
+	t.Run("shorter wins within a tier", func(t *testing.T) {
+		short, _ := scoreFuzzy("a", "api", "")
+		long, _ := scoreFuzzy("a", "api-v2", "")
+		if !short.betterThan(long) {
+			t.Errorf("short %+v should beat long %+v", short, long)
+		}
+	})
+
+	t.Run("case-insensitive", func(t *testing.T) {
+		s, ok := scoreFuzzy("API", "api", "")
+		if !ok || s.tier != fuzzyExact {
+			t.Errorf("got %+v ok=%v, want exact match", s, ok)
+		}
+	})
+
+	t.Run("best of short name and branch", func(t *testing.T) {
+		s, _ := scoreFuzzy("user/fix", "fix", "user/fix")
+		if s.tier != fuzzyExact {
+			t.Errorf("tier = %d, want exact via branch", s.tier)
+		}
+	})
+
+	t.Run("equal scores tie", func(t *testing.T) {
+		a, _ := scoreFuzzy("ap", "apx", "")
+		b, _ := scoreFuzzy("ap", "apy", "")
+		if a.betterThan(b) || b.betterThan(a) {
+			t.Errorf("%+v and %+v should tie", a, b)
+		}
+	})
+}
+
+func TestResolveWorktreeBaseBranchOffMain(t *testing.T) {
+	// The main worktree tracks develop; staging (the base) is checked out
+	// in a linked worktree.
+	ctx := &cmdContext{
+		Config:       &config.Config{BaseBranch: "staging", Remote: "origin"},
+		RepoName:     "repo",
+		MainWorktree: "/src/repo",
+		HomeWorktree: "/src/repo",
+		ParentDir:    t.TempDir(),
+	}
+	worktrees := []git.Worktree{
+		{Path: "/src/repo", Branch: "develop"},
+		{Path: "/src/wt-repo/staging", Branch: "staging"},
+	}
+
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"staging", "/src/wt-repo/staging"},
+		{"develop", "/src/repo"},
+		{"main", "/src/repo"},
+		{"repo", "/src/repo"},
+	}
+	for _, tt := range tests {
+		got, _, err := resolveWorktree(ctx, worktrees, tt.name)
+		if err != nil || got != tt.want {
+			t.Errorf("resolveWorktree(%q) = %q, %v; want %q", tt.name, got, err, tt.want)
+		}
+	}
+}
+
+func TestResolveWorktreeBare(t *testing.T) {
+	// A bare clone's main worktree is the bare repo: the repo name and base
+	// branch lead to the base branch's worktree instead, or nowhere.
+	ctx := &cmdContext{
+		Config:       &config.Config{BaseBranch: "main", Remote: "origin"},
+		RepoName:     "repo",
+		MainWorktree: "/src/repo/.bare",
+		HomeWorktree: "/src/repo/trunk",
+		ParentDir:    t.TempDir(),
+		Bare:         true,
+	}
+	worktrees := []git.Worktree{
+		{Path: "/src/repo/.bare", Bare: true},
+		{Path: "/src/repo/trunk", Branch: "main"},
+		{Path: "/src/repo/feat", Branch: "mvwi/feat"},
+	}
+	for _, name := range []string{"repo", "master"} {
+		if got, _, _ := resolveWorktree(ctx, worktrees, name); got != "/src/repo/trunk" {
+			t.Errorf("resolveWorktree(%q) = %q, want /src/repo/trunk", name, got)
+		}
+	}
+
+	ctx.HomeWorktree = ""
+	if got := resolveWorktreeExact(ctx, worktrees[2:], "repo"); got != "" {
+		t.Errorf("resolveWorktreeExact(repo) = %q with no base worktree, want none", got)
+	}
+}
+
+func TestSwitchPreviousAcrossWorktrees(t *testing.T) {
+	root, err := filepath.EvalSymlinks(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	a, b := filepath.Join(root, "repo"), filepath.Join(root, "wt-repo", "b")
+	for _, args := range [][]string{
+		{"init", "-q", "-b", "main", a},
+		{"-C", a, "-c", "user.name=t", "-c", "user.email=t@example.com", "commit", "-q", "--allow-empty", "-m", "init"},
+		{"-C", a, "worktree", "add", "-q", "-b", "b", b},
+	} {
+		if out, err := exec.Command("git", args...).CombinedOutput(); err != nil {
+			t.Skipf("git %v failed: %v\n%s", args, err, out)
+		}
+	}
+	t.Setenv("HOME", t.TempDir())
+	cdFile := filepath.Join(t.TempDir(), "cd")
+	t.Setenv("WT_CD_FILE", cdFile)
+
+	// lastCd returns the target of the last cd directive and resets the file.
+	lastCd := func(t *testing.T) string {
+		t.Helper()
+		data, err := os.ReadFile(cdFile)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = os.Remove(cdFile)
+		lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+		return strings.TrimPrefix(lines[len(lines)-1], "cd ")
+	}
+
+	// A → B
+	t.Chdir(a)
+	ctx, err := newContext()
+	if err != nil {
+		t.Fatal(err)
+	}
+	worktrees, err := git.ListWorktrees()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := switchByName(ctx, worktrees, a, "b"); err != nil {
+		t.Fatal(err)
+	}
+	if got := lastCd(t); got != b {
+		t.Fatalf("wt switch b: cd %q, want %q", got, b)
+	}
+
+	// wt switch - from B, whose own git dir never saw the switch
+	t.Chdir(b)
+	if err := switchPrevious(b); err != nil {
+		t.Fatal(err)
+	}
+	if got := lastCd(t); got != a {
+		t.Errorf("wt switch - from B: cd %q, want %q", got, a)
+	}
+
+	// and back again from A
+	t.Chdir(a)
+	if err := switchPrevious(a); err != nil {
+		t.Fatal(err)
+	}
+	if got := lastCd(t); got != b {
+		t.Errorf("wt switch - from A: cd %q, want %q", got, b)
+	}
+}
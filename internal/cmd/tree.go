@@ -0,0 +1,273 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+
+	"github.com/mvwi/wt/internal/git"
+	"github.com/mvwi/wt/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var treeCmd = &cobra.Command{
+	Use:     "tree",
+	GroupID: groupWorkflow,
+	Short:   "Show worktrees as a tree of stacked branches",
+	Long: `Show worktrees as a tree: the base worktree at the root, feature
+worktrees under it, and stacked branches nested under the branch they
+were built on.
+
+A branch is stacked on another feature branch when it contains all of
+that branch's commits (merge-base is the parent's tip). Ahead/behind
+counts are relative to the parent, so a stacked branch shows only its
+own commits.`,
+	Example: `  wt tree`,
+	Args:    cobra.NoArgs,
+	RunE:    runTree,
+}
+
+func init() {
+	rootCmd.AddCommand(treeCmd)
+}
+
+func runTree(cmd *cobra.Command, args []string) error {
+	ctx, err := newContext()
+	if err != nil {
+		return err
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("cannot determine current directory: %w", err)
+	}
+
+	worktrees, err := git.ListWorktrees()
+	if err != nil {
+		return err
+	}
+	if len(worktrees) == 0 {
+		fmt.Println("No worktrees found")
+		return nil
+	}
+
+	infos, featureBranches := collectWorktreeInfos(ctx, cwd, worktrees, false)
+
+	ahead := make(map[string]int)
+	paths := make(map[string]string)
+	for _, info := range infos {
+		ahead[info.Branch] = info.Ahead
+		paths[info.Branch] = info.Path
+	}
+	parents := stackParents(ctx, featureBranches, paths, ahead)
+
+	// Stacked branches show ahead/behind against their parent, not base
+	for i, info := range infos {
+		if p := parents[info.Branch]; p != "" {
+			if ab, err := git.GetAheadBehindIn(info.Path, p); err == nil {
+				infos[i].Ahead, infos[i].Behind = ab.Ahead, ab.Behind
+			}
+		}
+	}
+
+	// Build child lists; the base worktree (or the first one, if base isn't
+	// checked out anywhere) is the root.
+	root := -1
+	for i, info := range infos {
+		if ctx.isBaseBranch(info.Branch) {
+			root = i
+			break
+		}
+	}
+	byBranch := make(map[string]int)
+	for i, info := range infos {
+		byBranch[info.Branch] = i
+	}
+	children := make(map[int][]int)
+	var topLevel []int
+	for i, info := range infos {
+		if i == root {
+			continue
+		}
+		if p, ok := byBranch[parents[info.Branch]]; ok && parents[info.Branch] != "" {
+			children[p] = append(children[p], i)
+		} else {
+			topLevel = append(topLevel, i)
+		}
+	}
+	byName := func(ids []int) {
+		sort.Slice(ids, func(a, b int) bool { return infos[ids[a]].ShortName < infos[ids[b]].ShortName })
+	}
+	byName(topLevel)
+	for _, ids := range children {
+		byName(ids)
+	}
+
+	fmt.Println()
+	if root >= 0 {
+		printTreeNode(infos[root], "", "")
+	} else {
+		fmt.Printf("  %s\n", ui.Dim(ctx.Config.BaseBranch))
+	}
+	var walk func(ids []int, indent string)
+	walk = func(ids []int, indent string) {
+		for n, id := range ids {
+			branch, next := "├── ", "│   "
+			if n == len(ids)-1 {
+				branch, next = "└── ", "    "
+			}
+			printTreeNode(infos[id], indent, branch)
+			walk(children[id], indent+next)
+		}
+	}
+	walk(topLevel, "")
+	fmt.Println()
+	return nil
+}
+
+// printTreeNode prints one worktree line: current marker, tree connector,
+// name, branch (when it differs), sync arrows, and dirty count.
+func printTreeNode(info worktreeInfo, indent, connector string) {
+	if info.IsCurrent {
+		fmt.Printf("%s ", ui.Yellow(ui.Current))
+	} else {
+		fmt.Print("  ")
+	}
+	fmt.Print(ui.Dim(indent+connector), ui.Bold(info.ShortName))
+	if info.Branch != info.ShortName {
+		fmt.Print("  ", ui.Dim(info.Branch))
+	}
+	if info.Ahead > 0 || info.Behind > 0 {
+		fmt.Print("  ", ui.Cyan(buildSyncStr(info.Behind, info.Ahead)))
+	}
+	if info.DirtyCount > 0 {
+		fmt.Print("  ", ui.Yellow(fmt.Sprintf("%d dirty", info.DirtyCount)))
+	}
+	fmt.Println()
+}
+
+// inferParents finds the stack parent of each feature branch: the nearest
+// other feature branch it was built on. contains(p, c) reports whether c
+// holds all of p's commits plus at least one of its own. Only branches with
+// commits beyond base (ahead > 0) can be parents; among several candidates
+// the one furthest ahead of base is nearest. Branches without a feature
+// parent are absent from the result (their parent is base).
+func inferParents(branches []string, ahead map[string]int, contains func(p, c string) bool) map[string]string {
+	parents := make(map[string]string)
+	for _, child := range branches {
+		if ahead[child] == 0 {
+			continue
+		}
+		best := ""
+		for _, p := range branches {
+			if p == child || ahead[p] == 0 || ahead[p] >= ahead[child] {
+				continue
+			}
+			if (best == "" || ahead[p] > ahead[best]) && contains(p, child) {
+				best = p
+			}
+		}
+		if best != "" {
+			parents[child] = best
+		}
+	}
+	return parents
+}
+
+// stackContains reports whether child holds all of parent's commits plus at
+// least one of its own, i.e. child was built on top of parent.
+func stackContains(parent, child string) bool {
+	if merged, err := git.IsMergedInto(parent, child); err != nil || !merged {
+		return false
+	}
+	n, err := git.CountCommits(parent, child)
+	return err == nil && n > 0
+}
+
+// stackParent returns the feature branch that branch is stacked on, or ""
+// when it sits directly on base. Only branches checked out in a worktree
+// are considered.
+func stackParent(ctx *cmdContext, branch string) string {
+	worktrees, err := git.ListWorktrees()
+	if err != nil {
+		return ""
+	}
+	ahead := make(map[string]int)
+	paths := make(map[string]string)
+	var branches []string
+	for _, wt := range worktrees {
+		if wt.Branch == "" || ctx.isBaseBranch(wt.Branch) {
+			continue
+		}
+		n, err := git.CountCommits(ctx.baseRef(), wt.Branch)
+		if err != nil {
+			continue
+		}
+		ahead[wt.Branch] = n
+		paths[wt.Branch] = wt.Path
+		branches = append(branches, wt.Branch)
+	}
+	return stackParents(ctx, branches, paths, ahead)[branch]
+}
+
+// stackParents resolves the stack parent of each branch among branches
+// (those checked out in worktrees; paths maps each to its worktree). A
+// parent recorded in the worktree's metadata (by wt new --base, or by
+// rememberStackParent) wins while the two branches still share commits
+// beyond base — that survives the parent gaining new commits, which plain
+// inference can't see. Otherwise the parent is inferred. Read-only: callers
+// that act on the stack record what they used with rememberStackParent.
+func stackParents(ctx *cmdContext, branches []string, paths map[string]string, ahead map[string]int) map[string]string {
+	parents := inferParents(branches, ahead, stackContains)
+	for _, b := range branches {
+		meta, err := git.LoadWorktreeMeta(paths[b])
+		if err != nil {
+			continue
+		}
+		if stored := meta.Parent; stored != "" && stored != parents[b] && slices.Contains(branches, stored) &&
+			sharesWorkBeyondBase(ctx, stored, b) && !stackCycle(parents, b, stored) {
+			parents[b] = stored
+		}
+	}
+	return parents
+}
+
+// rememberStackParent records parent in the current worktree's metadata so
+// later lookups keep the stack after parent gains commits.
+func rememberStackParent(parent string) {
+	top, err := git.TopLevel()
+	if err != nil {
+		return
+	}
+	meta, err := git.LoadWorktreeMeta(top)
+	if err != nil || meta.Parent == parent {
+		return
+	}
+	meta.Parent = parent
+	_ = git.SaveWorktreeMeta(top, meta)
+}
+
+// sharesWorkBeyondBase reports whether parent and child fork from a commit
+// that isn't on base yet, i.e. child still carries some of parent's work.
+func sharesWorkBeyondBase(ctx *cmdContext, parent, child string) bool {
+	if !git.BranchExists(parent) {
+		return false
+	}
+	mb, err := git.MergeBase(parent, child)
+	if err != nil {
+		return false
+	}
+	n, err := git.CountCommits(ctx.baseRef(), mb)
+	return err == nil && n > 0
+}
+
+// stackCycle reports whether making parent the parent of child would loop.
+func stackCycle(parents map[string]string, child, parent string) bool {
+	for p := parent; p != ""; p = parents[p] {
+		if p == child {
+			return true
+		}
+	}
+	return false
+}
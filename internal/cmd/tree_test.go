@@ -0,0 +1,41 @@
+package cmd
+
+import "testing"
+
+func TestInferParents(t *testing.T) {
+	// main ← a ← b ← c, and d off main
+	commits := map[string][]string{
+		"a": {"a1"},
+		"b": {"a1", "b1"},
+		"c": {"a1", "b1", "c1", "c2"},
+		"d": {"d1"},
+		"e": {}, // fresh branch on base
+	}
+	contains := func(p, c string) bool {
+		have := make(map[string]bool)
+		for _, sha := range commits[c] {
+			have[sha] = true
+		}
+		for _, sha := range commits[p] {
+			if !have[sha] {
+				return false
+			}
+		}
+		return len(commits[c]) > len(commits[p])
+	}
+	ahead := make(map[string]int)
+	for b, cs := range commits {
+		ahead[b] = len(cs)
+	}
+
+	got := inferParents([]string{"a", "b", "c", "d", "e"}, ahead, contains)
+	want := map[string]string{"b": "a", "c": "b"}
+	if len(got) != len(want) {
+		t.Fatalf("inferParents() = %v, want %v", got, want)
+	}
+	for child, parent := range want {
+		if got[child] != parent {
+			t.Errorf("parent of %s = %q, want %q", child, got[child], parent)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+
+	"github.com/mvwi/wt/internal/ui"
+	"github.com/mvwi/wt/internal/update"
+	"github.com/spf13/cobra"
+)
+
+var upgradeCmd = &cobra.Command{
+	Use:     "upgrade",
+	GroupID: groupManage,
+	Short:   "Upgrade wt to the latest release",
+	Long: `Upgrade wt to the latest release.
+
+Homebrew installs are upgraded with 'brew upgrade wt'. Otherwise the
+release archive for this OS/arch is downloaded from GitHub, verified
+against the release's checksums.txt, and swapped in place of the running
+binary.
+
+Development builds (version "dev") can't be upgraded this way.`,
+	Args: cobra.NoArgs,
+	RunE: runUpgrade,
+}
+
+func init() {
+	rootCmd.AddCommand(upgradeCmd)
+}
+
+func runUpgrade(cmd *cobra.Command, args []string) error {
+	if Version == "dev" {
+		return fmt.Errorf("this is a development build — rebuild from source, or install a release:\n   go install github.com/mvwi/wt@latest")
+	}
+
+	if update.IsHomebrewInstall() {
+		fmt.Println("Installed via Homebrew — running brew upgrade wt")
+		brew := exec.Command("brew", "upgrade", "wt")
+		brew.Stdin = os.Stdin
+		brew.Stdout = os.Stdout
+		brew.Stderr = os.Stderr
+		if err := brew.Run(); err != nil {
+			return fmt.Errorf("brew upgrade failed: %w", err)
+		}
+		return nil
+	}
+
+	spin := ui.NewSpinner("Checking for updates")
+	rel, err := update.FetchLatestRelease()
+	spin.Stop()
+	if err != nil {
+		return fmt.Errorf("could not check for updates: %w", err)
+	}
+
+	if !rel.NewerThan(Version) {
+		ui.Success("Already up to date (%s)", Version)
+		return nil
+	}
+
+	spin = ui.NewSpinner(fmt.Sprintf("Downloading %s", rel.TagName))
+	err = rel.Install()
+	spin.Stop()
+	if err != nil {
+		return fmt.Errorf("upgrade failed: %w", err)
+	}
+
+	ui.Success("Upgraded wt %s → %s", Version, rel.TagName)
+	return nil
+}
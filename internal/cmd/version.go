@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/mvwi/wt/internal/ui"
+	"github.com/mvwi/wt/internal/update"
+	"github.com/spf13/cobra"
+)
+
+var versionCmd = &cobra.Command{
+	Use:     "version",
+	GroupID: groupManage,
+	Short:   "Print the wt version (--check to look for updates now)",
+	Long: `Print the wt version, like wt --version.
+
+With --check, also ask GitHub for the latest release right away and print
+it next to the current version. The background update check only runs
+once a day (and never in CI), so this is how to see a just-published
+release; it refreshes the cached result too. It works even when update
+checks are turned off (check_updates = false or WT_NO_UPDATE_CHECK=1),
+since you asked.`,
+	Example: `  wt version
+  wt version --check`,
+	Args: cobra.NoArgs,
+	RunE: runVersion,
+}
+
+var versionCheck bool
+
+func init() {
+	versionCmd.Flags().BoolVar(&versionCheck, "check", false, "check GitHub for the latest release now, bypassing the daily cache")
+	rootCmd.AddCommand(versionCmd)
+}
+
+func runVersion(cmd *cobra.Command, args []string) error {
+	fmt.Printf("wt version %s\n", Version)
+	if !versionCheck {
+		return nil
+	}
+
+	spin := ui.NewSpinner("Checking for updates")
+	rel, err := update.FetchLatestRelease()
+	spin.Stop()
+	if err != nil {
+		return fmt.Errorf("could not check for updates: %w", err)
+	}
+
+	switch {
+	case Version == "dev":
+		fmt.Printf("Latest release: %s %s\n", rel.TagName, ui.Dim("(this is a development build)"))
+	case rel.NewerThan(Version):
+		fmt.Printf("%s %s → %s\n", ui.Cyan(ui.PushUp+" Update available:"), ui.Dim(Version), ui.Cyan(rel.TagName))
+		fmt.Printf("  %s\n", ui.Dim(update.UpgradeHint()))
+	default:
+		ui.Success("Up to date (latest release is %s)", rel.TagName)
+	}
+	return nil
+}
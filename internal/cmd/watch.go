@@ -14,7 +14,10 @@ import (
 	"github.com/spf13/cobra"
 )
 
-const watchCheckColWidth = 28
+const (
+	watchCheckColWidth = 28
+	watchCheckURLWidth = 40 // keeps failed-check rows on one line so redraws clear cleanly
+)
 
 var watchCmd = &cobra.Command{
 	Use:     "watch [branch or PR number]",
@@ -69,6 +72,9 @@ func runWatch(cmd *cobra.Command, args []string) error {
 	if !github.IsAvailable() {
 		return fmt.Errorf("gh CLI is required for this command (brew install gh)")
 	}
+	if err := github.CheckAuth(); err != nil {
+		return err
+	}
 
 	ctx, err := newContext()
 	if err != nil {
@@ -121,7 +127,7 @@ func runWatch(cmd *cobra.Command, args []string) error {
 	// Resolve merge method once up front (2 gh calls — no need to repeat per poll)
 	mergeMethod := ""
 	if watchMergeFlag {
-		m, err := github.GetDefaultMergeMethod()
+		m, err := github.GetDefaultMergeMethod(ctx.remoteHost())
 		if err != nil {
 			ui.Warn("Could not determine merge method, defaulting to merge: %v", err)
 			m = "merge"
@@ -276,28 +282,39 @@ func renderWatchTable(ws *github.WatchStatus) int {
 		type checkEntry struct {
 			glyph string
 			name  string
+			url   string
+			fail  bool
 		}
 		var entries []checkEntry
 		for _, c := range pass {
-			entries = append(entries, checkEntry{glyph: ui.Green(ui.Pass), name: c.Name})
+			entries = append(entries, checkEntry{glyph: ui.Green(ui.Pass), name: c.Name, url: c.URL()})
 		}
 		for _, c := range fail {
-			entries = append(entries, checkEntry{glyph: ui.Red(ui.Fail), name: c.Name})
+			entries = append(entries, checkEntry{glyph: ui.Red(ui.Fail), name: c.Name, url: c.URL(), fail: true})
 		}
 		for _, c := range pending {
-			entries = append(entries, checkEntry{glyph: ui.Yellow(ui.Pending), name: c.Name})
+			entries = append(entries, checkEntry{glyph: ui.Yellow(ui.Pending), name: c.Name, url: c.URL()})
 		}
 
-		// Render in two columns
-		for i := 0; i < len(entries); i += 2 {
+		// Render in two columns; failed checks with a link get a row of
+		// their own so the URL has room. Names link to the check when the
+		// terminal supports hyperlinks.
+		ownRow := func(e checkEntry) bool { return e.fail && e.url != "" }
+		for i := 0; i < len(entries); {
 			left := entries[i]
 			leftName := ui.Truncate(left.name, watchCheckColWidth)
-			if i+1 < len(entries) {
+			switch {
+			case ownRow(left):
+				fmt.Printf("    %s  %s  %s\n", left.glyph, ui.LinkPadded(leftName, left.url, watchCheckColWidth), ui.Dim(ui.Truncate(left.url, watchCheckURLWidth)))
+				i++
+			case i+1 < len(entries) && !ownRow(entries[i+1]):
 				right := entries[i+1]
 				rightName := ui.Truncate(right.name, watchCheckColWidth)
-				fmt.Printf("    %s  %-*s  %s  %s\n", left.glyph, watchCheckColWidth, leftName, right.glyph, rightName)
-			} else {
-				fmt.Printf("    %s  %s\n", left.glyph, leftName)
+				fmt.Printf("    %s  %s  %s  %s\n", left.glyph, ui.LinkPadded(leftName, left.url, watchCheckColWidth), right.glyph, ui.Link(rightName, right.url))
+				i += 2
+			default:
+				fmt.Printf("    %s  %s\n", left.glyph, ui.Link(leftName, left.url))
+				i++
 			}
 			lines++
 		}
@@ -321,7 +338,7 @@ func renderWatchTable(ws *github.WatchStatus) int {
 				lg, lt := formatReviewItem(items[i])
 				if i+1 < len(items) {
 					rg, rt := formatReviewItem(items[i+1])
-					fmt.Printf("    %s  %-*s  %s  %s\n", lg, watchCheckColWidth, lt, rg, rt)
+					fmt.Printf("    %s  %s  %s  %s\n", lg, ui.PadRight(lt, watchCheckColWidth), rg, rt)
 				} else {
 					fmt.Printf("    %s  %s\n", lg, lt)
 				}
@@ -336,7 +353,7 @@ func renderWatchTable(ws *github.WatchStatus) int {
 }
 
 // formatReviewItem returns the glyph and text for a review item separately,
-// so the caller can pad the text correctly (ANSI codes break %-*s alignment).
+// so the caller can pad the text into a column with ui.PadRight.
 func formatReviewItem(item github.ReviewItem) (glyph, text string) {
 	login := ui.Truncate(item.Login, 16)
 	switch item.State {
@@ -390,6 +407,7 @@ func printWatchVerdict(ws *github.WatchStatus) {
 			ui.Error("%d check(s) failed", cs.Fail)
 		}
 		ui.Error("PR is a draft %s mark as ready first", ui.Dash)
+		printFailedCheckURLs(ws)
 		return
 	}
 
@@ -409,6 +427,20 @@ func printWatchVerdict(ws *github.WatchStatus) {
 	case ws.MergeStateStatus == "BLOCKED":
 		ui.Error("Blocked by branch protection")
 	}
+	if ws.State == "OPEN" {
+		printFailedCheckURLs(ws)
+	}
+}
+
+// printFailedCheckURLs lists the full details link of each failed check, so
+// the logs are one click away once watching ends.
+func printFailedCheckURLs(ws *github.WatchStatus) {
+	_, fail, _ := ws.ChecksByStatus()
+	for _, c := range fail {
+		if url := c.URL(); url != "" {
+			fmt.Printf("   %s  %s\n", c.Name, ui.Dim(url))
+		}
+	}
 }
 
 // mergeIfRequested merges the PR when --merge is set and the PR is CLEAN.
@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/mvwi/wt/internal/git"
+	"github.com/mvwi/wt/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var whereamiCmd = &cobra.Command{
+	Use:   "whereami",
+	Short: "Show what wt resolved about this repo (for debugging)",
+	Long: `Print the repo context wt works from: repo name, main worktree, where
+worktrees are created, the base ref, and the branch prefix, plus the
+current worktree's name and branch.
+
+Useful when a worktree lands somewhere unexpected or branches get the
+wrong prefix.`,
+	Example: `  wt whereami`,
+	Args:    cobra.NoArgs,
+	RunE:    runWhereami,
+}
+
+func init() {
+	rootCmd.AddCommand(whereamiCmd)
+}
+
+func runWhereami(cmd *cobra.Command, args []string) error {
+	ctx, err := newContext()
+	if err != nil {
+		return err
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("cannot determine current directory: %w", err)
+	}
+
+	prefix := ui.Dim("(none)")
+	prefixSource := "branch_prefix"
+	if ctx.Config.BranchPrefix == nil {
+		prefixSource = "username_source = " + ctx.Config.EffectiveUsernameSource()
+		if u := ctx.Username(); u != "" {
+			prefix = u
+		}
+	} else if *ctx.Config.BranchPrefix != "" {
+		prefix = *ctx.Config.BranchPrefix
+	}
+
+	row := func(key, value string) {
+		fmt.Printf("  %s %s\n", ui.Dim(fmt.Sprintf("%-16s", key)), value)
+	}
+
+	ui.Header("REPO")
+	row("Repo name", ctx.RepoName)
+	if ctx.Bare {
+		row("Main worktree", ctx.MainWorktree+" "+ui.Dim("(bare repo)"))
+	} else {
+		row("Main worktree", ctx.MainWorktree)
+	}
+	row("Worktree root", ctx.ParentDir)
+	row("Worktree path", ctx.worktreePath("<name>"))
+	row("Base ref", ctx.baseRef())
+	row("Branch prefix", fmt.Sprintf("%s %s", prefix, ui.Dim("("+prefixSource+")")))
+	row("New branch", ctx.branchName("<name>"))
+
+	ui.Header("CURRENT")
+	top, err := git.TopLevel()
+	if err != nil {
+		row("Directory", cwd)
+		row("Worktree", ui.Dim("(not in a worktree)"))
+		fmt.Println()
+		return nil
+	}
+	name := ctx.shortName(top)
+	if top == ctx.MainWorktree {
+		name += " " + ui.Dim("(main)")
+	}
+	branch, err := git.CurrentBranch()
+	if err != nil || branch == "HEAD" {
+		branch = ui.Dim("(detached HEAD)")
+	}
+	row("Worktree", name)
+	row("Path", top)
+	row("Branch", branch)
+	fmt.Println()
+	return nil
+}
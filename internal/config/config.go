@@ -4,6 +4,9 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
 
 	"github.com/BurntSushi/toml"
 )
@@ -19,15 +22,36 @@ type Config struct {
 	Remote string `toml:"remote"`
 
 	// BranchPrefix is prepended to new branch names: "<prefix>/<name>".
-	// Default: git user's first name (lowercase). Set to "" to disable prefixing.
+	// Default: a username derived per UsernameSource. Set to "" to disable prefixing.
 	// Pointer so we can distinguish "not set" (nil) from "explicitly empty" ("").
 	BranchPrefix *string `toml:"branch_prefix"`
 
+	// UsernameSource picks where the default branch prefix comes from when
+	// BranchPrefix isn't set: "git-name-first" (default), "git-name-full",
+	// "git-email-local", or "gh-login".
+	UsernameSource string `toml:"username_source"`
+
 	// WorktreePrefix controls the directory naming: "<prefix><name>".
 	// Default: "wt-<repo>-". Set to customize (e.g., "wt-" for shorter names).
 	// Pointer so we can distinguish "not set" (nil) from "explicitly empty" ("").
 	WorktreePrefix *string `toml:"worktree_prefix"`
 
+	// WorktreeRoot is the directory new worktrees are created in, instead of
+	// "wt-<repo>/" next to the main worktree. Absolute, "~/"-relative, or
+	// relative to the main worktree (e.g. ".worktrees"). "{repo}" is replaced
+	// with the repo name, for use in global config: "~/worktrees/{repo}".
+	WorktreeRoot string `toml:"worktree_root"`
+
+	// BranchTemplate overrides the "<prefix>/<name>" pattern for new branch
+	// names, e.g. "feature/${date}-${name}". Placeholders: ${prefix}, ${name},
+	// ${repo}, ${user}, ${date}. Must contain ${name}.
+	BranchTemplate string `toml:"branch_template"`
+
+	// WorktreeTemplate overrides worktree_prefix for worktree directory
+	// names, relative to the worktree root, e.g. "${repo}-${name}". Same
+	// placeholders as BranchTemplate. Must contain ${name}.
+	WorktreeTemplate string `toml:"worktree_template"`
+
 	// StaleThreshold is the number of days after which a worktree with no open PR
 	// is considered stale in `wt list`. Default: 7.
 	StaleThreshold int `toml:"stale_threshold"`
@@ -37,6 +61,45 @@ type Config struct {
 	// Pointer to distinguish "not set" (nil → true) from "explicitly false".
 	AutoInstall *bool `toml:"auto_install"`
 
+	// FetchOnNew controls whether `wt new` fetches the base branch before
+	// creating the worktree. Default: true. `wt new --no-fetch` overrides it.
+	// Pointer to distinguish "not set" (nil → true) from "explicitly false".
+	FetchOnNew *bool `toml:"fetch_on_new"`
+
+	// ListPRs controls whether `wt list` fetches PR data from GitHub.
+	// Default: true. `wt list --no-pr` and WT_NO_PR=1 override it.
+	// Pointer to distinguish "not set" (nil → true) from "explicitly false".
+	ListPRs *bool `toml:"list_prs"`
+
+	// GHRetries is how many times read-only gh calls (PR list/view) are
+	// retried on transient failures (5xx, rate limits, network errors).
+	// Default: 2. Pointer to distinguish "not set" (nil → 2) from 0 (no retries).
+	GHRetries *int `toml:"gh_retries"`
+
+	// PRLimit caps how many PRs per state (open/merged/closed) wt list, wt
+	// prune, and friends fetch in one listing. Branches missing from a list
+	// that hit the cap are looked up by head name. Default: 50.
+	PRLimit int `toml:"pr_limit"`
+
+	// NetworkTimeout bounds network calls, in seconds: gh calls outright,
+	// git fetch/push only while they produce no output. Default: 30.
+	NetworkTimeout int `toml:"network_timeout"`
+
+	// GitTimeout bounds local read-only git queries, in seconds. Default: 10.
+	// Commands that rewrite the work tree (worktree add, rebase) are exempt.
+	GitTimeout int `toml:"git_timeout"`
+
+	// Hyperlinks controls clickable OSC-8 links (PR numbers, CI checks).
+	// Default: auto-detect terminal support. Pointer to distinguish "not set"
+	// (nil → auto) from an explicit true/false.
+	Hyperlinks *bool `toml:"hyperlinks"`
+
+	// CheckUpdates controls the daily background check for a new wt release
+	// and the "Update available" banner. Default: true. Only read from the
+	// global config's top level, since it's checked before any repo is
+	// known. WT_NO_UPDATE_CHECK overrides it.
+	CheckUpdates *bool `toml:"check_updates"`
+
 	// Init configures the `wt init` command behavior.
 	Init InitConfig `toml:"init"`
 }
@@ -54,8 +117,50 @@ type InitConfig struct {
 	// CopyFiles are copied from the main worktree if missing in the target.
 	CopyFiles []string `toml:"copy_files"`
 
-	// Commands are shell commands run sequentially during init.
-	Commands []string `toml:"commands"`
+	// Commands are shell commands run sequentially during init. Each is a
+	// plain string or a {name, run} table; names are for wt init --only/--skip.
+	Commands []InitCommand `toml:"commands"`
+
+	// Env is added to the environment of init commands (and wt rebase's
+	// auto-install). Values can reference $WT_WORKTREE, $WT_WORKTREE_NAME,
+	// $WT_MAIN_WORKTREE, $WT_REPO, and the inherited environment.
+	Env map[string]string `toml:"env"`
+
+	// Timeout bounds each init command, in seconds. Default: 600.
+	Timeout int `toml:"timeout"`
+}
+
+// InitCommand is one init command, optionally named so wt init --only and
+// --skip can pick it out.
+type InitCommand struct {
+	Name string `toml:"name"`
+	Run  string `toml:"run"`
+}
+
+// UnmarshalTOML accepts a plain command string or a {name, run} table.
+func (c *InitCommand) UnmarshalTOML(v any) error {
+	switch v := v.(type) {
+	case string:
+		*c = InitCommand{Run: v}
+	case map[string]any:
+		name, _ := v["name"].(string)
+		run, _ := v["run"].(string)
+		if run == "" {
+			return fmt.Errorf("init command %q: run is required", name)
+		}
+		*c = InitCommand{Name: name, Run: run}
+	default:
+		return fmt.Errorf("init command must be a string or a {name, run} table, got %T", v)
+	}
+	return nil
+}
+
+// Label returns the command's name, or the command itself when unnamed.
+func (c InitCommand) Label() string {
+	if c.Name != "" {
+		return c.Name
+	}
+	return c.Run
 }
 
 // Load reads config with layered precedence:
@@ -72,23 +177,18 @@ func Load(dir, repoName string) (*Config, error) {
 	}
 
 	// Layer 1+2: global defaults + per-repo overrides
-	if globalPath, err := globalConfigPath(); err == nil {
-		data, readErr := os.ReadFile(globalPath)
-		if readErr == nil {
-			var gf globalFile
-			if err := toml.Unmarshal(data, &gf); err != nil {
-				return nil, fmt.Errorf("global config (%s): %w", globalPath, err)
-			}
-			// Apply global defaults
-			mergeConfig(cfg, &gf.Config)
-			// Apply per-repo overrides
-			if repoName != "" {
-				if repoCfg, ok := gf.Repos[repoName]; ok {
-					mergeConfig(cfg, &repoCfg)
-				}
+	gf, err := readGlobalFile()
+	if err != nil {
+		return nil, err
+	}
+	if gf != nil {
+		// Apply global defaults
+		mergeConfig(cfg, &gf.Config)
+		// Apply per-repo overrides
+		if repoName != "" {
+			if repoCfg, ok := gf.Repos[repoName]; ok {
+				mergeConfig(cfg, &repoCfg)
 			}
-		} else if !os.IsNotExist(readErr) {
-			return nil, fmt.Errorf("global config (%s): %w", globalPath, readErr)
 		}
 	}
 
@@ -102,9 +202,51 @@ func Load(dir, repoName string) (*Config, error) {
 		mergeConfig(cfg, &repoCfg)
 	}
 
+	if err := cfg.validateTemplates(); err != nil {
+		return nil, err
+	}
+
 	return cfg, nil
 }
 
+// LoadGlobal reads only the defaults and the global config's top-level
+// fields, for settings needed before (or without) a repo, like
+// check_updates.
+func LoadGlobal() (*Config, error) {
+	cfg := &Config{
+		BaseBranch: "main",
+		Remote:     "origin",
+	}
+	gf, err := readGlobalFile()
+	if err != nil {
+		return nil, err
+	}
+	if gf != nil {
+		mergeConfig(cfg, &gf.Config)
+	}
+	return cfg, nil
+}
+
+// readGlobalFile parses the global config, or returns nil if there is none.
+func readGlobalFile() (*globalFile, error) {
+	globalPath, err := GlobalPath()
+	if err != nil {
+		return nil, nil
+	}
+	data, err := os.ReadFile(globalPath)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("global config (%s): %w", globalPath, err)
+	}
+	var gf globalFile
+	if err := toml.Unmarshal(data, &gf); err != nil {
+		return nil, fmt.Errorf("global config (%s): %w", globalPath, err)
+	}
+	return &gf, nil
+}
+
 // mergeConfig copies non-zero fields from src into dst.
 func mergeConfig(dst, src *Config) {
 	if src.BaseBranch != "" {
@@ -116,25 +258,67 @@ func mergeConfig(dst, src *Config) {
 	if src.BranchPrefix != nil {
 		dst.BranchPrefix = src.BranchPrefix
 	}
+	if src.UsernameSource != "" {
+		dst.UsernameSource = src.UsernameSource
+	}
 	if src.WorktreePrefix != nil {
 		dst.WorktreePrefix = src.WorktreePrefix
 	}
+	if src.WorktreeRoot != "" {
+		dst.WorktreeRoot = src.WorktreeRoot
+	}
+	if src.BranchTemplate != "" {
+		dst.BranchTemplate = src.BranchTemplate
+	}
+	if src.WorktreeTemplate != "" {
+		dst.WorktreeTemplate = src.WorktreeTemplate
+	}
 	if src.StaleThreshold > 0 {
 		dst.StaleThreshold = src.StaleThreshold
 	}
 	if src.AutoInstall != nil {
 		dst.AutoInstall = src.AutoInstall
 	}
+	if src.FetchOnNew != nil {
+		dst.FetchOnNew = src.FetchOnNew
+	}
+	if src.ListPRs != nil {
+		dst.ListPRs = src.ListPRs
+	}
+	if src.GHRetries != nil {
+		dst.GHRetries = src.GHRetries
+	}
+	if src.PRLimit > 0 {
+		dst.PRLimit = src.PRLimit
+	}
+	if src.NetworkTimeout > 0 {
+		dst.NetworkTimeout = src.NetworkTimeout
+	}
+	if src.GitTimeout > 0 {
+		dst.GitTimeout = src.GitTimeout
+	}
+	if src.Hyperlinks != nil {
+		dst.Hyperlinks = src.Hyperlinks
+	}
+	if src.CheckUpdates != nil {
+		dst.CheckUpdates = src.CheckUpdates
+	}
 	if len(src.Init.CopyFiles) > 0 {
 		dst.Init.CopyFiles = src.Init.CopyFiles
 	}
 	if len(src.Init.Commands) > 0 {
 		dst.Init.Commands = src.Init.Commands
 	}
+	if len(src.Init.Env) > 0 {
+		dst.Init.Env = src.Init.Env
+	}
+	if src.Init.Timeout > 0 {
+		dst.Init.Timeout = src.Init.Timeout
+	}
 }
 
-// globalConfigPath returns ~/.config/wt/config.toml.
-func globalConfigPath() (string, error) {
+// GlobalPath returns ~/.config/wt/config.toml.
+func GlobalPath() (string, error) {
 	home, err := os.UserHomeDir()
 	if err != nil {
 		return "", err
@@ -142,6 +326,45 @@ func globalConfigPath() (string, error) {
 	return filepath.Join(home, ".config", "wt", "config.toml"), nil
 }
 
+// CheckFile parses a single config file — the global config when global is
+// true, else a .wt.toml — and validates it the way Load would. It also
+// returns keys the file sets that wt doesn't recognize (typos, removed
+// settings), which Load silently ignores.
+func CheckFile(path string, global bool) (unknown []string, err error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var md toml.MetaData
+	var configs []Config
+	if global {
+		var gf globalFile
+		md, err = toml.Decode(string(data), &gf)
+		configs = append(configs, gf.Config)
+		for _, c := range gf.Repos {
+			configs = append(configs, c)
+		}
+	} else {
+		var c Config
+		md, err = toml.Decode(string(data), &c)
+		configs = append(configs, c)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for _, key := range md.Undecoded() {
+		unknown = append(unknown, key.String())
+	}
+	for i := range configs {
+		if err := configs[i].validateTemplates(); err != nil {
+			return unknown, err
+		}
+	}
+	return unknown, nil
+}
+
 // EffectiveBranchName builds the full branch name for a new worktree.
 // If BranchPrefix is explicitly set (even to ""), uses that value.
 // Otherwise falls back to gitUsername. Returns just "name" if both are empty.
@@ -158,6 +381,99 @@ func (c *Config) EffectiveBranchName(name, gitUsername string) string {
 	return prefix + "/" + name
 }
 
+// TemplateVars are the values substituted for BranchTemplate and
+// WorktreeTemplate placeholders.
+type TemplateVars struct {
+	Prefix string // ${prefix}: branch_prefix, or the username when unset
+	Name   string // ${name}: the worktree name given to wt new
+	Repo   string // ${repo}: the repo name
+	User   string // ${user}: the username per username_source
+	Date   string // ${date}: the creation date, YYYY-MM-DD
+}
+
+// templatePlaceholderRe matches a ${...} placeholder.
+var templatePlaceholderRe = regexp.MustCompile(`\$\{([^}]*)\}`)
+
+// ExpandTemplate replaces the placeholders in tmpl with vars. Placeholders
+// that expand to "" drop out along with the "/" they leave dangling, so
+// "${prefix}/${name}" with no prefix is just the name. Errors on unknown
+// placeholders and on templates without ${name}, which would give every
+// worktree the same name.
+func ExpandTemplate(tmpl string, vars TemplateVars) (string, error) {
+	if !strings.Contains(tmpl, "${name}") {
+		return "", fmt.Errorf("template %q must contain ${name}", tmpl)
+	}
+	unknown := ""
+	hasUnknown := false
+	out := templatePlaceholderRe.ReplaceAllStringFunc(tmpl, func(m string) string {
+		switch key := m[2 : len(m)-1]; key {
+		case "prefix":
+			return vars.Prefix
+		case "name":
+			return vars.Name
+		case "repo":
+			return vars.Repo
+		case "user":
+			return vars.User
+		case "date":
+			return vars.Date
+		default:
+			if !hasUnknown {
+				unknown, hasUnknown = key, true
+			}
+			return m
+		}
+	})
+	if hasUnknown {
+		return "", fmt.Errorf("template %q: unknown placeholder ${%s} (use ${prefix}, ${name}, ${repo}, ${user}, or ${date})", tmpl, unknown)
+	}
+	for strings.Contains(out, "//") {
+		out = strings.ReplaceAll(out, "//", "/")
+	}
+	return strings.Trim(out, "/"), nil
+}
+
+// validateTemplates checks BranchTemplate and WorktreeTemplate up front, so
+// a bad template fails at load rather than when a worktree is created.
+func (c *Config) validateTemplates() error {
+	if c.BranchTemplate != "" {
+		if _, err := ExpandTemplate(c.BranchTemplate, TemplateVars{Name: "x"}); err != nil {
+			return fmt.Errorf("branch_template: %w", err)
+		}
+	}
+	if c.WorktreeTemplate != "" {
+		if _, err := ExpandTemplate(c.WorktreeTemplate, TemplateVars{Name: "x"}); err != nil {
+			return fmt.Errorf("worktree_template: %w", err)
+		}
+	}
+	return nil
+}
+
+// Username sources for UsernameSource.
+const (
+	UsernameGitNameFirst  = "git-name-first"  // first word of user.name: "Mary Watson" → "mary"
+	UsernameGitNameFull   = "git-name-full"   // all of user.name: "Mary Watson" → "mary-watson"
+	UsernameGitEmailLocal = "git-email-local" // user.email before "@": "mj.watson@x.com" → "mj.watson"
+	UsernameGHLogin       = "gh-login"        // GitHub login via gh
+)
+
+// EffectiveUsernameSource returns the username source, defaulting to
+// git-name-first. Unknown values are returned as-is for the caller to reject.
+func (c *Config) EffectiveUsernameSource() string {
+	if c.UsernameSource != "" {
+		return c.UsernameSource
+	}
+	return UsernameGitNameFirst
+}
+
+// EffectiveCheckUpdates returns whether update checks are on (default: true).
+func (c *Config) EffectiveCheckUpdates() bool {
+	if c.CheckUpdates != nil {
+		return *c.CheckUpdates
+	}
+	return true
+}
+
 // EffectiveStaleThreshold returns the stale threshold in days, defaulting to 7.
 func (c *Config) EffectiveStaleThreshold() int {
 	if c.StaleThreshold > 0 {
@@ -174,12 +490,110 @@ func (c *Config) EffectiveAutoInstall() bool {
 	return true
 }
 
+// EffectiveFetchOnNew returns whether `wt new` fetches first (default: true).
+func (c *Config) EffectiveFetchOnNew() bool {
+	if c.FetchOnNew != nil {
+		return *c.FetchOnNew
+	}
+	return true
+}
+
+// EffectiveListPRs returns whether `wt list` fetches PR data (default: true).
+func (c *Config) EffectiveListPRs() bool {
+	if c.ListPRs != nil {
+		return *c.ListPRs
+	}
+	return true
+}
+
+// EffectiveGHRetries returns the gh read retry count (default: 2, min 0).
+func (c *Config) EffectiveGHRetries() int {
+	if c.GHRetries != nil {
+		return max(*c.GHRetries, 0)
+	}
+	return 2
+}
+
+// EffectivePRLimit returns the per-state PR listing cap (default: 50).
+func (c *Config) EffectivePRLimit() int {
+	if c.PRLimit > 0 {
+		return c.PRLimit
+	}
+	return 50
+}
+
+// EffectiveNetworkTimeout returns the network call timeout (default: 30s).
+func (c *Config) EffectiveNetworkTimeout() time.Duration {
+	if c.NetworkTimeout > 0 {
+		return time.Duration(c.NetworkTimeout) * time.Second
+	}
+	return 30 * time.Second
+}
+
+// EffectiveGitTimeout returns the local git query timeout (default: 10s).
+func (c *Config) EffectiveGitTimeout() time.Duration {
+	if c.GitTimeout > 0 {
+		return time.Duration(c.GitTimeout) * time.Second
+	}
+	return 10 * time.Second
+}
+
+// EffectiveInitTimeout returns the per-command init timeout (default: 10m).
+func (c *Config) EffectiveInitTimeout() time.Duration {
+	if c.Init.Timeout > 0 {
+		return time.Duration(c.Init.Timeout) * time.Second
+	}
+	return 10 * time.Minute
+}
+
 // EffectiveWorktreeDir builds the worktree directory name.
 // Default pattern: "wt-<repo>/<name>" (nested under a per-project folder).
 // If WorktreePrefix is explicitly set (even to ""), uses flat layout instead.
+// Under a WorktreeRoot the root already separates repos, so the layout is
+// flat: "<prefix><name>", with no prefix unless one is set.
 func (c *Config) EffectiveWorktreeDir(repoName, name string) string {
 	if c.WorktreePrefix != nil {
 		return *c.WorktreePrefix + name
 	}
+	if c.WorktreeRoot != "" {
+		return name
+	}
 	return filepath.Join("wt-"+repoName, name)
 }
+
+// WorktreeDirPrefix returns what to strip from a worktree directory's base
+// name to get the worktree name: the prefix from EffectiveWorktreeDir, or
+// with a WorktreeTemplate, the literal text before ${name} in its last path
+// element. Returns "" when that text varies per worktree (e.g. ${date}).
+func (c *Config) WorktreeDirPrefix(repoName string) string {
+	if c.WorktreeTemplate == "" {
+		return c.EffectiveWorktreeDir(repoName, "")
+	}
+	before, _, _ := strings.Cut(c.WorktreeTemplate, "${name}")
+	if i := strings.LastIndex(before, "/"); i >= 0 {
+		before = before[i+1:]
+	}
+	before = strings.ReplaceAll(before, "${repo}", repoName)
+	if strings.Contains(before, "${") {
+		return ""
+	}
+	return before
+}
+
+// EffectiveWorktreeRoot resolves WorktreeRoot to an absolute directory, or
+// returns "" when it isn't set (worktrees go next to the main worktree).
+func (c *Config) EffectiveWorktreeRoot(mainWorktree, repoName string) string {
+	if c.WorktreeRoot == "" {
+		return ""
+	}
+	root := strings.ReplaceAll(c.WorktreeRoot, "{repo}", repoName)
+	if root == "~" || strings.HasPrefix(root, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			root = filepath.Join(home, root[1:])
+		}
+	}
+	if !filepath.IsAbs(root) {
+		root = filepath.Join(mainWorktree, root)
+	}
+	return filepath.Clean(root)
+}
@@ -3,7 +3,10 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestMergeConfig(t *testing.T) {
@@ -31,7 +34,7 @@ func TestMergeConfig(t *testing.T) {
 			StaleThreshold: 14,
 			Init: InitConfig{
 				CopyFiles: []string{".env"},
-				Commands:  []string{"make build"},
+				Commands:  []InitCommand{{Run: "make build"}},
 			},
 		}
 		src := &Config{} // all zero/nil values
@@ -60,6 +63,42 @@ func TestMergeConfig(t *testing.T) {
 		}
 	})
 
+	t.Run("explicit false bool overrides default", func(t *testing.T) {
+		off := false
+		dst := &Config{}
+		src := &Config{FetchOnNew: &off, ListPRs: &off}
+		mergeConfig(dst, src)
+
+		if dst.EffectiveFetchOnNew() {
+			t.Errorf("EffectiveFetchOnNew() = true, want false")
+		}
+		if (&Config{}).EffectiveFetchOnNew() != true {
+			t.Errorf("EffectiveFetchOnNew() default should be true")
+		}
+		if dst.EffectiveListPRs() {
+			t.Errorf("EffectiveListPRs() = true, want false")
+		}
+		if (&Config{}).EffectiveListPRs() != true {
+			t.Errorf("EffectiveListPRs() default should be true")
+		}
+	})
+
+	t.Run("init env and timeout", func(t *testing.T) {
+		dst := &Config{Init: InitConfig{Env: map[string]string{"A": "1", "B": "2"}}}
+		src := &Config{Init: InitConfig{Env: map[string]string{"C": "3"}, Timeout: 60}}
+		mergeConfig(dst, src)
+
+		if len(dst.Init.Env) != 1 || dst.Init.Env["C"] != "3" {
+			t.Errorf("Init.Env = %v, want map[C:3]", dst.Init.Env)
+		}
+		if got := dst.EffectiveInitTimeout(); got != time.Minute {
+			t.Errorf("EffectiveInitTimeout() = %s, want 1m", got)
+		}
+		if got := (&Config{}).EffectiveInitTimeout(); got != 10*time.Minute {
+			t.Errorf("EffectiveInitTimeout() default = %s, want 10m", got)
+		}
+	})
+
 	t.Run("slices replace entirely", func(t *testing.T) {
 		dst := &Config{Init: InitConfig{CopyFiles: []string{".env", ".env.local"}}}
 		src := &Config{Init: InitConfig{CopyFiles: []string{".env.production"}}}
@@ -145,10 +184,52 @@ commands = ["pnpm install", "npx prisma generate"]
 		if len(cfg.Init.CopyFiles) != 2 || cfg.Init.CopyFiles[0] != ".env" {
 			t.Errorf("CopyFiles = %v, want [.env .env.local]", cfg.Init.CopyFiles)
 		}
-		if len(cfg.Init.Commands) != 2 || cfg.Init.Commands[0] != "pnpm install" {
+		if len(cfg.Init.Commands) != 2 || cfg.Init.Commands[0].Run != "pnpm install" {
 			t.Errorf("Commands = %v, want [pnpm install, npx prisma generate]", cfg.Init.Commands)
 		}
 	})
+
+	t.Run("init commands mix strings and named tables", func(t *testing.T) {
+		dir := t.TempDir()
+		err := os.WriteFile(filepath.Join(dir, ".wt.toml"), []byte(`
+[init]
+commands = ["pnpm install", { name = "db", run = "npx prisma generate" }]
+`), 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		cfg, err := Load(dir, "myrepo")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := []InitCommand{{Run: "pnpm install"}, {Name: "db", Run: "npx prisma generate"}}
+		if !slices.Equal(cfg.Init.Commands, want) {
+			t.Errorf("Commands = %+v, want %+v", cfg.Init.Commands, want)
+		}
+		if got := cfg.Init.Commands[0].Label(); got != "pnpm install" {
+			t.Errorf("unnamed Label() = %q, want the command", got)
+		}
+		if got := cfg.Init.Commands[1].Label(); got != "db" {
+			t.Errorf("named Label() = %q, want db", got)
+		}
+	})
+
+	t.Run("init command table without run", func(t *testing.T) {
+		dir := t.TempDir()
+		err := os.WriteFile(filepath.Join(dir, ".wt.toml"), []byte(`
+[init]
+commands = [{ name = "db" }]
+`), 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := Load(dir, "myrepo"); err == nil {
+			t.Error("expected error for init command without run, got nil")
+		}
+	})
 }
 
 func strPtr(s string) *string { return &s }
@@ -203,3 +284,213 @@ func TestEffectiveBranchName(t *testing.T) {
 		})
 	}
 }
+
+func TestEffectiveUsernameSource(t *testing.T) {
+	if got := (&Config{}).EffectiveUsernameSource(); got != UsernameGitNameFirst {
+		t.Errorf("default = %q, want %q", got, UsernameGitNameFirst)
+	}
+
+	dst := &Config{}
+	mergeConfig(dst, &Config{UsernameSource: UsernameGHLogin})
+	if got := dst.EffectiveUsernameSource(); got != UsernameGHLogin {
+		t.Errorf("after merge = %q, want %q", got, UsernameGHLogin)
+	}
+}
+
+func TestEffectiveWorktreeRoot(t *testing.T) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		t.Skip("no home directory")
+	}
+	tests := []struct {
+		root string
+		want string
+	}{
+		{"", ""},
+		{"/srv/worktrees", "/srv/worktrees"},
+		{".worktrees", "/code/myrepo/.worktrees"},
+		{"../trees/{repo}", "/code/trees/myrepo"},
+		{"~/worktrees/{repo}", filepath.Join(home, "worktrees", "myrepo")},
+	}
+	for _, tt := range tests {
+		cfg := &Config{WorktreeRoot: tt.root}
+		if got := cfg.EffectiveWorktreeRoot("/code/myrepo", "myrepo"); got != tt.want {
+			t.Errorf("EffectiveWorktreeRoot() with %q = %q, want %q", tt.root, got, tt.want)
+		}
+	}
+
+	// Under a root the layout is flat, unless a prefix is set
+	cfg := &Config{WorktreeRoot: ".worktrees"}
+	if got := cfg.EffectiveWorktreeDir("myrepo", "feat"); got != "feat" {
+		t.Errorf("EffectiveWorktreeDir under root = %q, want %q", got, "feat")
+	}
+	cfg.WorktreePrefix = strPtr("wt-")
+	if got := cfg.EffectiveWorktreeDir("myrepo", "feat"); got != "wt-feat" {
+		t.Errorf("EffectiveWorktreeDir under root with prefix = %q, want %q", got, "wt-feat")
+	}
+}
+
+func TestExpandTemplate(t *testing.T) {
+	vars := TemplateVars{Prefix: "mary", Name: "sidebar", Repo: "myapp", User: "mary", Date: "2026-03-01"}
+	tests := []struct {
+		tmpl string
+		vars TemplateVars
+		want string
+	}{
+		{"${prefix}/${name}", vars, "mary/sidebar"},
+		{"feature/${date}-${name}", vars, "feature/2026-03-01-sidebar"},
+		{"${repo}-${name}", vars, "myapp-sidebar"},
+		{"${user}/${repo}/${name}", vars, "mary/myapp/sidebar"},
+		{"${name}", vars, "sidebar"},
+		// Empty values drop out without leaving a dangling "/"
+		{"${prefix}/${name}", TemplateVars{Name: "sidebar"}, "sidebar"},
+		{"team/${user}/${name}", TemplateVars{Name: "sidebar"}, "team/sidebar"},
+	}
+	for _, tt := range tests {
+		got, err := ExpandTemplate(tt.tmpl, tt.vars)
+		if err != nil {
+			t.Errorf("ExpandTemplate(%q) error: %v", tt.tmpl, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ExpandTemplate(%q) = %q, want %q", tt.tmpl, got, tt.want)
+		}
+	}
+}
+
+func TestExpandTemplateErrors(t *testing.T) {
+	for _, tmpl := range []string{
+		"feature/${date}",        // no ${name}
+		"${ticket}-${name}",      // unknown placeholder
+		"${prefix}/${Name}",      // placeholders are case-sensitive
+		"${name}-${}",            // empty placeholder
+		"release/${name}/${ver}", // unknown after ${name}
+	} {
+		if got, err := ExpandTemplate(tmpl, TemplateVars{Name: "x"}); err == nil {
+			t.Errorf("ExpandTemplate(%q) = %q, want error", tmpl, got)
+		}
+	}
+}
+
+func TestLoadRejectsBadTemplate(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("HOME", t.TempDir())
+	if err := os.WriteFile(filepath.Join(dir, ".wt.toml"), []byte(`branch_template = "feature/${date}"`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	_, err := Load(dir, "myrepo")
+	if err == nil || !strings.Contains(err.Error(), "branch_template") {
+		t.Errorf("Load() error = %v, want branch_template error", err)
+	}
+}
+
+func TestWorktreeDirPrefix(t *testing.T) {
+	tests := []struct {
+		tmpl string
+		want string
+	}{
+		{"", "wt-myrepo"},
+		{"${repo}-${name}", "myrepo-"},
+		{"trees/wt-${name}", "wt-"},
+		{"${date}-${name}", ""},
+		{"${name}", ""},
+	}
+	for _, tt := range tests {
+		cfg := &Config{WorktreeTemplate: tt.tmpl}
+		if got := cfg.WorktreeDirPrefix("myrepo"); got != tt.want {
+			t.Errorf("WorktreeDirPrefix() with %q = %q, want %q", tt.tmpl, got, tt.want)
+		}
+	}
+}
+
+func TestCheckFile(t *testing.T) {
+	write := func(t *testing.T, content string) string {
+		t.Helper()
+		path := filepath.Join(t.TempDir(), "config.toml")
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	t.Run("valid repo config", func(t *testing.T) {
+		path := write(t, "base_branch = \"develop\"\n[init]\ncommands = [\"pnpm install\", {name = \"db\", run = \"make db\"}]\n")
+		unknown, err := CheckFile(path, false)
+		if err != nil || len(unknown) != 0 {
+			t.Errorf("CheckFile() = %v, %v; want no unknown keys, no error", unknown, err)
+		}
+	})
+
+	t.Run("unknown keys", func(t *testing.T) {
+		path := write(t, "base_brnach = \"develop\"\n[init]\ncopy = [\".env\"]\n")
+		unknown, err := CheckFile(path, false)
+		if err != nil {
+			t.Fatalf("CheckFile() error = %v", err)
+		}
+		if want := []string{"base_brnach", "init.copy"}; !slices.Equal(unknown, want) {
+			t.Errorf("CheckFile() unknown = %v, want %v", unknown, want)
+		}
+	})
+
+	t.Run("repos section only valid globally", func(t *testing.T) {
+		path := write(t, "[repos.myrepo]\nbase_branch = \"develop\"\n")
+		if unknown, err := CheckFile(path, true); err != nil || len(unknown) != 0 {
+			t.Errorf("CheckFile(global) = %v, %v; want clean", unknown, err)
+		}
+		if unknown, _ := CheckFile(path, false); len(unknown) == 0 {
+			t.Error("CheckFile(repo) accepted [repos]; want unknown keys")
+		}
+	})
+
+	t.Run("syntax error", func(t *testing.T) {
+		if _, err := CheckFile(write(t, "base_branch = "), false); err == nil {
+			t.Error("CheckFile() error = nil, want parse error")
+		}
+	})
+
+	t.Run("bad template in per-repo section", func(t *testing.T) {
+		path := write(t, "[repos.myrepo]\nbranch_template = \"${nope}\"\n")
+		if _, err := CheckFile(path, true); err == nil || !strings.Contains(err.Error(), "branch_template") {
+			t.Errorf("CheckFile() error = %v, want branch_template error", err)
+		}
+	})
+}
+
+func TestLoadGlobal(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	cfg, err := LoadGlobal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cfg.EffectiveCheckUpdates() {
+		t.Error("EffectiveCheckUpdates() = false with no config, want true")
+	}
+
+	dir := filepath.Join(home, ".config", "wt")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	err = os.WriteFile(filepath.Join(dir, "config.toml"), []byte(`
+check_updates = false
+base_branch = "develop"
+
+[repos.myrepo]
+base_branch = "staging"
+`), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err = LoadGlobal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.EffectiveCheckUpdates() {
+		t.Error("EffectiveCheckUpdates() = true, want false from check_updates = false")
+	}
+	if cfg.BaseBranch != "develop" {
+		t.Errorf("BaseBranch = %q, want the top-level %q, not a [repos] override", cfg.BaseBranch, "develop")
+	}
+}
@@ -25,6 +25,24 @@ func BranchExists(name string) bool {
 	return RunSilent("show-ref", "--verify", "--quiet", "refs/heads/"+name) == nil
 }
 
+// ListLocalBranches returns the names of all local branches in a single
+// `git for-each-ref` call. Use this instead of calling BranchExists in a loop.
+func ListLocalBranches() ([]string, error) {
+	// Full refnames, not %(refname:short): short names become "heads/x" when
+	// a tag or remote shares the branch name.
+	out, err := Run("for-each-ref", "--format=%(refname)", "refs/heads")
+	if err != nil {
+		return nil, err
+	}
+	var branches []string
+	for _, line := range strings.Split(out, "\n") {
+		if name := strings.TrimPrefix(strings.TrimSpace(line), "refs/heads/"); name != "" {
+			branches = append(branches, name)
+		}
+	}
+	return branches, nil
+}
+
 // RemoteBranchExists checks if a remote branch exists (e.g., "origin/feature").
 func RemoteBranchExists(name string) bool {
 	return RunSilent("show-ref", "--verify", "--quiet", "refs/remotes/"+name) == nil
@@ -49,12 +67,49 @@ func ResolveBranch(name, remote string) (ref string, isRemote bool, err error) {
 	return "", false, fmt.Errorf("branch not found: %s (tried local and remote refs)", name)
 }
 
+// RefKind is what ResolveRef found a name to be.
+type RefKind int
+
+const (
+	RefLocalBranch RefKind = iota + 1
+	RefRemoteBranch
+	RefTag
+	RefCommit
+)
+
+// ResolveRef resolves name like ResolveBranch, then as a tag, then as a
+// commit (a SHA, abbreviated or full, or anything rev-parse accepts, like
+// HEAD~3). For branches ref is what ResolveBranch returns; for a tag it's
+// refs/tags/<name>; for a commit it's the full SHA.
+func ResolveRef(name, remote string) (ref string, kind RefKind, err error) {
+	if ref, isRemote, err := ResolveBranch(name, remote); err == nil {
+		if isRemote {
+			return ref, RefRemoteBranch, nil
+		}
+		return ref, RefLocalBranch, nil
+	}
+	tag := "refs/tags/" + strings.TrimPrefix(name, "refs/tags/")
+	if RunSilent("show-ref", "--verify", "--quiet", tag) == nil {
+		return tag, RefTag, nil
+	}
+	if sha, err := Run("rev-parse", "--verify", "--quiet", name+"^{commit}"); err == nil && sha != "" {
+		return sha, RefCommit, nil
+	}
+	return "", 0, fmt.Errorf("ref not found: %s (tried branches, tags, and commits)", name)
+}
+
 // RenameBranch renames a local branch.
 func RenameBranch(oldName, newName string) error {
 	_, err := Run("branch", "-m", oldName, newName)
 	return err
 }
 
+// CreateTag creates a lightweight tag name pointing at ref.
+func CreateTag(name, ref string) error {
+	_, err := Run("tag", name, ref)
+	return err
+}
+
 // DeleteBranch force-deletes a local branch.
 func DeleteBranch(name string) error {
 	_, err := Run("branch", "-D", name)
@@ -78,6 +133,38 @@ func GetAheadBehind(remoteRef string) (AheadBehind, error) {
 	return GetAheadBehindIn("", remoteRef)
 }
 
+// CountCommits returns the number of commits reachable from head but not
+// from base (git rev-list --count base..head).
+func CountCommits(base, head string) (int, error) {
+	out, err := Run("rev-list", "--count", base+".."+head)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(strings.TrimSpace(out))
+}
+
+// IsMergedInto reports whether branch has no commits that aren't reachable
+// from ref — i.e. it's fully merged (or has no work of its own).
+func IsMergedInto(branch, ref string) (bool, error) {
+	n, err := CountCommits(ref, branch)
+	if err != nil {
+		return false, err
+	}
+	return n == 0, nil
+}
+
+// MergeBase returns the best common ancestor commit of a and b.
+func MergeBase(a, b string) (string, error) {
+	return Run("merge-base", a, b)
+}
+
+// ForkPoint returns the commit where branch forked from ref, consulting
+// ref's reflog so commits later rewritten out of ref still count
+// (git merge-base --fork-point).
+func ForkPoint(ref, branch string) (string, error) {
+	return Run("merge-base", "--fork-point", ref, branch)
+}
+
 // GetAheadBehindIn computes ahead/behind for a specific worktree.
 func GetAheadBehindIn(dir, remoteRef string) (AheadBehind, error) {
 	ab := AheadBehind{}
@@ -126,14 +213,39 @@ func WorktreeAge(dir string) string {
 	return formatRelativeAge(time.Since(t))
 }
 
-// WorktreeAgeDays returns the number of days since the most recent activity
-// in the worktree, or -1 if it can't be determined. Used for staleness checks.
+// RelativeAge formats the time since t like WorktreeAge ("3d", "2w").
+func RelativeAge(t time.Time) string {
+	return formatRelativeAge(time.Since(t))
+}
+
+// WorktreeAgeDays returns the number of calendar days since the most recent
+// activity in the worktree, or -1 if it can't be determined. Used for
+// staleness checks.
 func WorktreeAgeDays(dir string) int {
 	t, ok := worktreeActivity(dir)
 	if !ok {
 		return -1
 	}
-	return int(time.Since(t).Hours() / 24)
+	return calendarDaysBetween(t, time.Now())
+}
+
+// calendarDaysBetween returns the number of local calendar days from from to
+// to: 0 for the same day, 1 for yesterday, and so on. Counting dates rather
+// than dividing elapsed hours by 24 keeps the result stable across DST
+// transitions (23h/25h days) and independent of the time of day. Times in
+// the future (clock skew) count as 0, never negative — callers reserve -1
+// for "unknown".
+func calendarDaysBetween(from, to time.Time) int {
+	fy, fm, fd := from.In(to.Location()).Date()
+	ty, tm, td := to.Date()
+	// Dates at UTC midnight are exactly 24h apart, whatever the local zone.
+	start := time.Date(fy, fm, fd, 0, 0, 0, 0, time.UTC)
+	end := time.Date(ty, tm, td, 0, 0, 0, 0, time.UTC)
+	days := int(end.Sub(start).Hours() / 24)
+	if days < 0 {
+		return 0
+	}
+	return days
 }
 
 // worktreeActivity returns the most recent of (worktree creation, last commit).
@@ -1,6 +1,7 @@
 package git
 
 import (
+	"os/exec"
 	"testing"
 	"time"
 )
@@ -73,3 +74,80 @@ func TestParseReflogTime(t *testing.T) {
 		})
 	}
 }
+
+func TestCalendarDaysBetween(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("tz database unavailable: %v", err)
+	}
+	at := func(month time.Month, day, hour, min int) time.Time {
+		return time.Date(2026, month, day, hour, min, 0, 0, ny)
+	}
+	tests := []struct {
+		name     string
+		from, to time.Time
+		want     int
+	}{
+		{"same day", at(3, 1, 0, 5), at(3, 1, 23, 55), 0},
+		{"just past midnight is yesterday", at(3, 1, 23, 59), at(3, 2, 0, 1), 1},
+		{"a week across spring-forward (under 7×24h)", at(3, 7, 23, 30), at(3, 14, 23, 0), 7},
+		{"a week across fall-back (over 7×24h)", at(10, 31, 0, 30), at(11, 7, 0, 10), 7},
+		{"exactly at threshold boundary", at(3, 1, 12, 0), at(3, 8, 11, 59), 7},
+		{"future clamps to zero", at(3, 5, 0, 0), at(3, 1, 0, 0), 0},
+		{"other zone converted to local date", time.Date(2026, 3, 2, 3, 0, 0, 0, time.UTC), at(3, 2, 12, 0), 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := calendarDaysBetween(tt.from, tt.to); got != tt.want {
+				t.Errorf("calendarDaysBetween(%v, %v) = %d, want %d", tt.from, tt.to, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveRef(t *testing.T) {
+	repo := t.TempDir()
+	for _, args := range [][]string{
+		{"init", "-q", "-b", "main", repo},
+		{"-C", repo, "-c", "user.name=t", "-c", "user.email=t@example.com", "commit", "-q", "--allow-empty", "-m", "init"},
+		{"-C", repo, "tag", "v1.0.0"},
+		{"-C", repo, "tag", "-a", "-m", "annotated", "release/v2"},
+		{"-C", repo, "branch", "feat"},
+	} {
+		if out, err := exec.Command("git", args...).CombinedOutput(); err != nil {
+			t.Skipf("git %v failed: %v\n%s", args, err, out)
+		}
+	}
+	t.Chdir(repo)
+	head, err := Run("rev-parse", "HEAD")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		ref  string
+		kind RefKind
+	}{
+		{name: "feat", ref: "feat", kind: RefLocalBranch},
+		{name: "v1.0.0", ref: "refs/tags/v1.0.0", kind: RefTag},
+		{name: "release/v2", ref: "refs/tags/release/v2", kind: RefTag},
+		{name: "refs/tags/v1.0.0", ref: "refs/tags/v1.0.0", kind: RefTag},
+		{name: head[:7], ref: head, kind: RefCommit},
+		{name: "HEAD", ref: head, kind: RefCommit},
+	}
+	for _, tt := range tests {
+		ref, kind, err := ResolveRef(tt.name, "origin")
+		if err != nil {
+			t.Errorf("ResolveRef(%q) error: %v", tt.name, err)
+			continue
+		}
+		if ref != tt.ref || kind != tt.kind {
+			t.Errorf("ResolveRef(%q) = %q, %v; want %q, %v", tt.name, ref, kind, tt.ref, tt.kind)
+		}
+	}
+
+	if _, _, err := ResolveRef("nope", "origin"); err == nil {
+		t.Error("ResolveRef(\"nope\") succeeded, want error")
+	}
+}
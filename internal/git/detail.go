@@ -60,7 +60,9 @@ type DiffStats struct {
 }
 
 // DiffStatsIn returns commits-ahead and shortstat (files/additions/deletions)
-// for HEAD vs base. Empty range yields zeroed struct, not an error.
+// for HEAD vs base. The diff is taken from the merge base (base...HEAD), like
+// a PR's, so base moving on doesn't count against the branch. Empty range
+// yields zeroed struct, not an error.
 func DiffStatsIn(dir, base string) (DiffStats, error) {
 	stats := DiffStats{}
 
@@ -75,7 +77,7 @@ func DiffStatsIn(dir, base string) (DiffStats, error) {
 	}
 
 	// `git diff --shortstat` output: " 14 files changed, 247 insertions(+), 82 deletions(-)"
-	shortstat, err := RunIn(dir, "diff", "--shortstat", base+"..HEAD")
+	shortstat, err := RunIn(dir, "diff", "--shortstat", base+"...HEAD")
 	if err != nil {
 		return stats, err
 	}
@@ -197,9 +199,10 @@ func CommitDetailIn(dir, hash string) (CommitDetail, error) {
 	return d, nil
 }
 
-// DiffFilesIn returns per-file change status between base and HEAD.
+// DiffFilesIn returns per-file change status between the merge base of base
+// and HEAD, matching DiffStatsIn.
 func DiffFilesIn(dir, base string) ([]DiffFile, error) {
-	out, err := RunIn(dir, "diff", "--name-status", base+"..HEAD")
+	out, err := RunIn(dir, "diff", "--name-status", base+"...HEAD")
 	if err != nil {
 		return nil, err
 	}
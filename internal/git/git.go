@@ -1,17 +1,133 @@
 package git
 
 import (
+	"bufio"
 	"bytes"
+	"context"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/mvwi/wt/internal/trace"
+)
+
+// Timeouts for captured (non-passthrough) git commands, so a hung git — e.g.
+// a fetch stuck on credentials — fails cleanly instead of freezing wt. Set
+// from config by the cmd layer. Passthrough commands have no timeout: the
+// user is watching and may need to answer prompts.
+var (
+	LocalTimeout   = 10 * time.Second
+	NetworkTimeout = 30 * time.Second
 )
 
+// timeoutFor picks the timeout for a git subcommand. Transfers (fetch, push,
+// pull) get NetworkTimeout as an idle timeout: they can legitimately run for
+// minutes — a large fork fetch, a slow pre-push hook — so they're only killed
+// after that long without output. ls-remote is a quick query and gets it as
+// a hard limit. Commands that rewrite the work tree can run hooks of
+// arbitrary length, so they get none (0); read-only queries get LocalTimeout.
+func timeoutFor(args []string) (d time.Duration, idle bool) {
+	if len(args) == 0 {
+		return LocalTimeout, false
+	}
+	switch args[0] {
+	case "fetch", "push", "pull":
+		return NetworkTimeout, true
+	case "ls-remote":
+		return NetworkTimeout, false
+	case "worktree", "rebase", "merge", "stash", "checkout", "switch", "reset", "clean":
+		return 0, false
+	}
+	return LocalTimeout, false
+}
+
+// Logger traces every git command run (see --verbose and --debug). Set by
+// the cmd layer; nil logs nothing.
+var Logger *trace.Logger
+
+// deadline bounds one git command per timeoutFor. Output written through
+// watch counts as activity for an idle timeout.
+type deadline struct {
+	ctx     context.Context
+	cancel  context.CancelFunc
+	timeout time.Duration
+	idle    *time.Timer
+	expired atomic.Bool
+}
+
+func newDeadline(args []string) *deadline {
+	timeout, idle := timeoutFor(args)
+	d := &deadline{timeout: timeout}
+	switch {
+	case timeout <= 0:
+		d.ctx, d.cancel = context.WithCancel(context.Background())
+	case idle:
+		d.ctx, d.cancel = context.WithCancel(context.Background())
+		d.idle = time.AfterFunc(timeout, func() {
+			d.expired.Store(true)
+			d.cancel()
+		})
+	default:
+		d.ctx, d.cancel = context.WithTimeout(context.Background(), timeout)
+	}
+	return d
+}
+
+// watch returns w, teed so that writes restart an idle timeout.
+func (d *deadline) watch(w io.Writer) io.Writer {
+	if d.idle == nil {
+		return w
+	}
+	return io.MultiWriter(w, idleWriter{d})
+}
+
+// timedOut reports whether the command was killed by its timeout.
+func (d *deadline) timedOut() bool {
+	return d.expired.Load() || errors.Is(d.ctx.Err(), context.DeadlineExceeded)
+}
+
+func (d *deadline) stop() {
+	if d.idle != nil {
+		d.idle.Stop()
+	}
+	d.cancel()
+}
+
+// err builds the error for a git command killed by its timeout.
+func (d *deadline) err(args []string) error {
+	if d.idle != nil {
+		return fmt.Errorf("git %s: no output for %s", strings.Join(args, " "), d.timeout)
+	}
+	return fmt.Errorf("git %s: timed out after %s", strings.Join(args, " "), d.timeout)
+}
+
+// touch records activity, restarting an idle timeout.
+func (d *deadline) touch() {
+	if d.idle != nil {
+		d.idle.Reset(d.timeout)
+	}
+}
+
+type idleWriter struct{ d *deadline }
+
+func (w idleWriter) Write(p []byte) (int, error) {
+	w.d.touch()
+	return len(p), nil
+}
+
 // gitCmd creates a git command with LC_ALL=C to ensure English output for parsing.
-func gitCmd(args ...string) *exec.Cmd {
-	cmd := exec.Command("git", args...)
+// The command is killed when ctx is done.
+func gitCmd(ctx context.Context, args ...string) *exec.Cmd {
+	cmd := exec.CommandContext(ctx, "git", args...)
 	cmd.Env = append(os.Environ(), "LC_ALL=C")
+	// Don't wait forever on grandchildren (ssh, credential helpers) that
+	// still hold our pipes after git itself is killed.
+	cmd.WaitDelay = time.Second
 	return cmd
 }
 
@@ -23,17 +139,66 @@ func Run(args ...string) (string, error) {
 
 // RunIn executes a git command in a specific directory.
 func RunIn(dir string, args ...string) (string, error) {
-	cmd := gitCmd(args...)
+	return runGit(dir, args...)
+}
+
+// RunCombined is Run for commands that report failures on stdout too, like
+// merge and rebase printing CONFLICT lines: the error includes both.
+func RunCombined(args ...string) (string, error) {
+	return RunCombinedIn("", args...)
+}
+
+// RunCombinedIn is RunCombined in a specific directory.
+func RunCombinedIn(dir string, args ...string) (string, error) {
+	return runGitCombined(dir, args...)
+}
+
+// runGit, runGitCombined, and runGitSilent run every captured (Run/RunIn,
+// RunCombined/RunCombinedIn) and silent (RunSilent/RunSilentIn) git
+// command, so tests can swap them out to check the exact args a helper
+// builds without running git. Passthrough and streaming commands always
+// run git.
+var (
+	runGit         = execGit
+	runGitCombined = execGitCombined
+	runGitSilent   = execGitSilent
+)
+
+// execGit runs git in dir, returning trimmed stdout or an error with stderr.
+func execGit(dir string, args ...string) (string, error) {
+	return execCaptured(dir, false, args)
+}
+
+// execGitCombined is execGit with stdout in the error as well.
+func execGitCombined(dir string, args ...string) (string, error) {
+	return execCaptured(dir, true, args)
+}
+
+// execCaptured runs git in dir, capturing its output. On failure the error
+// has stderr, plus stdout when withStdout is set.
+func execCaptured(dir string, withStdout bool, args []string) (string, error) {
+	dl := newDeadline(args)
+	defer dl.stop()
+	cmd := gitCmd(dl.ctx, args...)
 	if dir != "" {
 		cmd.Dir = dir
 	}
 
 	var stdout, stderr bytes.Buffer
-	cmd.Stdout = &stdout
-	cmd.Stderr = &stderr
+	cmd.Stdout = dl.watch(&stdout)
+	cmd.Stderr = dl.watch(&stderr)
 
-	if err := cmd.Run(); err != nil {
+	call := Logger.Start("git", dir, args)
+	err := cmd.Run()
+	call.Done(err, stderr.String())
+	if err != nil {
+		if dl.timedOut() {
+			return "", dl.err(args)
+		}
 		errMsg := strings.TrimSpace(stderr.String())
+		if withStdout {
+			errMsg = combinedErrMsg(stderr.String(), stdout.String())
+		}
 		if errMsg == "" {
 			errMsg = err.Error()
 		}
@@ -43,6 +208,30 @@ func RunIn(dir string, args ...string) (string, error) {
 	return strings.TrimSpace(stdout.String()), nil
 }
 
+// combinedErrMsg merges a failed command's stderr and stdout into one
+// message, one line per line of output. Progress (Rebasing (1/3)), advice
+// (hint:) and Auto-merging chatter are dropped, as are lines repeating an earlier
+// one (rebase says "could not apply" twice).
+func combinedErrMsg(stderr, stdout string) string {
+	var lines []string
+	seen := make(map[string]bool)
+	for _, out := range []string{stderr, stdout} {
+		for _, line := range strings.FieldsFunc(out, func(r rune) bool { return r == '\n' || r == '\r' }) {
+			line = strings.TrimSpace(line)
+			if line == "" || strings.HasPrefix(line, "hint:") || strings.HasPrefix(line, "Auto-merging ") || strings.HasPrefix(line, "Rebasing (") {
+				continue
+			}
+			key := strings.ToLower(strings.TrimPrefix(line, "error: "))
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
 // RunPassthrough executes a git command with stdout/stderr connected to the terminal.
 // Used for commands where the user needs to see real-time output (rebase, push, etc.).
 func RunPassthrough(args ...string) error {
@@ -51,32 +240,110 @@ func RunPassthrough(args ...string) error {
 
 // RunPassthroughIn executes a git command in a directory with passthrough I/O.
 func RunPassthroughIn(dir string, args ...string) error {
-	cmd := gitCmd(args...)
+	cmd := gitCmd(context.Background(), args...)
 	if dir != "" {
 		cmd.Dir = dir
 	}
 	cmd.Stdin = os.Stdin
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
-	return cmd.Run()
+	call := Logger.Start("git", dir, args)
+	err := cmd.Run()
+	call.Done(err, "")
+	return err
 }
 
 // RunSilent executes a git command and discards all output.
 // Returns only whether it succeeded.
 func RunSilent(args ...string) error {
-	cmd := gitCmd(args...)
-	cmd.Stdout = nil
-	cmd.Stderr = nil
-	return cmd.Run()
+	return RunSilentIn("", args...)
 }
 
 // RunSilentIn executes a git command in a directory silently.
 func RunSilentIn(dir string, args ...string) error {
-	cmd := gitCmd(args...)
+	return runGitSilent(dir, args...)
+}
+
+// execGitSilent runs git in dir, discarding its output.
+func execGitSilent(dir string, args ...string) error {
+	dl := newDeadline(args)
+	defer dl.stop()
+	cmd := gitCmd(dl.ctx, args...)
 	if dir != "" {
 		cmd.Dir = dir
 	}
-	cmd.Stdout = nil
-	cmd.Stderr = nil
-	return cmd.Run()
+	// Stderr is only kept for the Logger, and to restart an idle timeout.
+	var stderr bytes.Buffer
+	if Logger != nil {
+		cmd.Stderr = dl.watch(&stderr)
+	} else {
+		cmd.Stderr = dl.watch(io.Discard)
+	}
+	call := Logger.Start("git", dir, args)
+	err := cmd.Run()
+	call.Done(err, stderr.String())
+	if err != nil {
+		if dl.timedOut() {
+			return dl.err(args)
+		}
+		return err
+	}
+	return nil
+}
+
+// RunStderrLines executes a git command, calling onLine for each line git
+// writes to stderr as it arrives. Progress meters redraw with carriage
+// returns, so "\r" ends a line too. Stdout is discarded; on failure the
+// error includes the last stderr line.
+func RunStderrLines(onLine func(string), args ...string) error {
+	dl := newDeadline(args)
+	defer dl.stop()
+	cmd := gitCmd(dl.ctx, args...)
+
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		return err
+	}
+	call := Logger.Start("git", "", args)
+	if err := cmd.Start(); err != nil {
+		call.Done(err, "")
+		return err
+	}
+
+	var last string
+	scanner := bufio.NewScanner(stderr)
+	scanner.Split(scanLinesCR)
+	for scanner.Scan() {
+		dl.touch()
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		last = line
+		onLine(line)
+	}
+
+	err = cmd.Wait()
+	call.Done(err, last)
+	if err != nil {
+		if dl.timedOut() {
+			return dl.err(args)
+		}
+		if last == "" {
+			last = err.Error()
+		}
+		return fmt.Errorf("git %s: %s", strings.Join(args, " "), last)
+	}
+	return nil
+}
+
+// scanLinesCR is bufio.ScanLines, but also splitting on "\r".
+func scanLinesCR(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	if i := bytes.IndexAny(data, "\r\n"); i >= 0 {
+		return i + 1, data[:i], nil
+	}
+	if atEOF && len(data) > 0 {
+		return len(data), data, nil
+	}
+	return 0, nil, nil
 }
@@ -0,0 +1,267 @@
+package git
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mvwi/wt/internal/trace"
+)
+
+// gitCall is one git command a stubbed helper ran.
+type gitCall struct {
+	Dir  string
+	Args string // space-joined
+}
+
+// stubGit serves every captured, combined, and silent git command from respond
+// instead of running git, for the rest of the test. Silent commands only
+// see respond's error. It returns the calls made so far.
+func stubGit(t *testing.T, respond func(args string) (string, error)) func() []gitCall {
+	t.Helper()
+	var calls []gitCall
+	origRun, origCombined, origSilent := runGit, runGitCombined, runGitSilent
+	runGit = func(dir string, args ...string) (string, error) {
+		calls = append(calls, gitCall{Dir: dir, Args: strings.Join(args, " ")})
+		return respond(strings.Join(args, " "))
+	}
+	runGitCombined = runGit
+	runGitSilent = func(dir string, args ...string) error {
+		_, err := runGit(dir, args...)
+		return err
+	}
+	t.Cleanup(func() { runGit, runGitCombined, runGitSilent = origRun, origCombined, origSilent })
+	return func() []gitCall { return calls }
+}
+
+func TestTimeoutFor(t *testing.T) {
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"fetch", "origin"}, "idle"},
+		{[]string{"push", "origin", "--delete", "x"}, "idle"},
+		{[]string{"ls-remote", "origin"}, "network"},
+		{[]string{"worktree", "add", "/tmp/x", "main"}, "none"},
+		{[]string{"rebase", "origin/main"}, "none"},
+		{[]string{"clean", "-fdx"}, "none"},
+		{[]string{"rev-parse", "HEAD"}, "local"},
+		{nil, "local"},
+	}
+	for _, tt := range tests {
+		var got string
+		switch d, idle := timeoutFor(tt.args); {
+		case idle && d == NetworkTimeout:
+			got = "idle"
+		case d == NetworkTimeout:
+			got = "network"
+		case d == LocalTimeout:
+			got = "local"
+		case d == 0:
+			got = "none"
+		}
+		if got != tt.want {
+			t.Errorf("timeoutFor(%v) = %s, want %s", tt.args, got, tt.want)
+		}
+	}
+}
+
+func TestIdleTimeout(t *testing.T) {
+	orig := NetworkTimeout
+	NetworkTimeout = 50 * time.Millisecond
+	t.Cleanup(func() { NetworkTimeout = orig })
+
+	dl := newDeadline([]string{"fetch", "origin"})
+	defer dl.stop()
+	w := dl.watch(io.Discard)
+	for range 6 {
+		time.Sleep(20 * time.Millisecond)
+		_, _ = w.Write([]byte("Receiving objects\r"))
+	}
+	if dl.timedOut() {
+		t.Fatal("timed out despite steady output")
+	}
+
+	select {
+	case <-dl.ctx.Done():
+	case <-time.After(time.Second):
+		t.Fatal("not cancelled after going idle")
+	}
+	if !dl.timedOut() {
+		t.Error("timedOut() = false after going idle")
+	}
+	if err := dl.err([]string{"fetch", "origin"}); !strings.Contains(err.Error(), "no output for 50ms") {
+		t.Errorf("err = %v", err)
+	}
+}
+
+func TestLogger(t *testing.T) {
+	var buf bytes.Buffer
+	Logger = &trace.Logger{Verbose: &buf}
+	t.Cleanup(func() { Logger = nil })
+
+	dir := t.TempDir()
+	if _, err := RunIn(dir, "rev-parse", "--verify", "no-such-ref"); err == nil {
+		t.Skip("expected git to fail outside a repo")
+	}
+	logged := buf.String()
+	for _, want := range []string{
+		"+ git -C " + dir + " rev-parse --verify no-such-ref\n",
+		"! git rev-parse --verify no-such-ref: exit status",
+		"\n    fatal: ",
+	} {
+		if !strings.Contains(logged, want) {
+			t.Errorf("log missing %q:\n%s", want, logged)
+		}
+	}
+
+	buf.Reset()
+	Logger = nil
+	_ = RunSilentIn(dir, "rev-parse", "--verify", "no-such-ref")
+	if buf.Len() != 0 {
+		t.Errorf("logged with no Logger: %q", buf.String())
+	}
+}
+
+func TestGetAheadBehindInArgs(t *testing.T) {
+	calls := stubGit(t, func(args string) (string, error) {
+		switch args {
+		case "rev-list --count HEAD..origin/feat":
+			return "3", nil
+		case "rev-list --count origin/feat..HEAD":
+			return "1", nil
+		}
+		return "", fmt.Errorf("unexpected git %s", args)
+	})
+	ab, err := GetAheadBehindIn("/wt/feat", "origin/feat")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ab.Ahead != 1 || ab.Behind != 3 {
+		t.Errorf("got %+v, want ahead 1, behind 3", ab)
+	}
+	for _, c := range calls() {
+		if c.Dir != "/wt/feat" {
+			t.Errorf("git %s ran in %q, want /wt/feat", c.Args, c.Dir)
+		}
+	}
+
+	stubGit(t, func(string) (string, error) { return "lots", nil })
+	if _, err := GetAheadBehindIn("", "origin/feat"); err == nil {
+		t.Error("GetAheadBehindIn accepted a non-numeric count")
+	}
+}
+
+func TestPotentialConflictsArgs(t *testing.T) {
+	calls := stubGit(t, func(args string) (string, error) {
+		switch args {
+		case "diff --name-only HEAD...origin/main":
+			return "go.mod\nREADME.md\ninternal/cmd/new.go", nil
+		case "diff --name-only origin/main...HEAD":
+			return "internal/cmd/new.go\ninternal/cmd/new_test.go\nREADME.md", nil
+		}
+		return "", fmt.Errorf("unexpected git %s", args)
+	})
+	got, err := PotentialConflicts("origin/main")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"internal/cmd/new.go", "README.md"}; !slices.Equal(got, want) {
+		t.Errorf("PotentialConflicts() = %v, want %v", got, want)
+	}
+	if n := len(calls()); n != 2 {
+		t.Errorf("ran %d git commands, want 2", n)
+	}
+}
+
+func TestResolveRefOrder(t *testing.T) {
+	tests := []struct {
+		name   string
+		exists []string // refs show-ref and rev-parse succeed for
+		ref    string
+		kind   RefKind
+	}{
+		{name: "feat", exists: []string{"refs/heads/feat", "refs/remotes/origin/feat"}, ref: "feat", kind: RefLocalBranch},
+		{name: "origin/feat", exists: []string{"refs/remotes/origin/feat"}, ref: "origin/feat", kind: RefRemoteBranch},
+		{name: "feat", exists: []string{"refs/remotes/origin/feat", "refs/tags/feat"}, ref: "origin/feat", kind: RefRemoteBranch},
+		{name: "v1", exists: []string{"refs/tags/v1", "v1^{commit}"}, ref: "refs/tags/v1", kind: RefTag},
+		{name: "abc1234", exists: []string{"abc1234^{commit}"}, ref: "abc1234def", kind: RefCommit},
+	}
+	for _, tt := range tests {
+		stubGit(t, func(args string) (string, error) {
+			for _, ref := range tt.exists {
+				if strings.HasSuffix(args, " "+ref) {
+					if strings.HasPrefix(args, "rev-parse") {
+						return "abc1234def", nil
+					}
+					return "", nil
+				}
+			}
+			return "", fmt.Errorf("git %s: exit status 1", args)
+		})
+		ref, kind, err := ResolveRef(tt.name, "origin")
+		if err != nil || ref != tt.ref || kind != tt.kind {
+			t.Errorf("ResolveRef(%q) with %v = %q, %v, %v; want %q, %v", tt.name, tt.exists, ref, kind, err, tt.ref, tt.kind)
+		}
+	}
+}
+
+func TestCombinedErrMsg(t *testing.T) {
+	stderr := "Rebasing (1/1)\rerror: could not apply 470f090... c\n" +
+		"hint: Resolve all conflicts manually, mark them as resolved with\n" +
+		"hint: \"git add/rm <conflicted_files>\", then run \"git rebase --continue\".\n" +
+		"Could not apply 470f090... c\n"
+	stdout := "Auto-merging f\nCONFLICT (add/add): Merge conflict in f\n"
+	want := "error: could not apply 470f090... c\nCONFLICT (add/add): Merge conflict in f"
+	if got := combinedErrMsg(stderr, stdout); got != want {
+		t.Errorf("combinedErrMsg() = %q, want %q", got, want)
+	}
+	if got := combinedErrMsg("", ""); got != "" {
+		t.Errorf("combinedErrMsg(empty) = %q, want \"\"", got)
+	}
+}
+
+func TestRunCombinedIncludesStdout(t *testing.T) {
+	dir := t.TempDir()
+	commit := []string{"-c", "user.name=t", "-c", "user.email=t@example.com", "commit", "-q", "-m"}
+	for _, args := range [][]string{
+		{"init", "-q", "-b", "main"},
+		append(append([]string{}, commit...), "a", "--allow-empty"),
+		{"checkout", "-q", "-b", "other"},
+	} {
+		if _, err := RunIn(dir, args...); err != nil {
+			t.Skipf("git %v failed: %v", args, err)
+		}
+	}
+	// The same file added on both branches conflicts on rebase.
+	for _, branch := range []string{"other", "main"} {
+		if _, err := RunIn(dir, "checkout", "-q", branch); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, "f"), []byte(branch+"\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := RunIn(dir, "add", "f"); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := RunIn(dir, append(append([]string{}, commit...), branch)...); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	err := MergeFFIn(dir, "other")
+	if err == nil || !strings.Contains(err.Error(), "Not possible to fast-forward") {
+		t.Errorf("MergeFFIn() = %v, want a fast-forward error", err)
+	}
+	err = RebaseIn(dir, "other")
+	RebaseAbortIn(dir)
+	if err == nil || !strings.Contains(err.Error(), "CONFLICT") || !strings.Contains(err.Error(), "Merge conflict in f") {
+		t.Errorf("RebaseIn() = %v, want the CONFLICT line from stdout", err)
+	}
+}
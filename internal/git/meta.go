@@ -0,0 +1,230 @@
+package git
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+const (
+	metaFileName    = "wt-meta.json"
+	archiveFileName = "wt-archive.json"
+)
+
+// WorktreeMeta is durable per-worktree metadata that git itself doesn't
+// record: where a worktree came from and what it's stacked on.
+type WorktreeMeta struct {
+	Parent    string    `json:"parent,omitempty"` // stack parent branch
+	Base      string    `json:"base,omitempty"`   // ref a new branch was created from
+	From      string    `json:"from,omitempty"`   // existing branch checked out (wt new --from)
+	PR        int       `json:"pr,omitempty"`     // PR checked out (wt pr, wt new --from #123)
+	Fork      bool      `json:"fork,omitempty"`   // that PR's head lives in a fork
+	CreatedAt time.Time `json:"created_at,omitempty"`
+	// Init maps each wt init command that succeeded to a hash of its inputs
+	// (the worktree's lockfiles) at the time, so unchanged ones can be skipped.
+	Init map[string]string `json:"init,omitempty"`
+}
+
+// metaFile returns the path of a metadata store. Stores live in the common
+// git dir, shared by all worktrees, rather than a per-worktree git dir.
+func metaFile(name string) (string, error) {
+	dir, err := CommonDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, name), nil
+}
+
+// loadStore reads the JSON store name into a map keyed by string. A missing
+// file is an empty store.
+func loadStore[V any](name string) (map[string]V, string, error) {
+	path, err := metaFile(name)
+	if err != nil {
+		return nil, "", err
+	}
+	store := make(map[string]V)
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return store, path, nil
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	if err := json.Unmarshal(data, &store); err != nil {
+		return nil, "", err
+	}
+	return store, path, nil
+}
+
+func loadMetaStore() (map[string]WorktreeMeta, string, error) {
+	return loadStore[WorktreeMeta](metaFileName)
+}
+
+func saveStore[V any](path string, store map[string]V) error {
+	data, err := json.MarshalIndent(store, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0600)
+}
+
+// LoadWorktreeMeta returns the metadata for the worktree at wtPath. A
+// worktree with nothing recorded gets the zero value and no error.
+func LoadWorktreeMeta(wtPath string) (WorktreeMeta, error) {
+	store, _, err := loadMetaStore()
+	if err != nil {
+		return WorktreeMeta{}, err
+	}
+	return store[filepath.Clean(wtPath)], nil
+}
+
+// SaveWorktreeMeta records metadata for the worktree at wtPath, replacing
+// any previous entry. CreatedAt is filled in if unset.
+func SaveWorktreeMeta(wtPath string, meta WorktreeMeta) error {
+	store, path, err := loadMetaStore()
+	if err != nil {
+		return err
+	}
+	if meta.CreatedAt.IsZero() {
+		meta.CreatedAt = time.Now().UTC().Truncate(time.Second)
+	}
+	store[filepath.Clean(wtPath)] = meta
+	return saveStore(path, store)
+}
+
+// MoveWorktreeMeta re-keys a worktree's metadata after it moves on disk.
+func MoveWorktreeMeta(oldPath, newPath string) error {
+	store, path, err := loadMetaStore()
+	if err != nil {
+		return err
+	}
+	meta, ok := store[filepath.Clean(oldPath)]
+	if !ok {
+		return nil
+	}
+	delete(store, filepath.Clean(oldPath))
+	store[filepath.Clean(newPath)] = meta
+	return saveStore(path, store)
+}
+
+// DeleteWorktreeMeta drops the metadata for a removed worktree.
+func DeleteWorktreeMeta(wtPath string) error {
+	store, path, err := loadMetaStore()
+	if err != nil {
+		return err
+	}
+	if _, ok := store[filepath.Clean(wtPath)]; !ok {
+		return nil
+	}
+	delete(store, filepath.Clean(wtPath))
+	return saveStore(path, store)
+}
+
+// ReparentWorktreeMeta points worktrees stacked on oldParent at newParent,
+// after a branch rename.
+func ReparentWorktreeMeta(oldParent, newParent string) error {
+	store, path, err := loadMetaStore()
+	if err != nil {
+		return err
+	}
+	changed := false
+	for k, meta := range store {
+		if meta.Parent == oldParent {
+			meta.Parent = newParent
+			store[k] = meta
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+	return saveStore(path, store)
+}
+
+// ArchivedBranch records a branch whose worktree was removed by wt archive
+// while the branch itself was kept.
+type ArchivedBranch struct {
+	Branch     string       `json:"branch"`
+	Tag        string       `json:"tag,omitempty"`  // archive/<branch>, if tagged
+	Path       string       `json:"path,omitempty"` // where the worktree lived
+	ArchivedAt time.Time    `json:"archived_at"`
+	Meta       WorktreeMeta `json:"meta"` // the worktree's metadata at archive time
+}
+
+// ListArchivedBranches returns archived branches, most recently archived
+// first.
+func ListArchivedBranches() ([]ArchivedBranch, error) {
+	store, _, err := loadStore[ArchivedBranch](archiveFileName)
+	if err != nil {
+		return nil, err
+	}
+	archived := make([]ArchivedBranch, 0, len(store))
+	for _, a := range store {
+		archived = append(archived, a)
+	}
+	sort.Slice(archived, func(i, j int) bool {
+		return archived[i].ArchivedAt.After(archived[j].ArchivedAt)
+	})
+	return archived, nil
+}
+
+// SaveArchivedBranch records an archived branch, replacing any earlier
+// record for the same branch. ArchivedAt is filled in if unset.
+func SaveArchivedBranch(a ArchivedBranch) error {
+	store, path, err := loadStore[ArchivedBranch](archiveFileName)
+	if err != nil {
+		return err
+	}
+	if a.ArchivedAt.IsZero() {
+		a.ArchivedAt = time.Now().UTC().Truncate(time.Second)
+	}
+	store[a.Branch] = a
+	return saveStore(path, store)
+}
+
+// DeleteArchivedBranch forgets an archived branch.
+func DeleteArchivedBranch(branch string) error {
+	store, path, err := loadStore[ArchivedBranch](archiveFileName)
+	if err != nil {
+		return err
+	}
+	if _, ok := store[branch]; !ok {
+		return nil
+	}
+	delete(store, branch)
+	return saveStore(path, store)
+}
+
+// LoadCache reads the JSON cache file name, in the common git dir, into v.
+// Returns false if it's missing, unreadable, or older than maxAge.
+func LoadCache(name string, maxAge time.Duration, v any) bool {
+	path, err := metaFile(name)
+	if err != nil {
+		return false
+	}
+	info, err := os.Stat(path)
+	if err != nil || time.Since(info.ModTime()) > maxAge {
+		return false
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return false
+	}
+	return json.Unmarshal(data, v) == nil
+}
+
+// SaveCache writes v to the JSON cache file name in the common git dir.
+func SaveCache(name string, v any) error {
+	path, err := metaFile(name)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0600)
+}
@@ -0,0 +1,103 @@
+package git
+
+import (
+	"os/exec"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestWorktreeMetaStore(t *testing.T) {
+	dir := t.TempDir()
+	if err := exec.Command("git", "init", "-q", dir).Run(); err != nil {
+		t.Skipf("git init failed: %v", err)
+	}
+	t.Chdir(dir)
+
+	if meta, err := LoadWorktreeMeta("/wt/a"); err != nil || !reflect.DeepEqual(meta, WorktreeMeta{}) {
+		t.Fatalf("LoadWorktreeMeta on empty store = %+v, %v; want zero value", meta, err)
+	}
+
+	if err := SaveWorktreeMeta("/wt/a", WorktreeMeta{Parent: "michael/base", PR: 42}); err != nil {
+		t.Fatal(err)
+	}
+	meta, err := LoadWorktreeMeta("/wt/a/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if meta.Parent != "michael/base" || meta.PR != 42 || meta.CreatedAt.IsZero() {
+		t.Errorf("LoadWorktreeMeta() = %+v", meta)
+	}
+
+	if err := MoveWorktreeMeta("/wt/a", "/wt/b"); err != nil {
+		t.Fatal(err)
+	}
+	if meta, _ := LoadWorktreeMeta("/wt/a"); meta.PR != 0 {
+		t.Errorf("old path still has metadata after move: %+v", meta)
+	}
+	if meta, _ := LoadWorktreeMeta("/wt/b"); meta.PR != 42 {
+		t.Errorf("new path missing metadata after move: %+v", meta)
+	}
+
+	if err := DeleteWorktreeMeta("/wt/b"); err != nil {
+		t.Fatal(err)
+	}
+	if meta, _ := LoadWorktreeMeta("/wt/b"); meta.PR != 0 {
+		t.Errorf("metadata survived delete: %+v", meta)
+	}
+}
+
+func TestArchivedBranchStore(t *testing.T) {
+	dir := t.TempDir()
+	if err := exec.Command("git", "init", "-q", dir).Run(); err != nil {
+		t.Skipf("git init failed: %v", err)
+	}
+	t.Chdir(dir)
+
+	if err := SaveArchivedBranch(ArchivedBranch{Branch: "michael/old", ArchivedAt: time.Unix(100, 0)}); err != nil {
+		t.Fatal(err)
+	}
+	if err := SaveArchivedBranch(ArchivedBranch{Branch: "michael/new", Tag: "archive/michael/new", Meta: WorktreeMeta{PR: 7}}); err != nil {
+		t.Fatal(err)
+	}
+
+	archived, err := ListArchivedBranches()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(archived) != 2 || archived[0].Branch != "michael/new" || archived[1].Branch != "michael/old" {
+		t.Fatalf("ListArchivedBranches() = %+v; want michael/new then michael/old", archived)
+	}
+	if archived[0].Meta.PR != 7 || archived[0].ArchivedAt.IsZero() {
+		t.Errorf("archived[0] = %+v", archived[0])
+	}
+
+	if err := DeleteArchivedBranch("michael/old"); err != nil {
+		t.Fatal(err)
+	}
+	if archived, _ := ListArchivedBranches(); len(archived) != 1 {
+		t.Errorf("after delete, ListArchivedBranches() = %+v", archived)
+	}
+}
+
+func TestCache(t *testing.T) {
+	dir := t.TempDir()
+	if err := exec.Command("git", "init", "-q", dir).Run(); err != nil {
+		t.Skipf("git init failed: %v", err)
+	}
+	t.Chdir(dir)
+
+	var got []int
+	if LoadCache("wt-test-cache.json", time.Hour, &got) {
+		t.Error("LoadCache() hit before anything was saved")
+	}
+	if err := SaveCache("wt-test-cache.json", []int{1, 2}); err != nil {
+		t.Fatal(err)
+	}
+	if !LoadCache("wt-test-cache.json", time.Hour, &got) || len(got) != 2 {
+		t.Errorf("LoadCache() = %v, want [1 2]", got)
+	}
+	if LoadCache("wt-test-cache.json", -time.Second, &got) {
+		t.Error("LoadCache() hit on a stale cache")
+	}
+}
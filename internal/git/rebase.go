@@ -11,9 +11,17 @@ func Rebase(onto string) error {
 	return RunPassthrough("rebase", onto)
 }
 
-// RebaseIn runs rebase in a specific directory silently. Returns error on conflict.
+// RebaseOnto runs `git rebase --onto <onto> <upstream>` with passthrough
+// output, replaying only the commits after upstream.
+func RebaseOnto(onto, upstream string) error {
+	return RunPassthrough("rebase", "--onto", onto, upstream)
+}
+
+// RebaseIn runs rebase in a specific directory silently. Returns error on
+// conflict, naming the commit that didn't apply and the conflicting files.
 func RebaseIn(dir, onto string) error {
-	return RunSilentIn(dir, "rebase", onto)
+	_, err := RunCombinedIn(dir, "rebase", onto)
+	return err
 }
 
 // RebaseContinue continues an in-progress rebase.
@@ -42,12 +50,13 @@ func IsRebaseInProgress() (bool, error) {
 
 // MergeFF does a fast-forward merge.
 func MergeFF(ref string) (string, error) {
-	return Run("merge", "--ff-only", ref)
+	return RunCombined("merge", "--ff-only", ref)
 }
 
 // MergeFFIn does a fast-forward merge in a specific directory.
 func MergeFFIn(dir, ref string) error {
-	return RunSilentIn(dir, "merge", "--ff-only", ref)
+	_, err := RunCombinedIn(dir, "merge", "--ff-only", ref)
+	return err
 }
 
 // Push pushes with force-with-lease.
@@ -60,34 +69,81 @@ func PushSetUpstream(remote string) error {
 	return RunPassthrough("push", "-u", remote, "HEAD")
 }
 
+// PushNewBranch publishes a local branch to remote under the same name and
+// sets it as the branch's upstream. Works from any worktree.
+func PushNewBranch(remote, branch string) error {
+	_, err := Run("push", "-u", remote, branch)
+	return err
+}
+
+// CommitEmptyIn records a commit with no changes in dir, e.g. so a fresh
+// branch has something to open a PR for.
+func CommitEmptyIn(dir, message string) error {
+	_, err := RunIn(dir, "commit", "--allow-empty", "--no-verify", "-m", message)
+	return err
+}
+
 // FetchPrune fetches and prunes dead remote refs.
 func FetchPrune() {
-	_ = RunSilent("fetch", "--prune")
+	_ = RunSilent("fetch", "--progress", "--prune")
 }
 
-// SaveStateFile writes the rebase state to a file in the git dir.
+// State files come in two scopes:
+//   - per-worktree (SaveStateFile etc.), in the worktree's own GitDir: state
+//     that belongs to one checkout, like wt rebase's stash/pre-rebase ref.
+//   - shared (SaveSharedStateFile etc.), in CommonDir: state every worktree
+//     should see, like wt switch -'s previous worktree.
+
+// SaveStateFile writes a per-worktree state file to the git dir.
 func SaveStateFile(name, content string) error {
 	gitDir, err := GitDir()
 	if err != nil {
 		return err
 	}
-	return os.WriteFile(filepath.Join(gitDir, name), []byte(content), 0600)
+	return writeStateFile(gitDir, name, content)
 }
 
-// ReadStateFile reads a state file from the git dir.
+// ReadStateFile reads a per-worktree state file from the git dir.
 func ReadStateFile(name string) (string, error) {
 	gitDir, err := GitDir()
 	if err != nil {
 		return "", err
 	}
-	data, err := os.ReadFile(filepath.Join(gitDir, name))
+	return readStateFile(gitDir, name)
+}
+
+// SaveSharedStateFile writes a state file to the common git dir, where it's
+// visible from every worktree of the repo.
+func SaveSharedStateFile(name, content string) error {
+	dir, err := CommonDir()
+	if err != nil {
+		return err
+	}
+	return writeStateFile(dir, name, content)
+}
+
+// ReadSharedStateFile reads a state file from the common git dir.
+func ReadSharedStateFile(name string) (string, error) {
+	dir, err := CommonDir()
+	if err != nil {
+		return "", err
+	}
+	return readStateFile(dir, name)
+}
+
+func writeStateFile(dir, name, content string) error {
+	return os.WriteFile(filepath.Join(dir, name), []byte(content), 0600)
+}
+
+func readStateFile(dir, name string) (string, error) {
+	data, err := os.ReadFile(filepath.Join(dir, name))
 	if err != nil {
 		return "", err
 	}
 	return string(data), nil
 }
 
-// RemoveStateFile deletes a state file. Errors are ignored since
+// RemoveStateFile deletes a per-worktree state file. Errors are ignored since
 // leftover state files in .git/ are harmless.
 func RemoveStateFile(name string) {
 	gitDir, err := GitDir()
@@ -134,7 +190,7 @@ func toSet(s string) map[string]bool {
 	return m
 }
 
-// StateFileExists checks if a state file exists.
+// StateFileExists checks if a per-worktree state file exists.
 func StateFileExists(name string) bool {
 	gitDir, err := GitDir()
 	if err != nil {
@@ -1,32 +1,128 @@
 package git
 
 import (
+	"fmt"
+	"net/url"
 	"os"
 	"path/filepath"
+	"regexp"
+	"sort"
 	"strings"
 )
 
 // RepoName returns the basename of the main worktree (the true repo name).
 // Uses MainWorktree() rather than --show-toplevel, which would return the
-// linked worktree's directory name when called from inside one.
+// linked worktree's directory name when called from inside one. In a bare
+// repo the name comes from the bare directory; see bareRepoName.
 func RepoName() (string, error) {
 	main, err := MainWorktree()
 	if err != nil {
 		return "", err
 	}
+	if IsBareRepo() {
+		return bareRepoName(main), nil
+	}
 	return filepath.Base(main), nil
 }
 
+// bareRepoName names a repo from its bare directory: "repo.git" → "repo",
+// and for the repo/.bare (or repo/.git) layout, the enclosing "repo".
+func bareRepoName(dir string) string {
+	name := strings.TrimSuffix(filepath.Base(dir), ".git")
+	if name == "" || name == ".bare" {
+		name = filepath.Base(filepath.Dir(dir))
+	}
+	return name
+}
+
+// IsBareRepo reports whether the repository is a bare clone whose
+// checkouts are all linked worktrees (the repo.git or repo/.bare layout).
+// It asks the common git dir, since --is-bare-repository is always false
+// from inside a linked worktree.
+func IsBareRepo() bool {
+	dir, err := CommonDir()
+	if err != nil {
+		return false
+	}
+	out, err := Run("--git-dir", dir, "rev-parse", "--is-bare-repository")
+	return err == nil && out == "true"
+}
+
 // TopLevel returns the absolute path to the repository root.
 func TopLevel() (string, error) {
 	return Run("rev-parse", "--show-toplevel")
 }
 
 // GitDir returns the .git directory path (handles worktrees where .git is a file).
+// For a linked worktree this is its private .git/worktrees/<name> dir.
 func GitDir() (string, error) {
 	return Run("rev-parse", "--git-dir")
 }
 
+// CommonDir returns the absolute path of the git dir shared by all of the
+// repo's worktrees — the main worktree's .git, even from a linked worktree.
+func CommonDir() (string, error) {
+	dir, err := Run("rev-parse", "--git-common-dir")
+	if err != nil {
+		return "", err
+	}
+	return filepath.Abs(dir)
+}
+
+// RemoteURL returns the URL configured for a remote (git remote get-url).
+func RemoteURL(remote string) (string, error) {
+	return Run("remote", "get-url", remote)
+}
+
+// RemoteDefaultBranch returns the branch the remote's HEAD points at (e.g.
+// "main"), as recorded by clone or `git remote set-head`.
+func RemoteDefaultBranch(remote string) (string, error) {
+	ref, err := Run("symbolic-ref", "--short", "refs/remotes/"+remote+"/HEAD")
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimPrefix(ref, remote+"/"), nil
+}
+
+// RemoteHost extracts the hostname from a git remote URL, lowercased.
+// Handles https://host/owner/repo, ssh://git@host:22/owner/repo, and the
+// scp-like git@host:owner/repo form. Returns "" for local paths.
+//
+//	"git@github.example.com:org/app.git" → "github.example.com"
+//	"https://github.com/mvwi/wt"         → "github.com"
+func RemoteHost(rawURL string) string {
+	if u, err := url.Parse(rawURL); err == nil && u.Scheme != "" && u.Host != "" {
+		return strings.ToLower(u.Hostname())
+	}
+	// scp-like: [user@]host:path — a colon before any slash
+	// (a single letter before the colon is a Windows drive, not a host).
+	colon := strings.Index(rawURL, ":")
+	if colon <= 1 || strings.Contains(rawURL[:colon], "/") {
+		return ""
+	}
+	host := rawURL[:colon]
+	if at := strings.LastIndex(host, "@"); at >= 0 {
+		host = host[at+1:]
+	}
+	return strings.ToLower(host)
+}
+
+// RemoteSlug extracts "owner/repo" from a git remote URL, in any of the
+// forms RemoteHost accepts. Returns "" for local paths.
+//
+//	"git@github.com:mvwi/wt.git" → "mvwi/wt"
+func RemoteSlug(rawURL string) string {
+	var path string
+	if u, err := url.Parse(rawURL); err == nil && u.Scheme != "" && u.Host != "" {
+		path = u.Path
+	} else if RemoteHost(rawURL) != "" {
+		path = rawURL[strings.Index(rawURL, ":")+1:]
+	} else {
+		return ""
+	}
+	return strings.Trim(strings.TrimSuffix(strings.Trim(path, "/"), ".git"), "/")
+}
+
 // Username returns the git user's first name, lowercased.
 // e.g., "Michael Williams" → "michael"
 func Username() (string, error) {
@@ -40,6 +136,36 @@ func Username() (string, error) {
 	return parts[0], nil
 }
 
+// UsernameFull returns the git user's full name, lowercased and hyphenated.
+// e.g., "Mary-Jane Watson" → "mary-jane-watson"
+func UsernameFull() (string, error) {
+	name, err := Run("config", "user.name")
+	if err != nil {
+		return "", err
+	}
+	return strings.Join(strings.Fields(strings.ToLower(name)), "-"), nil
+}
+
+// EmailUsername returns the local part of the git user's email, lowercased,
+// without any "+tag". e.g., "MJ.Watson+work@example.com" → "mj.watson"
+func EmailUsername() (string, error) {
+	email, err := Run("config", "user.email")
+	if err != nil {
+		return "", err
+	}
+	local, _, _ := strings.Cut(strings.ToLower(email), "@")
+	local, _, _ = strings.Cut(local, "+")
+	if local == "" {
+		return "", fmt.Errorf("user.email has no local part: %s", email)
+	}
+	return local, nil
+}
+
+// Version returns git's version string, e.g. "git version 2.44.0".
+func Version() (string, error) {
+	return Run("--version")
+}
+
 // IsInsideWorkTree returns true if the current directory is inside a git repo.
 func IsInsideWorkTree() bool {
 	err := RunSilent("rev-parse", "--is-inside-work-tree")
@@ -47,14 +173,107 @@ func IsInsideWorkTree() bool {
 }
 
 // Fetch fetches a specific ref from a remote.
+//
+// Silent fetches pass --progress so a long transfer keeps writing to stderr,
+// which holds off the idle NetworkTimeout; only a stalled fetch is killed.
 func Fetch(remote string, refs ...string) error {
-	args := append([]string{"fetch", remote}, refs...)
+	args := append([]string{"fetch", "--progress", remote}, refs...)
 	return RunSilent(args...)
 }
 
-// FetchAll fetches all refs from a remote.
-func FetchAll(remote string) error {
-	return RunSilent("fetch", remote)
+// FetchBranch fetches branch from remote into its remote-tracking ref
+// (<remote>/<branch>). Unlike Fetch, the explicit refspec creates the ref
+// even when the remote's configured fetch refspec doesn't cover the branch
+// — single-branch clones, or a base branch never checked out locally.
+func FetchBranch(remote, branch string) error {
+	return RunSilent("fetch", "--progress", remote, TrackingRefspec(remote, branch))
+}
+
+// TrackingRefspec returns the refspec that fetches branch from remote into
+// refs/remotes/<remote>/<branch>.
+func TrackingRefspec(remote, branch string) string {
+	return "+refs/heads/" + branch + ":refs/remotes/" + remote + "/" + branch
+}
+
+// FetchWithProgress runs git fetch with the given arguments (remote, refs,
+// flags), reporting progress as it goes: onProgress receives short strings
+// like "Receiving objects 45% (450/1000)". Use it where a spinner would
+// otherwise sit silent through a large fetch.
+func FetchWithProgress(onProgress func(string), args ...string) error {
+	fetchArgs := append([]string{"fetch", "--progress"}, args...)
+	return RunStderrLines(func(line string) {
+		if msg, ok := parseFetchProgress(line); ok {
+			onProgress(msg)
+		}
+	}, fetchArgs...)
+}
+
+// fetchProgressRe matches git's progress meter lines, e.g.
+// "remote: Counting objects:  50% (5/10)" or
+// "Receiving objects:  45% (450/1000), 1.20 MiB | 2.00 MiB/s".
+var fetchProgressRe = regexp.MustCompile(`^(?:remote: )?([A-Z][a-z]+ [a-z]+):\s+(\d+)% \((\d+/\d+)\)(?:, ([\d.]+ [KMG]iB))?`)
+
+// parseFetchProgress condenses one line of git fetch --progress output into
+// a spinner message. Lines that aren't progress meters report false.
+func parseFetchProgress(line string) (string, bool) {
+	m := fetchProgressRe.FindStringSubmatch(line)
+	if m == nil {
+		return "", false
+	}
+	msg := m[1] + " " + m[2] + "% (" + m[3] + ")"
+	if m[4] != "" {
+		msg += ", " + m[4]
+	}
+	return msg, true
+}
+
+// RemoteRefs returns every remote-tracking branch ("origin/main") mapped to
+// the commit it points at. Symbolic refs like origin/HEAD are skipped.
+func RemoteRefs() (map[string]string, error) {
+	out, err := Run("for-each-ref", "--format=%(refname:short) %(objectname) %(symref)", "refs/remotes/")
+	if err != nil {
+		return nil, err
+	}
+	refs := make(map[string]string)
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue // blank, or a symref (third field)
+		}
+		refs[fields[0]] = fields[1]
+	}
+	return refs, nil
+}
+
+// RefChanges describes how remote-tracking refs moved across a fetch.
+// Each list is sorted by ref name.
+type RefChanges struct {
+	Added   []string
+	Updated []string
+	Deleted []string
+}
+
+// DiffRefs compares two RemoteRefs snapshots.
+func DiffRefs(before, after map[string]string) RefChanges {
+	var c RefChanges
+	for ref, sha := range after {
+		old, ok := before[ref]
+		switch {
+		case !ok:
+			c.Added = append(c.Added, ref)
+		case old != sha:
+			c.Updated = append(c.Updated, ref)
+		}
+	}
+	for ref := range before {
+		if _, ok := after[ref]; !ok {
+			c.Deleted = append(c.Deleted, ref)
+		}
+	}
+	sort.Strings(c.Added)
+	sort.Strings(c.Updated)
+	sort.Strings(c.Deleted)
+	return c
 }
 
 // isDir returns true if the path exists and is a directory.
@@ -7,15 +7,40 @@ import (
 
 // FileChange represents a single file change from git status --porcelain.
 type FileChange struct {
-	Status string // Two-character status (e.g., "M ", " M", "??", "R ")
+	Status string // Two-character XY status (e.g., "M ", " M", "??", "R ")
 	Path   string
-	// For renames: original path
+	// For renames and copies: original path
 	OldPath string
 }
 
-// IsRename returns true if this change is a rename.
+// Staged returns the index half of the status: what's staged for commit
+// ('M', 'A', 'D', 'R', 'C', 'U'), or ' ' when nothing is. Untracked files
+// report '?'.
+func (f FileChange) Staged() byte {
+	if len(f.Status) < 2 {
+		return ' '
+	}
+	return f.Status[0]
+}
+
+// Unstaged returns the work-tree half of the status: changes not yet
+// staged ('M', 'D', ...), or ' ' when the work tree matches the index.
+// Untracked files report '?'.
+func (f FileChange) Unstaged() byte {
+	if len(f.Status) < 2 {
+		return ' '
+	}
+	return f.Status[1]
+}
+
+// IsUntracked returns true if the file isn't tracked by git.
+func (f FileChange) IsUntracked() bool {
+	return f.Status == "??"
+}
+
+// IsRename returns true if this change is a rename, staged or not.
 func (f FileChange) IsRename() bool {
-	return strings.HasPrefix(f.Status, "R")
+	return f.Staged() == 'R' || f.Unstaged() == 'R'
 }
 
 // HasChanges returns true if the working tree has uncommitted changes.
@@ -48,16 +73,70 @@ func StatusPorcelain() ([]FileChange, error) {
 
 // StatusPorcelainIn returns parsed file changes for a specific directory.
 // Uses -uall to list individual files inside untracked directories,
-// rather than collapsing them into a single directory entry.
+// rather than collapsing them into a single directory entry, and the
+// NUL-delimited v2 format so paths are never quoted or mis-split.
 func StatusPorcelainIn(dir string) ([]FileChange, error) {
-	out, err := RunIn(dir, "status", "--porcelain", "-uall")
+	out, err := RunIn(dir, "status", "--porcelain=v2", "-z", "-uall")
 	if err != nil {
 		return nil, err
 	}
-	return ParsePorcelainOutput(out), nil
+	return ParsePorcelainV2(out), nil
 }
 
-// ParsePorcelainOutput parses the output of `git status --porcelain` into FileChanges.
+// ParsePorcelainV2 parses the output of `git status --porcelain=v2 -z`
+// into FileChanges. Status is normalized to the v1 XY form ("." becomes
+// " ", untracked is "??", ignored is "!!"), so callers see one format.
+//
+// Record layouts (fields space-separated, records NUL-terminated):
+//
+//	1 XY sub mH mI mW hH hI path
+//	2 XY sub mH mI mW hH hI Xscore path<NUL>origPath
+//	u XY sub m1 m2 m3 mW h1 h2 h3 path
+//	? path
+//	! path
+func ParsePorcelainV2(out string) []FileChange {
+	records := strings.Split(out, "\x00")
+	var changes []FileChange
+	for i := 0; i < len(records); i++ {
+		rec := records[i]
+		if len(rec) < 2 {
+			continue
+		}
+		switch rec[0] {
+		case '1', '2', 'u':
+			// The path is the last field and may itself contain spaces
+			fields := 9
+			switch rec[0] {
+			case '2':
+				fields = 10
+			case 'u':
+				fields = 11
+			}
+			parts := strings.SplitN(rec, " ", fields)
+			if len(parts) < fields {
+				continue
+			}
+			fc := FileChange{
+				Status: strings.ReplaceAll(parts[1], ".", " "),
+				Path:   parts[fields-1],
+			}
+			if rec[0] == '2' && i+1 < len(records) {
+				i++
+				fc.OldPath = records[i]
+			}
+			changes = append(changes, fc)
+		case '?':
+			changes = append(changes, FileChange{Status: "??", Path: rec[2:]})
+		case '!':
+			changes = append(changes, FileChange{Status: "!!", Path: rec[2:]})
+		}
+	}
+	return changes
+}
+
+// ParsePorcelainOutput parses the output of `git status --porcelain` (v1)
+// into FileChanges. Prefer ParsePorcelainV2: v1 quotes unusual paths and
+// splits renames on " -> ", which is ambiguous.
 func ParsePorcelainOutput(out string) []FileChange {
 	if strings.TrimSpace(out) == "" {
 		return nil
@@ -76,10 +155,10 @@ func ParsePorcelainOutput(out string) []FileChange {
 		// Handle renames: "R  old -> new"
 		if strings.HasPrefix(status, "R") && strings.Contains(path, " -> ") {
 			parts := strings.SplitN(path, " -> ", 2)
-			fc.OldPath = parts[0]
-			fc.Path = parts[1]
+			fc.OldPath = unquotePath(parts[0])
+			fc.Path = unquotePath(parts[1])
 		} else {
-			fc.Path = path
+			fc.Path = unquotePath(path)
 		}
 
 		changes = append(changes, fc)
@@ -89,11 +168,45 @@ func ParsePorcelainOutput(out string) []FileChange {
 }
 
 // UnpushedCountIn returns the number of commits ahead of the upstream.
-func UnpushedCountIn(dir string) int {
+// hasUpstream is false when the branch has no upstream (never pushed, or
+// its remote branch is gone); n is then 0 and says nothing about what's
+// been pushed.
+func UnpushedCountIn(dir string) (n int, hasUpstream bool) {
+	if _, err := RunIn(dir, "rev-parse", "--abbrev-ref", "@{upstream}"); err != nil {
+		return 0, false
+	}
 	out, err := RunIn(dir, "rev-list", "--count", "@{upstream}..HEAD")
+	if err != nil {
+		return 0, true
+	}
+	n, _ = strconv.Atoi(strings.TrimSpace(out))
+	return n, true
+}
+
+// UnpublishedCountIn returns the number of commits on HEAD that no
+// remote-tracking branch contains, i.e. commits that exist only locally.
+// Unlike UnpushedCountIn it needs no upstream, so it's the measure of what
+// deleting a never-pushed branch would lose.
+func UnpublishedCountIn(dir string) int {
+	out, err := RunIn(dir, "rev-list", "--count", "HEAD", "--not", "--remotes")
 	if err != nil {
 		return 0
 	}
 	n, _ := strconv.Atoi(strings.TrimSpace(out))
 	return n
 }
+
+// unquotePath undoes git's C-style path quoting: paths with control
+// characters, quotes, backslashes, or (with core.quotePath) non-ASCII bytes
+// are wrapped in double quotes with backslash and octal escapes, e.g.
+// "tab\there" or "caf\303\251". Go's string-literal syntax is a superset,
+// so strconv.Unquote decodes it. Unquoted paths are returned as-is.
+func unquotePath(p string) string {
+	if len(p) < 2 || p[0] != '"' || p[len(p)-1] != '"' {
+		return p
+	}
+	if unq, err := strconv.Unquote(p); err == nil {
+		return unq
+	}
+	return p
+}
@@ -1,6 +1,9 @@
 package git
 
 import (
+	"os"
+	"os/exec"
+	"path/filepath"
 	"testing"
 )
 
@@ -74,3 +77,123 @@ func TestParsePorcelainOutput(t *testing.T) {
 		}
 	})
 }
+
+func TestUnpublishedCountIn(t *testing.T) {
+	dir := t.TempDir()
+	git := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=t", "GIT_AUTHOR_EMAIL=t@t",
+			"GIT_COMMITTER_NAME=t", "GIT_COMMITTER_EMAIL=t@t")
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Skipf("git %v failed: %v\n%s", args, err, out)
+		}
+	}
+	git("init", "-q")
+	git("commit", "-q", "--allow-empty", "-m", "one")
+	git("update-ref", "refs/remotes/origin/main", "HEAD")
+	if n := UnpublishedCountIn(dir); n != 0 {
+		t.Errorf("UnpublishedCountIn() = %d with HEAD on a remote ref, want 0", n)
+	}
+
+	git("commit", "-q", "--allow-empty", "-m", "two")
+	git("commit", "-q", "--allow-empty", "-m", "three")
+	if n := UnpublishedCountIn(dir); n != 2 {
+		t.Errorf("UnpublishedCountIn() = %d, want 2", n)
+	}
+}
+
+func TestParsePorcelainV2(t *testing.T) {
+	out := "1 .M N... 100644 100644 100644 abc abc src/main.go\x00" +
+		"1 A. N... 000000 100644 100644 000 def dir with space/new.go\x00" +
+		"2 R. N... 100644 100644 100644 abc abc R100 new -> name.go\x00old.go\x00" +
+		"u UU N... 100644 100644 100644 100644 a b c conflict.go\x00" +
+		"? untracked.txt\x00"
+
+	got := ParsePorcelainV2(out)
+	want := []FileChange{
+		{Status: " M", Path: "src/main.go"},
+		{Status: "A ", Path: "dir with space/new.go"},
+		{Status: "R ", Path: "new -> name.go", OldPath: "old.go"},
+		{Status: "UU", Path: "conflict.go"},
+		{Status: "??", Path: "untracked.txt"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d changes, want %d: %+v", len(got), len(want), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("change %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+
+	if c := got[0]; c.Staged() != ' ' || c.Unstaged() != 'M' {
+		t.Errorf("%q: Staged=%q Unstaged=%q, want ' ' and 'M'", c.Status, c.Staged(), c.Unstaged())
+	}
+	if c := got[1]; c.Staged() != 'A' || c.Unstaged() != ' ' {
+		t.Errorf("%q: Staged=%q Unstaged=%q, want 'A' and ' '", c.Status, c.Staged(), c.Unstaged())
+	}
+	if !got[2].IsRename() || got[0].IsRename() {
+		t.Error("IsRename() wrong for rename/non-rename")
+	}
+	if !got[4].IsUntracked() {
+		t.Error("IsUntracked() = false for ?? entry")
+	}
+
+	if got := ParsePorcelainV2(""); got != nil {
+		t.Errorf("empty output: got %v, want nil", got)
+	}
+}
+
+func TestParsePorcelainOutputQuotedPaths(t *testing.T) {
+	out := "?? \"tab\\there.txt\"\n" +
+		" M \"caf\\303\\251.md\"\n" +
+		"R  \"old\\\"q\\\".go\" -> plain.go\n"
+
+	got := ParsePorcelainOutput(out)
+	want := []FileChange{
+		{Status: "??", Path: "tab\there.txt"},
+		{Status: " M", Path: "café.md"},
+		{Status: "R ", Path: "plain.go", OldPath: `old"q".go`},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d changes, want %d: %+v", len(got), len(want), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("change %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestStatusPorcelainInUnusualPaths(t *testing.T) {
+	dir := t.TempDir()
+	if err := exec.Command("git", "init", "-q", dir).Run(); err != nil {
+		t.Skipf("git init failed: %v", err)
+	}
+	names := []string{"tab\there.txt", "café.md", " leading space.txt", "arrow -> name.txt"}
+	for _, name := range names {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("x"), 0644); err != nil {
+			t.Skipf("cannot create %q on this filesystem: %v", name, err)
+		}
+	}
+
+	changes, err := StatusPorcelainIn(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := make(map[string]bool)
+	for _, c := range changes {
+		if !c.IsUntracked() {
+			t.Errorf("%q: status %q, want ??", c.Path, c.Status)
+		}
+		got[c.Path] = true
+	}
+	for _, name := range names {
+		if !got[name] {
+			t.Errorf("missing %q in %+v", name, changes)
+		}
+	}
+}
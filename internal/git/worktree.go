@@ -9,8 +9,17 @@ import (
 
 // Worktree represents a git worktree entry.
 type Worktree struct {
-	Path   string
-	Branch string
+	Path       string
+	Branch     string
+	Head       string // commit SHA checked out in the worktree
+	Locked     bool   // git worktree lock; git won't prune, move, or remove it
+	LockReason string // from git worktree lock --reason, if one was given
+	Bare       bool   // the bare repository itself; nothing is checked out
+	Detached   bool   // HEAD is detached, so Branch is empty
+	// Prunable is set when git worktree prune would remove the entry,
+	// usually because its directory is gone; PrunableReason says why.
+	Prunable       bool
+	PrunableReason string
 }
 
 // ListWorktrees returns all worktrees from `git worktree list --porcelain`.
@@ -31,10 +40,22 @@ func ParseWorktreeList(out string) []Worktree {
 		switch {
 		case strings.HasPrefix(line, "worktree "):
 			current = Worktree{Path: strings.TrimPrefix(line, "worktree ")}
+		case strings.HasPrefix(line, "HEAD "):
+			current.Head = strings.TrimPrefix(line, "HEAD ")
 		case strings.HasPrefix(line, "branch "):
 			ref := strings.TrimPrefix(line, "branch ")
 			// "refs/heads/feature" → "feature"
 			current.Branch = strings.TrimPrefix(ref, "refs/heads/")
+		case line == "bare":
+			current.Bare = true
+		case line == "detached":
+			current.Detached = true
+		case line == "locked" || strings.HasPrefix(line, "locked "):
+			current.Locked = true
+			current.LockReason = strings.TrimPrefix(strings.TrimPrefix(line, "locked"), " ")
+		case line == "prunable" || strings.HasPrefix(line, "prunable "):
+			current.Prunable = true
+			current.PrunableReason = strings.TrimPrefix(strings.TrimPrefix(line, "prunable"), " ")
 		case line == "":
 			if current.Path != "" {
 				worktrees = append(worktrees, current)
@@ -50,7 +71,9 @@ func ParseWorktreeList(out string) []Worktree {
 	return worktrees
 }
 
-// MainWorktree returns the path of the main (first) worktree.
+// MainWorktree returns the path of the main worktree — the one that owns
+// the repository's .git directory. git lists it first, but that's not
+// relied on; see mainWorktreeOf.
 func MainWorktree() (string, error) {
 	wts, err := ListWorktrees()
 	if err != nil {
@@ -59,11 +82,45 @@ func MainWorktree() (string, error) {
 	if len(wts) == 0 {
 		return "", fmt.Errorf("no worktrees found (not a git repository?)")
 	}
-	return wts[0].Path, nil
+	commonDir, _ := CommonDir()
+	return mainWorktreeOf(wts, commonDir, isGitDir), nil
+}
+
+// mainWorktreeOf picks the main worktree out of a worktree list: the bare
+// repo's own entry in a bare clone (which has no main checkout), else the
+// one whose .git is the common git dir, else the one whose .git is a
+// directory rather than a linked worktree's "gitdir:" file (isDir reports
+// which), else the first entry.
+func mainWorktreeOf(wts []Worktree, commonDir string, isDir func(string) bool) string {
+	for _, wt := range wts {
+		if wt.Bare {
+			return wt.Path
+		}
+	}
+	if commonDir != "" {
+		for _, wt := range wts {
+			if filepath.Join(wt.Path, ".git") == filepath.Clean(commonDir) {
+				return wt.Path
+			}
+		}
+	}
+	for _, wt := range wts {
+		if isDir(filepath.Join(wt.Path, ".git")) {
+			return wt.Path
+		}
+	}
+	return wts[0].Path
+}
+
+// isGitDir reports whether path is a directory.
+func isGitDir(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
 }
 
 // ParentDir returns the parent directory of the main worktree
-// (where sibling worktrees are created).
+// (where sibling worktrees are created). In a bare clone that's the
+// directory holding repo.git or .bare.
 func ParentDir() (string, error) {
 	main, err := MainWorktree()
 	if err != nil {
@@ -99,6 +156,16 @@ func AddWorktreeFromRemote(path, localBranch, remoteBranch string) error {
 	return err
 }
 
+// AddWorktreeDetached creates a worktree with HEAD detached at ref — no
+// branch is created.
+func AddWorktreeDetached(path, ref string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	_, err := Run("worktree", "add", "--detach", path, ref)
+	return err
+}
+
 // RemoveWorktree removes a worktree (force).
 // After removal, cleans up the parent directory if it's empty.
 func RemoveWorktree(path string) error {
@@ -121,6 +188,58 @@ func MoveWorktree(oldPath, newPath string) error {
 	return err
 }
 
+// LockWorktree locks a worktree so git won't prune, move, or remove it.
+// reason may be empty.
+func LockWorktree(path, reason string) error {
+	args := []string{"worktree", "lock"}
+	if reason != "" {
+		args = append(args, "--reason", reason)
+	}
+	_, err := Run(append(args, path)...)
+	return err
+}
+
+// UnlockWorktree unlocks a worktree locked with LockWorktree.
+func UnlockWorktree(path string) error {
+	_, err := Run("worktree", "unlock", path)
+	return err
+}
+
+// IsLocked reports whether the worktree at path is locked, and the lock
+// reason if one was given.
+func IsLocked(path string) (bool, string) {
+	wts, err := ListWorktrees()
+	if err != nil {
+		return false, ""
+	}
+	for _, wt := range wts {
+		if filepath.Clean(wt.Path) == filepath.Clean(path) {
+			return wt.Locked, wt.LockReason
+		}
+	}
+	return false, ""
+}
+
+// LinkedGitDir returns the git dir a linked worktree checkout at dir points
+// to, from the "gitdir: <path>" line in its .git file. ok is false when
+// dir has no .git file (a normal repo's .git is a directory).
+func LinkedGitDir(dir string) (gitDir string, ok bool) {
+	data, err := os.ReadFile(filepath.Join(dir, ".git"))
+	if err != nil {
+		return "", false
+	}
+	line, _, _ := strings.Cut(string(data), "\n")
+	gitDir, found := strings.CutPrefix(strings.TrimSpace(line), "gitdir:")
+	if !found {
+		return "", false
+	}
+	gitDir = strings.TrimSpace(gitDir)
+	if !filepath.IsAbs(gitDir) {
+		gitDir = filepath.Join(dir, gitDir)
+	}
+	return filepath.Clean(gitDir), true
+}
+
 // PruneWorktrees cleans up stale worktree bookkeeping.
 func PruneWorktrees() {
 	_ = RunSilent("worktree", "prune")
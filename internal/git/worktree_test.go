@@ -46,6 +46,17 @@ func TestParseWorktreeList(t *testing.T) {
 		}
 	})
 
+	t.Run("parses HEAD sha", func(t *testing.T) {
+		input := "worktree /tmp/wt\nHEAD 3f2a1b\nbranch refs/heads/main\n\n"
+		got := ParseWorktreeList(input)
+		if len(got) != 1 {
+			t.Fatalf("got %d worktrees, want 1", len(got))
+		}
+		if got[0].Head != "3f2a1b" {
+			t.Errorf("Head = %q, want %q", got[0].Head, "3f2a1b")
+		}
+	})
+
 	t.Run("no trailing newline", func(t *testing.T) {
 		// Real git output sometimes doesn't end with a blank line
 		input := "worktree /tmp/wt\nbranch refs/heads/main"
@@ -68,6 +79,60 @@ func TestParseWorktreeList(t *testing.T) {
 		if got[0].Branch != "" {
 			t.Errorf("Branch = %q, want empty for detached HEAD", got[0].Branch)
 		}
+		if !got[0].Detached {
+			t.Error("Detached = false, want true")
+		}
+		if got[0].Head != "abc1234" {
+			t.Errorf("Head = %q, want %q", got[0].Head, "abc1234")
+		}
+	})
+
+	t.Run("bare repository", func(t *testing.T) {
+		input := "worktree /home/user/project.git\nbare\n\n" +
+			"worktree /home/user/project-main\nHEAD 3f2a1b\nbranch refs/heads/main\n\n"
+		got := ParseWorktreeList(input)
+		if len(got) != 2 {
+			t.Fatalf("got %d worktrees, want 2", len(got))
+		}
+		if !got[0].Bare || got[0].Branch != "" || got[0].Head != "" {
+			t.Errorf("bare entry = %+v, want Bare with no branch or HEAD", got[0])
+		}
+		if got[1].Bare || got[1].Detached {
+			t.Errorf("second entry = %+v, want neither bare nor detached", got[1])
+		}
+	})
+
+	t.Run("locked worktrees", func(t *testing.T) {
+		input := "worktree /tmp/a\nbranch refs/heads/a\nlocked\n\n" +
+			"worktree /tmp/b\nbranch refs/heads/b\nlocked on usb drive\n\n" +
+			"worktree /tmp/c\nbranch refs/heads/c\n\n"
+		got := ParseWorktreeList(input)
+		if len(got) != 3 {
+			t.Fatalf("got %d worktrees, want 3", len(got))
+		}
+		if !got[0].Locked || got[0].LockReason != "" {
+			t.Errorf("a: Locked = %v, LockReason = %q; want true, \"\"", got[0].Locked, got[0].LockReason)
+		}
+		if !got[1].Locked || got[1].LockReason != "on usb drive" {
+			t.Errorf("b: Locked = %v, LockReason = %q; want true, %q", got[1].Locked, got[1].LockReason, "on usb drive")
+		}
+		if got[2].Locked {
+			t.Error("c: Locked = true, want false")
+		}
+	})
+
+	t.Run("prunable worktree", func(t *testing.T) {
+		input := "worktree /tmp/gone\nHEAD abc1234\nbranch refs/heads/gone\nprunable gitdir file points to non-existent location\n\n"
+		got := ParseWorktreeList(input)
+		if len(got) != 1 {
+			t.Fatalf("got %d worktrees, want 1", len(got))
+		}
+		if !got[0].Prunable || got[0].PrunableReason != "gitdir file points to non-existent location" {
+			t.Errorf("Prunable = %v, PrunableReason = %q", got[0].Prunable, got[0].PrunableReason)
+		}
+		if got[0].Locked {
+			t.Error("Locked = true, want false")
+		}
 	})
 
 	t.Run("path with spaces", func(t *testing.T) {
@@ -81,3 +146,39 @@ func TestParseWorktreeList(t *testing.T) {
 		}
 	})
 }
+
+func TestMainWorktreeOf(t *testing.T) {
+	// Linked worktree listed before the main one.
+	out := "worktree /src/wt-repo/feat\nHEAD abc\nbranch refs/heads/feat\n\n" +
+		"worktree /src/repo\nHEAD def\nbranch refs/heads/main\n\n"
+	wts := ParseWorktreeList(out)
+	noDirs := func(string) bool { return false }
+
+	t.Run("matches the common git dir", func(t *testing.T) {
+		if got := mainWorktreeOf(wts, "/src/repo/.git", noDirs); got != "/src/repo" {
+			t.Errorf("mainWorktreeOf() = %q, want /src/repo", got)
+		}
+	})
+
+	t.Run("falls back to the .git directory", func(t *testing.T) {
+		isDir := func(path string) bool { return path == "/src/repo/.git" }
+		if got := mainWorktreeOf(wts, "", isDir); got != "/src/repo" {
+			t.Errorf("mainWorktreeOf() = %q, want /src/repo", got)
+		}
+	})
+
+	t.Run("prefers a bare repo's own entry", func(t *testing.T) {
+		bare := ParseWorktreeList("worktree /src/repo/.bare\nbare\n\n" +
+			"worktree /src/repo/main\nHEAD abc\nbranch refs/heads/main\n\n")
+		isDir := func(path string) bool { return path == "/src/repo/main/.git" }
+		if got := mainWorktreeOf(bare, "/src/repo/.bare", isDir); got != "/src/repo/.bare" {
+			t.Errorf("mainWorktreeOf() = %q, want /src/repo/.bare", got)
+		}
+	})
+
+	t.Run("falls back to the first entry", func(t *testing.T) {
+		if got := mainWorktreeOf(wts, "/elsewhere/.git", noDirs); got != "/src/wt-repo/feat" {
+			t.Errorf("mainWorktreeOf() = %q, want /src/wt-repo/feat", got)
+		}
+	})
+}
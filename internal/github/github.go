@@ -2,12 +2,17 @@ package github
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os/exec"
 	"strconv"
 	"strings"
 	"sync"
+	"time"
+
+	"github.com/mvwi/wt/internal/trace"
 )
 
 var (
@@ -31,26 +36,156 @@ func ResetAvailability() {
 	ghInstalled = false
 }
 
-// runGH executes a gh command and returns stdout.
-func runGH(args ...string) (string, error) {
-	cmd := exec.Command("gh", args...)
+// ErrNotAuthenticated is returned when gh is installed but has no usable
+// credentials (not logged in, or GH_TOKEN is invalid).
+var ErrNotAuthenticated = errors.New("gh is installed but not logged in — run gh auth login")
+
+var (
+	authOnce sync.Once
+	authErr  error
+)
+
+// CheckAuth verifies gh is logged in (via `gh auth status`, which also
+// honours GH_TOKEN). Returns ErrNotAuthenticated if not. The result is
+// cached for the life of the process. Returns nil when gh isn't installed —
+// callers check IsAvailable() separately.
+func CheckAuth() error {
+	if !IsAvailable() {
+		return nil
+	}
+	authOnce.Do(func() {
+		// A timeout says nothing about auth; let the real call report it.
+		if _, err := runGH("auth", "status"); err != nil && !errors.Is(err, errTimeout) {
+			authErr = ErrNotAuthenticated
+		}
+	})
+	return authErr
+}
+
+// Timeout bounds each gh call so a hung gh fails instead of freezing wt.
+// Set from config by the cmd layer.
+var Timeout = 30 * time.Second
+
+// Logger traces every gh command run (see --verbose and --debug). Set by
+// the cmd layer; nil logs nothing.
+var Logger *trace.Logger
+
+// errTimeout is wrapped by execGH errors when a gh call exceeds Timeout.
+var errTimeout = errors.New("timed out")
+
+// runGH runs a gh command and returns stdout. Every gh call in this package
+// goes through it, so tests swap it out to serve canned output.
+var runGH = execGH
+
+// execGH executes a gh command and returns stdout.
+// Auth failures are reported as ErrNotAuthenticated.
+func execGH(args ...string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), Timeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, "gh", args...)
+	cmd.WaitDelay = time.Second
 	var stdout, stderr bytes.Buffer
 	cmd.Stdout = &stdout
 	cmd.Stderr = &stderr
-	if err := cmd.Run(); err != nil {
-		return "", fmt.Errorf("gh %s: %s", strings.Join(args, " "), strings.TrimSpace(stderr.String()))
+	call := Logger.Start("gh", "", args)
+	err := cmd.Run()
+	call.Done(err, stderr.String())
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return "", fmt.Errorf("gh %s: %w after %s", strings.Join(args, " "), errTimeout, Timeout)
+		}
+		msg := strings.TrimSpace(stderr.String())
+		if isAuthError(msg) {
+			return "", ErrNotAuthenticated
+		}
+		return "", fmt.Errorf("gh %s: %s", strings.Join(args, " "), msg)
 	}
 	return strings.TrimSpace(stdout.String()), nil
 }
 
+// MaxRetries is how many times runGHRead retries a transient failure
+// (5xx, rate limiting, network errors). Set from config by the cmd layer.
+var MaxRetries = 2
+
+// retryBaseDelay is the first backoff delay; it doubles on each retry.
+// A var so tests can shrink it.
+var retryBaseDelay = 500 * time.Millisecond
+
+// runGHRead is runGH with retries for transient failures. Only for
+// idempotent reads (pr list, pr view, GET api calls) — never use it for
+// commands that create or modify anything.
+func runGHRead(args ...string) (string, error) {
+	return withRetry(MaxRetries, func() (string, error) { return runGH(args...) })
+}
+
+// withRetry calls fn, retrying up to retries more times with exponential
+// backoff while the error looks transient.
+func withRetry(retries int, fn func() (string, error)) (string, error) {
+	delay := retryBaseDelay
+	for attempt := 0; ; attempt++ {
+		out, err := fn()
+		if err == nil || attempt >= retries || !isTransientError(err) {
+			return out, err
+		}
+		time.Sleep(delay)
+		delay *= 2
+	}
+}
+
+// isTransientError reports whether a gh failure is worth retrying: server
+// errors, rate limiting, and network blips. Auth errors never are.
+func isTransientError(err error) bool {
+	if errors.Is(err, ErrNotAuthenticated) {
+		return false
+	}
+	s := strings.ToLower(err.Error())
+	for _, marker := range []string{
+		"http 500", "http 502", "http 503", "http 504",
+		"rate limit",
+		"timeout",
+		"connection reset",
+		"connection refused",
+		"could not resolve host",
+		"no such host",
+		"unexpected eof",
+	} {
+		if strings.Contains(s, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// isAuthError reports whether gh's stderr describes a missing or rejected
+// login rather than some other failure.
+func isAuthError(stderr string) bool {
+	s := strings.ToLower(stderr)
+	for _, marker := range []string{
+		"gh auth login",
+		"not logged in",
+		"authentication required",
+		"bad credentials",
+		"http 401",
+	} {
+		if strings.Contains(s, marker) {
+			return true
+		}
+	}
+	return false
+}
+
 // PR represents a GitHub pull request.
 type PR struct {
 	Number         int              `json:"number"`
+	Title          string           `json:"title"`
 	HeadRefName    string           `json:"headRefName"`
+	HeadRefOid     string           `json:"headRefOid"`
+	URL            string           `json:"url"`
 	State          string           `json:"state"`
 	ReviewRequests []ReviewRequest  `json:"reviewRequests"`
 	LatestReviews  []Review         `json:"latestReviews"`
 	StatusChecks   []StatusCheckRun `json:"statusCheckRollup"`
+	Labels         []PRLabel        `json:"labels"`
 }
 
 // ReviewRequest represents a pending review request.
@@ -71,6 +206,33 @@ type StatusCheckRun struct {
 	Name       string `json:"name"`
 	State      string `json:"state"`      // SUCCESS, FAILURE, PENDING, etc.
 	Conclusion string `json:"conclusion"` // SUCCESS, FAILURE, SKIPPED, TIMED_OUT, CANCELLED, ""
+	DetailsURL string `json:"detailsUrl"` // check runs (GitHub Actions, apps)
+	TargetURL  string `json:"targetUrl"`  // commit statuses
+}
+
+// URL returns the link to the check's details page, or "" if it has none.
+func (c StatusCheckRun) URL() string {
+	if c.DetailsURL != "" {
+		return c.DetailsURL
+	}
+	return c.TargetURL
+}
+
+// RunID extracts the GitHub Actions run ID from a check's details URL
+// (".../actions/runs/<id>/job/<job>"). Returns "" for checks that aren't
+// Actions runs, which can't be rerun with `gh run rerun`.
+func (c StatusCheckRun) RunID() string {
+	_, rest, ok := strings.Cut(c.DetailsURL, "/actions/runs/")
+	if !ok {
+		return ""
+	}
+	id, _, _ := strings.Cut(rest, "/")
+	for _, r := range id {
+		if r < '0' || r > '9' {
+			return ""
+		}
+	}
+	return id
 }
 
 // Result classifies a CI check as "pass", "fail", or "pending".
@@ -169,18 +331,28 @@ func getCISummary(checks []StatusCheckRun) CISummary {
 	return s
 }
 
-// ListPRs fetches PRs in a given state with full review/CI data.
+// ListLimit caps how many PRs ListPRs returns per state. Set from config by
+// the cmd layer.
+var ListLimit = 50
+
+// openPRFields are the fields fetched for open PRs, including the review and
+// CI data wt list shows. Merged and closed PRs only need matching fields.
+const openPRFields = "number,title,headRefName,headRefOid,url,reviewRequests,latestReviews,statusCheckRollup"
+
+// ListPRs fetches up to ListLimit PRs in a given state, most recent first,
+// with full review/CI data for open ones. A result of exactly ListLimit PRs
+// may be truncated; see Truncated.
 func ListPRs(state string) ([]PR, error) {
 	if !IsAvailable() {
 		return nil, nil
 	}
 
-	fields := "number,headRefName"
+	fields := "number,headRefName,headRefOid,url"
 	if state == "open" {
-		fields = "number,headRefName,reviewRequests,latestReviews,statusCheckRollup"
+		fields = openPRFields
 	}
 
-	out, err := runGH("pr", "list", "--state", state, "--json", fields, "--limit", "50")
+	out, err := runGHRead("pr", "list", "--state", state, "--json", fields, "--limit", strconv.Itoa(ListLimit))
 	if err != nil {
 		return nil, err
 	}
@@ -192,6 +364,40 @@ func ListPRs(state string) ([]PR, error) {
 	return prs, nil
 }
 
+// Truncated reports whether a ListPRs result hit ListLimit, so PRs beyond
+// the cap may be missing from it.
+func Truncated(prs []PR) bool {
+	return len(prs) >= ListLimit
+}
+
+// SearchBatch is how many branches to pass ListPRsForBranches at once,
+// keeping the search query well under GitHub's length limit.
+const SearchBatch = 20
+
+// ListPRsForBranches fetches every PR whose head is one of branches, in any
+// state, with the same data ListPRs returns for open PRs, in a single gh
+// search (head:a head:b ...). State is set on each. Search can match more
+// than the exact head names, so match results with FindPRForBranch.
+func ListPRsForBranches(branches []string) ([]PR, error) {
+	if !IsAvailable() || len(branches) == 0 {
+		return nil, nil
+	}
+	terms := make([]string, len(branches))
+	for i, b := range branches {
+		terms[i] = "head:" + b
+	}
+	limit := strconv.Itoa(20 * len(branches))
+	out, err := runGHRead("pr", "list", "--search", strings.Join(terms, " "), "--state", "all", "--json", openPRFields+",state", "--limit", limit)
+	if err != nil {
+		return nil, err
+	}
+	var prs []PR
+	if err := json.Unmarshal([]byte(out), &prs); err != nil {
+		return nil, err
+	}
+	return prs, nil
+}
+
 // PRDetail is the rich PR info used by the dashboard detail panel.
 // Fetched on demand — kept separate from the lighter `PR` returned by ListPRs.
 type PRDetail struct {
@@ -210,7 +416,7 @@ func GetPRDetail(number int) (*PRDetail, error) {
 	if !IsAvailable() {
 		return nil, nil
 	}
-	out, err := runGH("pr", "view", strconv.Itoa(number),
+	out, err := runGHRead("pr", "view", strconv.Itoa(number),
 		"--json", "number,title,body,author,statusCheckRollup")
 	if err != nil {
 		return nil, err
@@ -232,13 +438,42 @@ func FindPRForBranch(prs []PR, branch string) *PR {
 	return nil
 }
 
+// FindPRForBranchOrHead is FindPRForBranch with a fallback to matching the
+// PR's head commit SHA, so a branch renamed locally after its PR was opened
+// is still recognized. An empty head disables the fallback.
+func FindPRForBranchOrHead(prs []PR, branch, head string) *PR {
+	if pr := FindPRForBranch(prs, branch); pr != nil {
+		return pr
+	}
+	if head == "" {
+		return nil
+	}
+	for i := range prs {
+		if prs[i].HeadRefOid == head {
+			return &prs[i]
+		}
+	}
+	return nil
+}
+
+// Version returns the first line of `gh --version`, e.g.
+// "gh version 2.49.0 (2024-04-30)".
+func Version() (string, error) {
+	out, err := runGH("--version")
+	if err != nil {
+		return "", err
+	}
+	first, _, _ := strings.Cut(out, "\n")
+	return first, nil
+}
+
 // GetPRForBranch fetches the PR for a specific branch.
 // Returns (nil, nil) if gh is not available or no PR exists.
 func GetPRForBranch(branch string) (*PR, error) {
 	if !IsAvailable() {
 		return nil, nil
 	}
-	out, err := runGH("pr", "list", "--head", branch, "--json", "number,state", "--limit", "1")
+	out, err := runGHRead("pr", "list", "--head", branch, "--json", "number,state,url,labels", "--limit", "1")
 	if err != nil {
 		return nil, err
 	}
@@ -252,36 +487,100 @@ func GetPRForBranch(branch string) (*PR, error) {
 	return &prs[0], nil
 }
 
+// GetPR fetches a PR by number, with the same fields as GetPRForBranch.
+// For PRs that no local branch tracks, like a `wt pr --detached` worktree.
+// A PR that doesn't exist is reported as *PRNotFoundError.
+func GetPR(number int) (*PR, error) {
+	if !IsAvailable() {
+		return nil, fmt.Errorf("gh not installed")
+	}
+	out, err := runGHRead("pr", "view", strconv.Itoa(number), "--json", "number,state,url,labels")
+	if err != nil {
+		if isPRNotFoundError(err.Error()) {
+			return nil, &PRNotFoundError{Number: number}
+		}
+		return nil, err
+	}
+	var pr PR
+	if err := json.Unmarshal([]byte(out), &pr); err != nil {
+		return nil, fmt.Errorf("unexpected gh pr view output: %w", err)
+	}
+	return &pr, nil
+}
+
 // PRInfo holds lightweight PR metadata for checkout.
 type PRInfo struct {
 	Number      int    `json:"number"`
 	Title       string `json:"title"`
 	HeadRefName string `json:"headRefName"`
+	HeadRefOid  string `json:"headRefOid"`
 	State       string `json:"state"`
+	// IsCrossRepository is true when the PR's head branch lives in a fork.
+	IsCrossRepository bool `json:"isCrossRepository"`
+	// HeadRepositoryOwner owns the repo the head branch is in: the fork's
+	// owner for a cross-repository PR.
+	HeadRepositoryOwner struct {
+		Login string `json:"login"`
+	} `json:"headRepositoryOwner"`
+}
+
+// prInfoFields is what GetPRByNumber asks gh for; keep in sync with PRInfo.
+const prInfoFields = "number,title,headRefName,headRefOid,state,isCrossRepository,headRepositoryOwner"
+
+// PRNotFoundError is returned by GetPRByNumber when the repo has no PR (or
+// issue) with that number, as opposed to gh failing to answer.
+type PRNotFoundError struct {
+	Number int
+}
+
+func (e *PRNotFoundError) Error() string {
+	return fmt.Sprintf("PR #%d not found", e.Number)
 }
 
-// GetPRByNumber fetches PR metadata by number.
+// GetPRByNumber fetches PR metadata by number. A PR that doesn't exist is
+// reported as *PRNotFoundError; anything else (gh missing, auth, network)
+// is returned as is.
 func GetPRByNumber(number int) (*PRInfo, error) {
 	if !IsAvailable() {
 		return nil, fmt.Errorf("gh not installed")
 	}
-	out, err := runGH("pr", "view", fmt.Sprintf("%d", number), "--json", "number,title,headRefName,state")
+	out, err := runGHRead("pr", "view", strconv.Itoa(number), "--json", prInfoFields)
 	if err != nil {
+		if isPRNotFoundError(err.Error()) {
+			return nil, &PRNotFoundError{Number: number}
+		}
 		return nil, err
 	}
+	return parsePRInfo(out)
+}
+
+// parsePRInfo decodes gh pr view --json output, rejecting output that
+// doesn't describe a PR.
+func parsePRInfo(out string) (*PRInfo, error) {
 	var info PRInfo
 	if err := json.Unmarshal([]byte(out), &info); err != nil {
-		return nil, err
+		return nil, fmt.Errorf("unexpected gh pr view output: %w", err)
+	}
+	if info.Number == 0 || info.HeadRefName == "" {
+		return nil, fmt.Errorf("unexpected gh pr view output: missing number or head branch")
 	}
 	return &info, nil
 }
 
+// isPRNotFoundError reports whether a gh pr view failure means the number
+// doesn't name a PR, rather than that the lookup itself failed.
+func isPRNotFoundError(msg string) bool {
+	s := strings.ToLower(msg)
+	return strings.Contains(s, "could not resolve to a pullrequest") ||
+		strings.Contains(s, "could not resolve to an issue or pull request")
+}
+
 // RepoSlug returns the "owner/repo" string.
 func RepoSlug() (string, error) {
 	if !IsAvailable() {
 		return "", fmt.Errorf("gh not installed")
 	}
-	return runGH("repo", "view", "--json", "nameWithOwner", "-q", ".nameWithOwner")
+	return runGHRead("repo", "view", "--json", "nameWithOwner", "-q", ".nameWithOwner")
 }
 
 // PRLabel represents a label on a PR.
@@ -297,6 +596,35 @@ type PRDetails struct {
 	BaseRefName string    `json:"baseRefName"`
 	IsDraft     bool      `json:"isDraft"`
 	Labels      []PRLabel `json:"labels"`
+	// Reviewers and assignees, so a recreated PR can ask the same people
+	ReviewRequests []ReviewRequest `json:"reviewRequests"`
+	LatestReviews  []Review        `json:"latestReviews"`
+	Assignees      []PRAssignee    `json:"assignees"`
+}
+
+// PRAssignee represents a user assigned to a PR.
+type PRAssignee struct {
+	Login string `json:"login"`
+}
+
+// Reviewers returns everyone asked to review, or who has reviewed, the PR,
+// deduplicated. Team review requests have no login and are left out.
+func (d *PRDetails) Reviewers() []string {
+	seen := make(map[string]bool)
+	var logins []string
+	add := func(login string) {
+		if login != "" && !seen[login] {
+			seen[login] = true
+			logins = append(logins, login)
+		}
+	}
+	for _, r := range d.ReviewRequests {
+		add(r.Login)
+	}
+	for _, r := range d.LatestReviews {
+		add(r.Author.Login)
+	}
+	return logins
 }
 
 // GetPRDetails fetches full details for the open PR on a branch.
@@ -304,8 +632,8 @@ func GetPRDetails(branch string) (*PRDetails, error) {
 	if !IsAvailable() {
 		return nil, nil
 	}
-	out, err := runGH("pr", "list", "--head", branch, "--state", "open",
-		"--json", "number,title,body,baseRefName,isDraft,labels", "--limit", "1")
+	out, err := runGHRead("pr", "list", "--head", branch, "--state", "open",
+		"--json", "number,title,body,baseRefName,isDraft,labels,reviewRequests,latestReviews,assignees", "--limit", "1")
 	if err != nil {
 		return nil, err
 	}
@@ -435,7 +763,7 @@ func GetWatchStatus(branch string) (*WatchStatus, error) {
 	}
 
 	fields := "number,title,state,headRefName,mergeStateStatus,mergeable,reviewDecision,reviewRequests,latestReviews,statusCheckRollup"
-	out, err := runGH("pr", "view", branch, "--json", fields)
+	out, err := runGHRead("pr", "view", branch, "--json", fields)
 	if err != nil {
 		return nil, err
 	}
@@ -454,14 +782,24 @@ type repoSettings struct {
 	AllowRebaseMerge bool `json:"allow_rebase_merge"`
 }
 
+// DefaultHost is the public GitHub host, which gh targets unless told otherwise.
+const DefaultHost = "github.com"
+
 // GetDefaultMergeMethod returns the preferred merge method for the repo.
 // Picks the first enabled method in order: squash → merge → rebase.
-func GetDefaultMergeMethod() (string, error) {
+// host is the repo's GitHub host (from the git remote); `gh api` doesn't infer
+// it from the slug, so GitHub Enterprise repos need it passed explicitly.
+// Empty means DefaultHost.
+func GetDefaultMergeMethod(host string) (string, error) {
 	slug, err := RepoSlug()
 	if err != nil {
 		return "", err
 	}
-	out, err := runGH("api", "repos/"+slug)
+	args := []string{"api", "repos/" + slug}
+	if host != "" && host != DefaultHost {
+		args = append(args, "--hostname", host)
+	}
+	out, err := runGHRead(args...)
 	if err != nil {
 		return "", err
 	}
@@ -487,6 +825,84 @@ func MergePR(prNumber int, method string) error {
 	return err
 }
 
+// CommentPR posts a comment on a pull request.
+func CommentPR(number int, body string) error {
+	_, err := runGH("pr", "comment", strconv.Itoa(number), "--body", body)
+	return err
+}
+
+// EditPRLabels adds and removes labels on a pull request in one call.
+func EditPRLabels(number int, add, remove []string) error {
+	args := []string{"pr", "edit", strconv.Itoa(number)}
+	for _, l := range add {
+		args = append(args, "--add-label", l)
+	}
+	for _, l := range remove {
+		args = append(args, "--remove-label", l)
+	}
+	_, err := runGH(args...)
+	return err
+}
+
+// ReopenPR reopens a closed pull request.
+func ReopenPR(number int) error {
+	_, err := runGH("pr", "reopen", strconv.Itoa(number))
+	return err
+}
+
+// EditPRBase changes the branch a pull request merges into.
+func EditPRBase(number int, base string) error {
+	_, err := runGH("pr", "edit", strconv.Itoa(number), "--base", base)
+	return err
+}
+
+// AssignPR requests reviews from reviewers and adds assignees on a pull
+// request in one call.
+func AssignPR(number int, reviewers, assignees []string) error {
+	args := []string{"pr", "edit", strconv.Itoa(number)}
+	for _, r := range reviewers {
+		args = append(args, "--add-reviewer", r)
+	}
+	for _, a := range assignees {
+		args = append(args, "--add-assignee", a)
+	}
+	_, err := runGH(args...)
+	return err
+}
+
+// RerunFailedJobs re-runs the failed jobs of a GitHub Actions workflow run.
+func RerunFailedJobs(runID string) error {
+	_, err := runGH("run", "rerun", runID, "--failed")
+	return err
+}
+
+// Review actions accepted by ReviewPR (gh pr review flags).
+const (
+	ReviewApprove        = "approve"
+	ReviewRequestChanges = "request-changes"
+)
+
+// ReviewPR submits a review on a pull request. action is ReviewApprove or
+// ReviewRequestChanges; body is optional for approvals.
+func ReviewPR(number int, action, body string) error {
+	args := []string{"pr", "review", strconv.Itoa(number), "--" + action}
+	if body != "" {
+		args = append(args, "--body", body)
+	}
+	_, err := runGH(args...)
+	return err
+}
+
+// CurrentUser returns the login gh is authenticated as on host
+// (empty host means DefaultHost).
+func CurrentUser(host string) (string, error) {
+	args := []string{"api", "user", "-q", ".login"}
+	if host != "" && host != DefaultHost {
+		args = append(args, "--hostname", host)
+	}
+	return runGHRead(args...)
+}
+
 // CreatePR creates a new pull request and returns the PR URL.
 func CreatePR(head, base, title, body string, draft bool, labels []string) (string, error) {
 	if !IsAvailable() {
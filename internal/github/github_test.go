@@ -1,6 +1,53 @@
 package github
 
-import "testing"
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// stubGH makes gh look installed and serves every gh call from respond
+// instead of running it, for the rest of the test. It returns the args of
+// each call made so far.
+func stubGH(t *testing.T, respond func(args []string) (string, error)) func() [][]string {
+	t.Helper()
+	var calls [][]string
+	origRun := runGH
+	runGH = func(args ...string) (string, error) {
+		calls = append(calls, args)
+		return respond(args)
+	}
+	ghOnce.Do(func() {})
+	ghInstalled = true
+	t.Cleanup(func() {
+		runGH = origRun
+		ResetAvailability()
+	})
+	return func() [][]string { return calls }
+}
+
+// fixture returns the contents of testdata/name, canned gh --json output.
+func fixture(t *testing.T, name string) string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+// flagValue returns the value after flag in args, or "".
+func flagValue(args []string, flag string) string {
+	if i := slices.Index(args, flag); i >= 0 && i+1 < len(args) {
+		return args[i+1]
+	}
+	return ""
+}
 
 func review(login, state string) Review {
 	return Review{
@@ -132,3 +179,403 @@ func TestPRGetCISummary(t *testing.T) {
 		t.Errorf("Pending = %d, want 1", s.Pending)
 	}
 }
+
+func TestStatusCheckRunID(t *testing.T) {
+	tests := []struct {
+		url  string
+		want string
+	}{
+		{"https://github.com/mvwi/wt/actions/runs/123456/job/789", "123456"},
+		{"https://github.com/mvwi/wt/actions/runs/123456", "123456"},
+		{"https://ci.example.com/build/42", ""},
+		{"https://github.com/mvwi/wt/actions/runs/latest/job/1", ""},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := (StatusCheckRun{DetailsURL: tt.url}).RunID(); got != tt.want {
+			t.Errorf("RunID(%q) = %q, want %q", tt.url, got, tt.want)
+		}
+	}
+}
+
+func TestFindPRForBranchOrHead(t *testing.T) {
+	prs := []PR{
+		{Number: 1, HeadRefName: "michael/old-name", HeadRefOid: "aaa"},
+		{Number: 2, HeadRefName: "michael/other", HeadRefOid: "bbb"},
+	}
+
+	if pr := FindPRForBranchOrHead(prs, "michael/other", "aaa"); pr == nil || pr.Number != 2 {
+		t.Errorf("branch name match should win, got %+v", pr)
+	}
+	if pr := FindPRForBranchOrHead(prs, "michael/new-name", "aaa"); pr == nil || pr.Number != 1 {
+		t.Errorf("renamed branch should match by head SHA, got %+v", pr)
+	}
+	if pr := FindPRForBranchOrHead(prs, "michael/new-name", ""); pr != nil {
+		t.Errorf("empty head should disable fallback, got %+v", pr)
+	}
+}
+
+func TestIsAuthError(t *testing.T) {
+	tests := []struct {
+		stderr string
+		want   bool
+	}{
+		{"To get started with GitHub CLI, please run:  gh auth login", true},
+		{"You are not logged into any GitHub hosts. To log in, run: gh auth login", true},
+		{"HTTP 401: Bad credentials (https://api.github.com/graphql)", true},
+		{"no pull requests found for branch \"feature\"", false},
+		{"HTTP 502: Bad Gateway", false},
+	}
+	for _, tt := range tests {
+		if got := isAuthError(tt.stderr); got != tt.want {
+			t.Errorf("isAuthError(%q) = %v, want %v", tt.stderr, got, tt.want)
+		}
+	}
+}
+
+func TestCheckAuth(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{"logged in", nil, nil},
+		{"not logged in", errors.New("gh auth status: You are not logged into any GitHub hosts"), ErrNotAuthenticated},
+		{"timed out", fmt.Errorf("gh auth status: %w after 30s", errTimeout), nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := stubGH(t, func([]string) (string, error) { return "", tt.err })
+			authOnce, authErr = sync.Once{}, nil
+			t.Cleanup(func() { authOnce, authErr = sync.Once{}, nil })
+
+			if got := CheckAuth(); got != tt.want {
+				t.Errorf("CheckAuth() = %v, want %v", got, tt.want)
+			}
+			CheckAuth()
+			if got := calls(); len(got) != 1 || !slices.Equal(got[0], []string{"auth", "status"}) {
+				t.Errorf("gh calls = %v, want one gh auth status", got)
+			}
+		})
+	}
+}
+
+func TestWithRetry(t *testing.T) {
+	orig := retryBaseDelay
+	retryBaseDelay = 0
+	t.Cleanup(func() { retryBaseDelay = orig })
+	transient := fmt.Errorf("gh pr list: HTTP 502: Bad Gateway")
+
+	t.Run("retries transient failures", func(t *testing.T) {
+		calls := 0
+		out, err := withRetry(2, func() (string, error) {
+			calls++
+			if calls < 3 {
+				return "", transient
+			}
+			return "ok", nil
+		})
+		if err != nil || out != "ok" || calls != 3 {
+			t.Errorf("got (%q, %v) after %d calls, want (ok, nil) after 3", out, err, calls)
+		}
+	})
+
+	t.Run("gives up after retries", func(t *testing.T) {
+		calls := 0
+		_, err := withRetry(2, func() (string, error) { calls++; return "", transient })
+		if err == nil || calls != 3 {
+			t.Errorf("got err=%v after %d calls, want error after 3", err, calls)
+		}
+	})
+
+	t.Run("does not retry permanent failures", func(t *testing.T) {
+		for _, e := range []error{ErrNotAuthenticated, fmt.Errorf("no pull requests found")} {
+			calls := 0
+			_, _ = withRetry(2, func() (string, error) { calls++; return "", e })
+			if calls != 1 {
+				t.Errorf("%v: %d calls, want 1", e, calls)
+			}
+		}
+	})
+}
+
+func TestPRDetailsReviewers(t *testing.T) {
+	d := PRDetails{
+		// A team request has no login
+		ReviewRequests: []ReviewRequest{{Login: "alice"}, {}, {Login: "bob"}},
+		LatestReviews:  []Review{review("bob", "APPROVED"), review("carol", "COMMENTED")},
+	}
+	got := d.Reviewers()
+	want := []string{"alice", "bob", "carol"}
+	if !slices.Equal(got, want) {
+		t.Errorf("Reviewers() = %v, want %v", got, want)
+	}
+}
+
+func TestParsePRInfo(t *testing.T) {
+	tests := []struct {
+		fixture string
+		want    PRInfo
+	}{
+		{
+			fixture: "pr_view.json",
+			want: PRInfo{Number: 123, Title: "Add sidebar card", HeadRefName: "mvwi/sidebar-card",
+				HeadRefOid: "3f2c1ab9e4d8c7b6a5f4e3d2c1b0a9f8e7d6c5b4", State: "OPEN"},
+		},
+		{
+			fixture: "pr_view_fork.json",
+			want: PRInfo{Number: 456, Title: "Fix typo in README", HeadRefName: "main",
+				HeadRefOid: "9a8b7c6d5e4f3a2b1c0d9e8f7a6b5c4d3e2f1a0b", State: "MERGED", IsCrossRepository: true},
+		},
+	}
+	tests[0].want.HeadRepositoryOwner.Login = "mvwi"
+	tests[1].want.HeadRepositoryOwner.Login = "contributor"
+
+	for _, tt := range tests {
+		got, err := parsePRInfo(fixture(t, tt.fixture))
+		if err != nil {
+			t.Errorf("%s: %v", tt.fixture, err)
+			continue
+		}
+		if *got != tt.want {
+			t.Errorf("%s: got %+v, want %+v", tt.fixture, *got, tt.want)
+		}
+	}
+
+	for _, out := range []string{"", "{}", `{"number":1}`, "not json"} {
+		if _, err := parsePRInfo(out); err == nil {
+			t.Errorf("parsePRInfo(%q) succeeded, want error", out)
+		}
+	}
+}
+
+func TestIsPRNotFoundError(t *testing.T) {
+	tests := []struct {
+		msg  string
+		want bool
+	}{
+		{"gh pr view 99999 --json number: GraphQL: Could not resolve to a PullRequest with the number of 99999. (repository.pullRequest)", true},
+		{"gh pr view 7 --json number: GraphQL: Could not resolve to an issue or pull request with the number of 7.", true},
+		{"gh pr view 1 --json number: HTTP 502: Bad Gateway", false},
+		{"gh pr view 1 --json number: error connecting to api.github.com", false},
+		{"gh pr view 1 --json number: could not resolve host: api.github.com", false},
+	}
+	for _, tt := range tests {
+		if got := isPRNotFoundError(tt.msg); got != tt.want {
+			t.Errorf("isPRNotFoundError(%q) = %v, want %v", tt.msg, got, tt.want)
+		}
+	}
+}
+
+func TestListPRs(t *testing.T) {
+	tests := []struct {
+		state   string
+		fixture string
+		fields  string
+	}{
+		{state: "open", fixture: "pr_list_open.json", fields: openPRFields},
+		{state: "merged", fixture: "pr_list_merged.json", fields: "number,headRefName,headRefOid,url"},
+		{state: "closed", fixture: "pr_list_merged.json", fields: "number,headRefName,headRefOid,url"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.state, func(t *testing.T) {
+			calls := stubGH(t, func([]string) (string, error) { return fixture(t, tt.fixture), nil })
+			prs, err := ListPRs(tt.state)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(calls()) != 1 {
+				t.Fatalf("made %d gh calls, want 1", len(calls()))
+			}
+			args := calls()[0]
+			if got := flagValue(args, "--state"); got != tt.state {
+				t.Errorf("--state %q, want %q", got, tt.state)
+			}
+			if got := flagValue(args, "--json"); got != tt.fields {
+				t.Errorf("--json %q, want %q", got, tt.fields)
+			}
+			if got := flagValue(args, "--limit"); got != fmt.Sprint(ListLimit) {
+				t.Errorf("--limit %q, want %d", got, ListLimit)
+			}
+			if len(prs) == 0 || prs[0].Number == 0 || prs[0].HeadRefName == "" || prs[0].URL == "" {
+				t.Errorf("got %+v, want PRs with number, branch, and URL", prs)
+			}
+		})
+	}
+}
+
+func TestListPRsOpenDecodesReviewsAndChecks(t *testing.T) {
+	stubGH(t, func([]string) (string, error) { return fixture(t, "pr_list_open.json"), nil })
+	prs, err := ListPRs("open")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(prs) != 2 {
+		t.Fatalf("got %d PRs, want 2", len(prs))
+	}
+
+	pr := prs[0]
+	if pr.Number != 123 || pr.HeadRefName != "mvwi/sidebar-card" || pr.Title != "Add sidebar card" {
+		t.Errorf("got %+v", pr)
+	}
+	// The team request has no login
+	if got := pr.GetReviewSummary(); got != (ReviewSummary{Approved: 1, Pending: 1}) {
+		t.Errorf("GetReviewSummary() = %+v, want 1 approved, 1 pending", got)
+	}
+	if len(pr.StatusChecks) != 2 {
+		t.Fatalf("got %d status checks, want 2", len(pr.StatusChecks))
+	}
+	if c := pr.StatusChecks[0]; c.Result() != "pass" || c.RunID() != "111" {
+		t.Errorf("check run: Result() = %q, RunID() = %q; want pass, 111", c.Result(), c.RunID())
+	}
+	if c := pr.StatusChecks[1]; c.Result() != "pending" || c.URL() != "https://preview.example.com/123" {
+		t.Errorf("status context: Result() = %q, URL() = %q", c.Result(), c.URL())
+	}
+
+	if empty := prs[1]; len(empty.StatusChecks) != 0 || empty.GetCISummary().Total != 0 {
+		t.Errorf("PR without checks decoded as %+v", empty)
+	}
+}
+
+func TestListPRsErrors(t *testing.T) {
+	stubGH(t, func([]string) (string, error) { return "", fmt.Errorf("gh pr list: no git remotes found") })
+	if _, err := ListPRs("open"); err == nil {
+		t.Error("ListPRs succeeded when gh failed")
+	}
+
+	stubGH(t, func([]string) (string, error) { return "not json", nil })
+	if _, err := ListPRs("open"); err == nil {
+		t.Error("ListPRs succeeded on malformed output")
+	}
+
+	ResetAvailability()
+	ghOnce.Do(func() {})
+	if prs, err := ListPRs("open"); prs != nil || err != nil {
+		t.Errorf("without gh: got (%v, %v), want (nil, nil)", prs, err)
+	}
+}
+
+func TestGetWatchStatus(t *testing.T) {
+	calls := stubGH(t, func([]string) (string, error) { return fixture(t, "pr_view_watch.json"), nil })
+	ws, err := GetWatchStatus("mvwi/sidebar-card")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if args := calls()[0]; !slices.Equal(args[:3], []string{"pr", "view", "mvwi/sidebar-card"}) {
+		t.Errorf("ran gh %v", args)
+	}
+
+	if ws.Number != 123 || ws.State != "OPEN" || ws.MergeStateStatus != "BLOCKED" ||
+		ws.Mergeable != "MERGEABLE" || ws.ReviewDecision != "CHANGES_REQUESTED" {
+		t.Errorf("got %+v", ws)
+	}
+	if got := ws.FailedCheckNames(); !slices.Equal(got, []string{"lint"}) {
+		t.Errorf("FailedCheckNames() = %v, want [lint]", got)
+	}
+	pass, fail, pending := ws.ChecksByStatus()
+	if len(pass) != 1 || len(fail) != 1 || len(pending) != 1 {
+		t.Errorf("ChecksByStatus() = %d/%d/%d, want 1/1/1", len(pass), len(fail), len(pending))
+	}
+	want := []ReviewItem{{Login: "alice", State: "changes_requested"}, {Login: "bob", State: "pending"}}
+	if got := ws.ReviewItems(); !slices.Equal(got, want) {
+		t.Errorf("ReviewItems() = %v, want %v", got, want)
+	}
+}
+
+func TestGetPRDetails(t *testing.T) {
+	stubGH(t, func([]string) (string, error) { return fixture(t, "pr_list_details.json"), nil })
+	d, err := GetPRDetails("mvwi/sidebar-card")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d == nil || d.Number != 123 || d.BaseRefName != "main" || !d.IsDraft || d.Body == "" {
+		t.Fatalf("got %+v", d)
+	}
+	if len(d.Labels) != 1 || d.Labels[0].Name != "ui" {
+		t.Errorf("Labels = %+v, want [ui]", d.Labels)
+	}
+	// alice only commented, but was still asked to review
+	if got := d.Reviewers(); !slices.Equal(got, []string{"bob", "alice"}) {
+		t.Errorf("Reviewers() = %v, want [bob alice]", got)
+	}
+
+	stubGH(t, func([]string) (string, error) { return "[]", nil })
+	if d, err := GetPRDetails("no-pr"); d != nil || err != nil {
+		t.Errorf("no open PR: got (%+v, %v), want (nil, nil)", d, err)
+	}
+}
+
+func TestGetPRByNumber(t *testing.T) {
+	calls := stubGH(t, func([]string) (string, error) { return fixture(t, "pr_view_fork.json"), nil })
+	info, err := GetPRByNumber(456)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !info.IsCrossRepository || info.HeadRepositoryOwner.Login != "contributor" {
+		t.Errorf("got %+v, want a fork PR from contributor", info)
+	}
+	if got := flagValue(calls()[0], "--json"); got != prInfoFields {
+		t.Errorf("--json %q, want %q", got, prInfoFields)
+	}
+
+	stubGH(t, func(args []string) (string, error) {
+		return "", fmt.Errorf("gh %s: GraphQL: Could not resolve to a PullRequest with the number of 999. (repository.pullRequest)", strings.Join(args, " "))
+	})
+	var notFound *PRNotFoundError
+	if _, err := GetPRByNumber(999); !errors.As(err, &notFound) || notFound.Number != 999 {
+		t.Errorf("missing PR: got %v, want *PRNotFoundError for 999", err)
+	}
+
+	stubGH(t, func([]string) (string, error) { return "", ErrNotAuthenticated })
+	if _, err := GetPRByNumber(1); !errors.Is(err, ErrNotAuthenticated) {
+		t.Errorf("auth failure: got %v, want ErrNotAuthenticated", err)
+	}
+}
+
+func TestGetPR(t *testing.T) {
+	calls := stubGH(t, func([]string) (string, error) { return fixture(t, "pr_view_labels.json"), nil })
+	pr, err := GetPR(456)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pr.Number != 456 || pr.URL != "https://github.com/mvwi/wt/pull/456" || len(pr.Labels) != 1 || pr.Labels[0].Name != "bug" {
+		t.Errorf("got %+v", pr)
+	}
+	if args := calls()[0]; args[0] != "pr" || args[1] != "view" || args[2] != "456" {
+		t.Errorf("args = %v, want pr view 456", args)
+	}
+
+	stubGH(t, func(args []string) (string, error) {
+		return "", fmt.Errorf("gh %s: GraphQL: Could not resolve to a PullRequest with the number of 999. (repository.pullRequest)", strings.Join(args, " "))
+	})
+	var notFound *PRNotFoundError
+	if _, err := GetPR(999); !errors.As(err, &notFound) {
+		t.Errorf("missing PR: got %v, want *PRNotFoundError", err)
+	}
+}
+
+func TestListPRsForBranches(t *testing.T) {
+	calls := stubGH(t, func([]string) (string, error) { return fixture(t, "pr_search_heads.json"), nil })
+	prs, err := ListPRsForBranches([]string{"mvwi/sidebar-card", "mvwi/dark-mode"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(calls()) != 1 {
+		t.Fatalf("made %d gh calls, want 1", len(calls()))
+	}
+	args := calls()[0]
+	if got, want := flagValue(args, "--search"), "head:mvwi/sidebar-card head:mvwi/dark-mode"; got != want {
+		t.Errorf("--search %q, want %q", got, want)
+	}
+	if got := flagValue(args, "--state"); got != "all" {
+		t.Errorf("--state %q, want all", got)
+	}
+	if len(prs) != 2 || prs[1].State != "MERGED" || FindPRForBranch(prs, "mvwi/dark-mode").Number != 98 {
+		t.Errorf("got %+v, want PRs 123 (open) and 98 (merged)", prs)
+	}
+
+	calls = stubGH(t, func([]string) (string, error) { return "[]", nil })
+	if prs, err := ListPRsForBranches(nil); err != nil || prs != nil || len(calls()) != 0 {
+		t.Errorf("no branches: got %v, %v after %d call(s), want no gh call", prs, err, len(calls()))
+	}
+}
@@ -0,0 +1,56 @@
+package github
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// RefKind says what a GitHub URL points at.
+type RefKind int
+
+const (
+	RefPR     RefKind = iota + 1 // .../pull/123
+	RefBranch                    // .../tree/branch-name
+)
+
+// Ref is a pull request or branch parsed from a GitHub URL.
+type Ref struct {
+	Kind   RefKind
+	Host   string // "github.com", or a GitHub Enterprise host
+	Slug   string // "owner/repo"
+	Number int    // for RefPR
+	Branch string // for RefBranch
+}
+
+// ParseRef parses a pasted GitHub URL into the PR or branch it points at:
+//
+//	https://github.com/owner/repo/pull/123[/files...] → RefPR 123
+//	https://github.com/owner/repo/tree/user/feature   → RefBranch "user/feature"
+//
+// Query strings and fragments are ignored. Anything else, including bare
+// branch names and PR numbers, reports false.
+func ParseRef(s string) (Ref, bool) {
+	u, err := url.Parse(strings.TrimSpace(s))
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		return Ref{}, false
+	}
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(parts) < 4 || parts[0] == "" || parts[1] == "" {
+		return Ref{}, false
+	}
+	ref := Ref{Host: u.Host, Slug: parts[0] + "/" + parts[1]}
+	switch parts[2] {
+	case "pull":
+		n, err := strconv.Atoi(parts[3])
+		if err != nil || n <= 0 {
+			return Ref{}, false
+		}
+		ref.Kind, ref.Number = RefPR, n
+	case "tree":
+		ref.Kind, ref.Branch = RefBranch, strings.Join(parts[3:], "/")
+	default:
+		return Ref{}, false
+	}
+	return ref, true
+}
@@ -0,0 +1,27 @@
+package github
+
+import "testing"
+
+func TestParseRef(t *testing.T) {
+	tests := []struct {
+		in     string
+		want   Ref
+		wantOK bool
+	}{
+		{"https://github.com/mvwi/wt/pull/123", Ref{Kind: RefPR, Host: "github.com", Slug: "mvwi/wt", Number: 123}, true},
+		{"https://github.com/mvwi/wt/pull/123/files?w=1#diff", Ref{Kind: RefPR, Host: "github.com", Slug: "mvwi/wt", Number: 123}, true},
+		{"https://github.com/mvwi/wt/tree/michael/fix-login", Ref{Kind: RefBranch, Host: "github.com", Slug: "mvwi/wt", Branch: "michael/fix-login"}, true},
+		{"https://ghe.example.com/team/app/pull/7/", Ref{Kind: RefPR, Host: "ghe.example.com", Slug: "team/app", Number: 7}, true},
+		{"https://github.com/mvwi/wt/pull/abc", Ref{}, false},
+		{"https://github.com/mvwi/wt/issues/5", Ref{}, false},
+		{"https://github.com/mvwi/wt", Ref{}, false},
+		{"michael/fix-login", Ref{}, false},
+		{"123", Ref{}, false},
+	}
+	for _, tt := range tests {
+		got, ok := ParseRef(tt.in)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("ParseRef(%q) = %+v, %v; want %+v, %v", tt.in, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
@@ -0,0 +1,159 @@
+// Package trace is wt's lightweight command logger. The cmd layer builds a
+// Logger from --verbose and --debug and injects it into the git and github
+// packages, which report every command they run through it.
+package trace
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// KeepLogs is how many debug log files are kept; older ones are deleted
+// when a new one is opened.
+const KeepLogs = 20
+
+// Logger writes command traces. A nil *Logger logs nothing, so callers
+// never need to check.
+type Logger struct {
+	// Verbose, if set, gets each command as it starts ("+ git ...") and
+	// the error and stderr of each that fails ("! git ...").
+	Verbose io.Writer
+	// File, if set, gets a timestamped record of everything: commands,
+	// exit codes, durations, stderr, and Printf'd decisions.
+	File io.Writer
+
+	mu sync.Mutex
+}
+
+// Call is one command in flight, returned by Start.
+type Call struct {
+	l     *Logger
+	cmd   string
+	start time.Time
+}
+
+// Start logs a command about to run in dir ("" for the current directory)
+// and returns a Call to report its outcome with.
+func (l *Logger) Start(tool, dir string, args []string) *Call {
+	if l == nil {
+		return nil
+	}
+	cmd := tool + " " + strings.Join(args, " ")
+	shown := cmd
+	if dir != "" {
+		shown = tool + " -C " + dir + " " + strings.Join(args, " ")
+	}
+	l.write(l.Verbose, "+ %s\n", shown)
+	l.stamp("exec %s\n", shown)
+	return &Call{l: l, cmd: cmd, start: time.Now()}
+}
+
+// Done logs the outcome of c: its exit code and duration, and on failure
+// its error and stderr.
+func (c *Call) Done(err error, stderr string) {
+	if c == nil {
+		return
+	}
+	elapsed := time.Since(c.start).Round(time.Millisecond)
+	if err == nil {
+		c.l.stamp("exit 0 (%s) %s\n", elapsed, c.cmd)
+		return
+	}
+	code := -1
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		code = exitErr.ExitCode()
+	}
+	c.l.stamp("exit %d (%s) %s: %v\n", code, elapsed, c.cmd, err)
+	c.l.write(c.l.Verbose, "! %s: %v\n", c.cmd, err)
+	for _, line := range strings.Split(strings.TrimSpace(stderr), "\n") {
+		if line != "" {
+			c.l.write(c.l.Verbose, "    %s\n", line)
+			c.l.write(c.l.File, "    %s\n", line)
+		}
+	}
+}
+
+// Printf records a decision or note in the debug log only.
+func (l *Logger) Printf(format string, args ...any) {
+	if l == nil {
+		return
+	}
+	l.stamp(format+"\n", args...)
+}
+
+func (l *Logger) stamp(format string, args ...any) {
+	if l.File != nil {
+		l.write(l.File, "%s %s", time.Now().Format("15:04:05.000"), fmt.Sprintf(format, args...))
+	}
+}
+
+func (l *Logger) write(w io.Writer, format string, args ...any) {
+	if w == nil {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	_, _ = fmt.Fprintf(w, format, args...)
+}
+
+// Dir returns ~/.config/wt/logs.
+func Dir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".config", "wt", "logs"), nil
+}
+
+// OpenFile creates a new debug log in Dir, named for the current time, and
+// deletes all but the newest KeepLogs logs.
+func OpenFile() (*os.File, error) {
+	dir, err := Dir()
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	name := fmt.Sprintf("wt-%s-%d.log", time.Now().Format("20060102-150405"), os.Getpid())
+	f, err := os.OpenFile(filepath.Join(dir, name), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	logs, _ := listLogs(dir)
+	for len(logs) > KeepLogs {
+		_ = os.Remove(logs[0])
+		logs = logs[1:]
+	}
+	return f, nil
+}
+
+// Latest returns the newest debug log, or "" if there is none.
+func Latest() string {
+	dir, err := Dir()
+	if err != nil {
+		return ""
+	}
+	logs, _ := listLogs(dir)
+	if len(logs) == 0 {
+		return ""
+	}
+	return logs[len(logs)-1]
+}
+
+// listLogs returns the debug logs in dir, oldest first. The names start
+// with a sortable timestamp, so name order is age order.
+func listLogs(dir string) ([]string, error) {
+	logs, err := filepath.Glob(filepath.Join(dir, "wt-*.log"))
+	slices.Sort(logs)
+	return logs, err
+}
@@ -0,0 +1,92 @@
+package trace
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestLogger(t *testing.T) {
+	var verbose, file bytes.Buffer
+	l := &Logger{Verbose: &verbose, File: &file}
+
+	l.Start("git", "", []string{"status"}).Done(nil, "")
+	l.Start("git", "/repo", []string{"fetch", "origin"}).Done(errors.New("exit status 128"), "fatal: no remote\n")
+	l.Printf("decided %s", "something")
+
+	wantVerbose := "+ git status\n" +
+		"+ git -C /repo fetch origin\n" +
+		"! git fetch origin: exit status 128\n" +
+		"    fatal: no remote\n"
+	if verbose.String() != wantVerbose {
+		t.Errorf("verbose log =\n%s\nwant\n%s", verbose.String(), wantVerbose)
+	}
+
+	stamp := `\d\d:\d\d:\d\d\.\d{3} `
+	for _, re := range []string{
+		`(?m)^` + stamp + `exec git status$`,
+		`(?m)^` + stamp + `exit 0 \(\d+m?s\) git status$`,
+		`(?m)^` + stamp + `exec git -C /repo fetch origin$`,
+		`(?m)^` + stamp + `exit -1 \(\d+m?s\) git fetch origin: exit status 128$`,
+		`(?m)^    fatal: no remote$`,
+		`(?m)^` + stamp + `decided something$`,
+	} {
+		if !regexp.MustCompile(re).MatchString(file.String()) {
+			t.Errorf("file log doesn't match %s:\n%s", re, file.String())
+		}
+	}
+}
+
+func TestLoggerExitCode(t *testing.T) {
+	var file bytes.Buffer
+	l := &Logger{File: &file}
+	err := exec.Command("sh", "-c", "exit 3").Run()
+	l.Start("sh", "", nil).Done(err, "")
+	if !strings.Contains(file.String(), "exit 3 ") {
+		t.Errorf("file log missing exit code 3:\n%s", file.String())
+	}
+}
+
+func TestNilLogger(t *testing.T) {
+	var l *Logger
+	l.Start("git", "", []string{"status"}).Done(errors.New("boom"), "stderr")
+	l.Printf("ignored")
+}
+
+func TestOpenFileRotates(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	dir := filepath.Join(home, ".config", "wt", "logs")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for i := range KeepLogs + 5 {
+		name := fmt.Sprintf("wt-20200101-0000%02d-1.log", i)
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	f, err := OpenFile()
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = f.Close()
+
+	logs, _ := listLogs(dir)
+	if len(logs) != KeepLogs {
+		t.Errorf("kept %d logs, want %d", len(logs), KeepLogs)
+	}
+	if got := Latest(); got != f.Name() {
+		t.Errorf("Latest() = %s, want the new log %s", got, f.Name())
+	}
+	if _, err := os.Stat(filepath.Join(dir, "wt-20200101-000000-1.log")); !os.IsNotExist(err) {
+		t.Error("oldest log was not deleted")
+	}
+}
@@ -1,14 +1,21 @@
 package ui
 
 import (
+	"errors"
+	"os"
 	"os/exec"
+	"runtime"
 	"strings"
 )
 
 // CopyToClipboard copies text to the system clipboard.
-// Supports macOS (pbcopy) and Linux (xclip, xsel).
+// Supports macOS (pbcopy), Wayland (wl-copy), X11 (xclip, xsel), and
+// Windows/WSL (clip.exe).
 func CopyToClipboard(text string) error {
 	name, args := clipboardCmd()
+	if name == "" {
+		return errors.New("no clipboard command found (install wl-copy, xclip, or xsel)")
+	}
 	cmd := exec.Command(name, args...)
 	cmd.Stdin = strings.NewReader(text)
 	return cmd.Run()
@@ -17,16 +24,42 @@ func CopyToClipboard(text string) error {
 // ClipboardAvailable reports whether a clipboard command is on PATH.
 func ClipboardAvailable() bool {
 	name, _ := clipboardCmd()
-	_, err := exec.LookPath(name)
-	return err == nil
+	return name != ""
 }
 
 func clipboardCmd() (string, []string) {
-	if _, err := exec.LookPath("pbcopy"); err == nil {
-		return "pbcopy", nil
-	}
-	if _, err := exec.LookPath("xclip"); err == nil {
-		return "xclip", []string{"-selection", "clipboard"}
+	return clipboardCmdFor(runtime.GOOS, os.Getenv, func(name string) bool {
+		_, err := exec.LookPath(name)
+		return err == nil
+	})
+}
+
+// clipboardCmdFor picks the clipboard command for goos, preferring the one
+// native to the running display server. Returns "" when none is installed.
+func clipboardCmdFor(goos string, getenv func(string) string, has func(string) bool) (string, []string) {
+	switch goos {
+	case "darwin":
+		if has("pbcopy") {
+			return "pbcopy", nil
+		}
+	case "windows":
+		if has("clip.exe") {
+			return "clip.exe", nil
+		}
+	default:
+		if getenv("WAYLAND_DISPLAY") != "" && has("wl-copy") {
+			return "wl-copy", nil
+		}
+		if has("xclip") {
+			return "xclip", []string{"-selection", "clipboard"}
+		}
+		if has("xsel") {
+			return "xsel", []string{"--clipboard", "--input"}
+		}
+		// WSL: the Windows clipboard is reachable through clip.exe
+		if has("clip.exe") {
+			return "clip.exe", nil
+		}
 	}
-	return "xsel", []string{"--clipboard", "--input"}
+	return "", nil
 }
@@ -0,0 +1,37 @@
+package ui
+
+import "testing"
+
+func TestClipboardCmdFor(t *testing.T) {
+	tests := []struct {
+		name      string
+		goos      string
+		env       map[string]string
+		installed []string
+		want      string
+	}{
+		{"macOS", "darwin", nil, []string{"pbcopy"}, "pbcopy"},
+		{"Windows", "windows", nil, []string{"clip.exe"}, "clip.exe"},
+		{"Wayland prefers wl-copy", "linux", map[string]string{"WAYLAND_DISPLAY": "wayland-0"}, []string{"wl-copy", "xclip"}, "wl-copy"},
+		{"X11 ignores wl-copy", "linux", nil, []string{"wl-copy", "xclip"}, "xclip"},
+		{"xsel fallback", "linux", nil, []string{"xsel"}, "xsel"},
+		{"WSL", "linux", nil, []string{"clip.exe"}, "clip.exe"},
+		{"nothing installed", "linux", nil, nil, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			has := func(name string) bool {
+				for _, n := range tt.installed {
+					if n == name {
+						return true
+					}
+				}
+				return false
+			}
+			got, _ := clipboardCmdFor(tt.goos, func(k string) string { return tt.env[k] }, has)
+			if got != tt.want {
+				t.Errorf("clipboardCmdFor() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// EditText opens $VISUAL / $EDITOR (falling back to vi) on a temp file
+// pre-filled with initial, and returns the saved contents, trimmed. Nothing
+// is stripped — markdown headings start with "#", so git-style comment lines
+// don't work here. name is used in the temp file name (e.g. "comment" →
+// wt-comment-*.md).
+func EditText(name, initial string) (string, error) {
+	f, err := os.CreateTemp("", "wt-"+name+"-*.md")
+	if err != nil {
+		return "", err
+	}
+	path := f.Name()
+	defer os.Remove(path) //nolint:errcheck // best-effort cleanup
+
+	if _, err := f.WriteString(initial); err != nil {
+		f.Close() //nolint:errcheck,gosec // already failing
+		return "", err
+	}
+	if err := f.Close(); err != nil {
+		return "", err
+	}
+
+	if err := EditFile(path); err != nil {
+		return "", err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// EditFile opens $VISUAL / $EDITOR (falling back to vi) on path and waits
+// for it to exit.
+func EditFile(path string) error {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = "vi"
+	}
+
+	// Through sh so EDITOR values with arguments ("code --wait") work.
+	cmd := exec.Command("sh", "-c", editor+` "$1"`, "sh", path)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("editor %q failed: %w", editor, err)
+	}
+	return nil
+}
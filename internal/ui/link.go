@@ -0,0 +1,59 @@
+package ui
+
+import (
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Hyperlinks overrides terminal detection for OSC-8 links: nil auto-detects,
+// true/false force them on/off. Set from config by the cmd layer. The
+// WT_HYPERLINKS env var (1/0) takes precedence over both.
+var Hyperlinks *bool
+
+// Link renders text as a clickable OSC-8 hyperlink to url when stdout is a
+// terminal that supports it, and as plain text otherwise.
+func Link(text, url string) string {
+	if url == "" || !linksEnabled() {
+		return text
+	}
+	return "\x1b]8;;" + url + "\x1b\\" + text + "\x1b]8;;\x1b\\"
+}
+
+// LinkPadded is Link with text left-aligned in a width-column field. Use it
+// instead of %-*s, which would count the escape sequence toward the width.
+func LinkPadded(text, url string, width int) string {
+	return PadRight(Link(text, url), width)
+}
+
+func linksEnabled() bool {
+	if !IsTTY() {
+		return false
+	}
+	if v, err := strconv.ParseBool(os.Getenv("WT_HYPERLINKS")); err == nil {
+		return v
+	}
+	if Hyperlinks != nil {
+		return *Hyperlinks
+	}
+	return terminalSupportsLinks(os.Getenv)
+}
+
+// terminalSupportsLinks reports whether the terminal is one known to render
+// OSC-8 links. Unknown terminals get plain text: some print the escape
+// sequence literally.
+func terminalSupportsLinks(getenv func(string) string) bool {
+	switch getenv("TERM_PROGRAM") {
+	case "iTerm.app", "WezTerm", "vscode", "ghostty", "Hyper", "Tabby":
+		return true
+	}
+	if getenv("WT_SESSION") != "" || getenv("KITTY_WINDOW_ID") != "" {
+		return true
+	}
+	// GNOME Terminal, Tilix, and other VTE terminals since 0.50
+	if v, err := strconv.Atoi(getenv("VTE_VERSION")); err == nil && v >= 5000 {
+		return true
+	}
+	term := getenv("TERM")
+	return strings.Contains(term, "kitty") || strings.Contains(term, "alacritty") || strings.Contains(term, "foot")
+}
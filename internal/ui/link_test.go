@@ -0,0 +1,40 @@
+package ui
+
+import "testing"
+
+func TestTerminalSupportsLinks(t *testing.T) {
+	tests := []struct {
+		name string
+		env  map[string]string
+		want bool
+	}{
+		{"iTerm", map[string]string{"TERM_PROGRAM": "iTerm.app"}, true},
+		{"Windows Terminal", map[string]string{"WT_SESSION": "abc"}, true},
+		{"new VTE", map[string]string{"VTE_VERSION": "6003"}, true},
+		{"old VTE", map[string]string{"VTE_VERSION": "4803"}, false},
+		{"kitty TERM", map[string]string{"TERM": "xterm-kitty"}, true},
+		{"Apple Terminal", map[string]string{"TERM_PROGRAM": "Apple_Terminal", "TERM": "xterm-256color"}, false},
+		{"nothing set", map[string]string{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := terminalSupportsLinks(func(k string) string { return tt.env[k] }); got != tt.want {
+				t.Errorf("terminalSupportsLinks() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLinkPlainWhenNotTTY(t *testing.T) {
+	// go test's stdout isn't a terminal, so links always degrade to text.
+	on := true
+	Hyperlinks = &on
+	defer func() { Hyperlinks = nil }()
+
+	if got := Link("#42", "https://github.com/o/r/pull/42"); got != "#42" {
+		t.Errorf("Link() = %q, want plain text", got)
+	}
+	if got := LinkPadded("#42", "https://github.com/o/r/pull/42", 6); got != "#42   " {
+		t.Errorf("LinkPadded() = %q, want %q", got, "#42   ")
+	}
+}
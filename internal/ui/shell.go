@@ -0,0 +1,75 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
+)
+
+// The shell wrapper from `wt init-shell` passes a temp file via WT_CD_FILE.
+// Commands append directives to it, one per line, and the wrapper applies
+// them in order after the binary exits:
+//
+//	cd <path>         change directory
+//	env KEY=VALUE     export an environment variable
+//	echo <message>    print a message from the parent shell
+//
+// A line with no recognized prefix is treated as a bare path to cd into,
+// which is what older versions of wt wrote.
+// wt itself only writes cd today; env and echo are for richer integrations.
+const directiveCd = "cd"
+
+// writeShellDirective appends a directive to WT_CD_FILE. Returns false when
+// there is no shell wrapper (WT_CD_FILE unset) or the write fails, so callers
+// can fall back to printing a manual hint.
+func writeShellDirective(kind, arg string) bool {
+	cdFile := os.Getenv("WT_CD_FILE")
+	if cdFile == "" {
+		return false
+	}
+	// Directives are line-based; a newline in arg would inject a second one.
+	if strings.ContainsAny(arg, "\r\n") {
+		return false
+	}
+	f, err := os.OpenFile(cdFile, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600)
+	if err != nil {
+		return false
+	}
+	defer f.Close() //nolint:errcheck // write error is checked below
+	_, err = fmt.Fprintf(f, "%s %s\n", kind, arg)
+	return err == nil
+}
+
+// PrintCdHint tells the shell wrapper to cd into the given path.
+// When WT_CD_FILE is set (by the shell wrapper), writes a cd directive to it.
+// Otherwise prints a hint so the user knows to cd manually.
+func PrintCdHint(path string) {
+	if writeShellDirective(directiveCd, path) {
+		return
+	}
+	fmt.Printf("  → cd %s\n", shellQuote(path))
+}
+
+// shellQuote quotes a path for the user's platform shell so the printed
+// `cd` hint can be pasted as-is.
+func shellQuote(s string) string {
+	return shellQuoteFor(runtime.GOOS, s)
+}
+
+// shellQuoteFor quotes s for the shell family used on goos.
+// POSIX shells get single quotes with '\'' escaping. Windows (cmd and
+// PowerShell) gets double quotes, which both accept for `cd` — Windows paths
+// can't contain '"' so no inner escaping is needed.
+func shellQuoteFor(goos, s string) string {
+	if goos == "windows" {
+		if !strings.ContainsAny(s, " \t&()[]{}^=;!'+,`~$%") {
+			return s
+		}
+		return `"` + s + `"`
+	}
+	if !strings.ContainsAny(s, " \t\n'\"\\$`|&;<>(){}[]!*?~") {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,62 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestShellQuoteFor(t *testing.T) {
+	tests := []struct {
+		name  string
+		goos  string
+		input string
+		want  string
+	}{
+		{"posix plain path", "darwin", "/home/user/wt-repo/feat", "/home/user/wt-repo/feat"},
+		{"posix space", "linux", "/home/user/my repo", "'/home/user/my repo'"},
+		{"posix single quote", "linux", "/tmp/it's", `'/tmp/it'\''s'`},
+		{"windows plain path", "windows", `C:\src\wt-repo\feat`, `C:\src\wt-repo\feat`},
+		{"windows space", "windows", `C:\Users\Jane Doe\src`, `"C:\Users\Jane Doe\src"`},
+		{"windows single quote not escaped", "windows", `C:\src\it's`, `"C:\src\it's"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := shellQuoteFor(tt.goos, tt.input)
+			if got != tt.want {
+				t.Errorf("shellQuoteFor(%q, %q) = %q, want %q", tt.goos, tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPrintCdHint(t *testing.T) {
+	t.Run("appends cd directives in order", func(t *testing.T) {
+		cdFile := filepath.Join(t.TempDir(), "cd")
+		t.Setenv("WT_CD_FILE", cdFile)
+
+		PrintCdHint("/home/user/repo")
+		PrintCdHint("/home/user/my repo")
+
+		data, err := os.ReadFile(cdFile)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := "cd /home/user/repo\ncd /home/user/my repo\n"
+		if string(data) != want {
+			t.Errorf("WT_CD_FILE = %q, want %q", data, want)
+		}
+	})
+
+	t.Run("rejects newlines", func(t *testing.T) {
+		cdFile := filepath.Join(t.TempDir(), "cd")
+		t.Setenv("WT_CD_FILE", cdFile)
+		if writeShellDirective(directiveCd, "/tmp\ncd /etc") {
+			t.Error("writeShellDirective with newline = true, want false")
+		}
+		if _, err := os.Stat(cdFile); err == nil {
+			t.Error("WT_CD_FILE was written despite rejected directive")
+		}
+	})
+}
@@ -12,6 +12,9 @@ type Spinner struct {
 	done    chan struct{}
 	stopped chan struct{}
 	once    sync.Once
+
+	mu        sync.Mutex
+	formatted string
 }
 
 // Wave frames: 3 bars that rise and fall in sequence.
@@ -40,8 +43,9 @@ func NewSpinnerRich(message string) *Spinner {
 
 func newSpinner(formatted string) *Spinner {
 	s := &Spinner{
-		done:    make(chan struct{}),
-		stopped: make(chan struct{}),
+		done:      make(chan struct{}),
+		stopped:   make(chan struct{}),
+		formatted: formatted,
 	}
 
 	tty := IsTTY()
@@ -66,7 +70,10 @@ func newSpinner(formatted string) *Spinner {
 				fmt.Print("\r\033[K")
 				return
 			case <-ticker.C:
-				fmt.Printf("\r\033[K  %s %s", Cyan(waveFrames[frame]), formatted)
+				s.mu.Lock()
+				msg := s.formatted
+				s.mu.Unlock()
+				fmt.Printf("\r\033[K  %s %s", Cyan(waveFrames[frame]), msg)
 				frame = (frame + 1) % len(waveFrames)
 			}
 		}
@@ -75,6 +82,15 @@ func newSpinner(formatted string) *Spinner {
 	return s
 }
 
+// SetMessage replaces the spinner's message (auto-dimmed) from the next
+// frame on. Non-TTY output already printed the original message once and
+// ignores updates, so progress doesn't flood logs.
+func (s *Spinner) SetMessage(message string) {
+	s.mu.Lock()
+	s.formatted = Dim(message)
+	s.mu.Unlock()
+}
+
 // Stop clears the spinner line and joins the goroutine.
 // Safe to call multiple times.
 func (s *Spinner) Stop() {
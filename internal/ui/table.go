@@ -0,0 +1,108 @@
+package ui
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// Table renders rows of cells as aligned columns. Each column is as wide as
+// its widest cell or header, measured with Width so colored and linked cells
+// line up. Cells may be pre-colored; the table adds no color of its own
+// except for dimming the header and its rule.
+type Table struct {
+	// Indent is the number of columns before the first cell. A row's mark
+	// (see Mark) is drawn in this space.
+	Indent int
+
+	header []string
+	rows   []tableRow
+}
+
+type tableRow struct {
+	mark  string
+	cells []string
+}
+
+// NewTable returns a table with the given column headers, indented by two
+// columns like the rest of wt's output. An empty header is allowed, e.g. for
+// a trailing column of extras.
+func NewTable(header ...string) *Table {
+	return &Table{Indent: 2, header: header}
+}
+
+// Row appends a row. Missing trailing cells are left blank.
+func (t *Table) Row(cells ...string) {
+	t.rows = append(t.rows, tableRow{cells: cells})
+}
+
+// Mark sets a marker (e.g. Current) drawn in the indent of the last row.
+func (t *Table) Mark(mark string) {
+	if len(t.rows) > 0 {
+		t.rows[len(t.rows)-1].mark = mark
+	}
+}
+
+// Print renders the table to stdout.
+func (t *Table) Print() {
+	t.Render(os.Stdout)
+}
+
+// Render writes the header, a rule under it, and the rows to w. Columns are
+// separated by one space, and trailing blank cells are dropped so lines
+// don't end in padding.
+func (t *Table) Render(w io.Writer) {
+	widths := make([]int, len(t.header))
+	for i, h := range t.header {
+		widths[i] = Width(h)
+	}
+	for _, r := range t.rows {
+		for i, c := range r.cells {
+			if i >= len(widths) {
+				widths = append(widths, 0)
+			}
+			widths[i] = max(widths[i], Width(c))
+		}
+	}
+
+	indent := strings.Repeat(" ", t.Indent)
+	// The rule spans the named columns, not a trailing unnamed one.
+	ruleWidth := -1
+	for i, h := range t.header {
+		if h != "" {
+			ruleWidth = 0
+			for _, wd := range widths[:i+1] {
+				ruleWidth += wd + 1
+			}
+		}
+	}
+	_, _ = fmt.Fprintln(w, Dim(indent+t.line(t.header, widths)))
+	_, _ = fmt.Fprintln(w, Dim(indent+strings.Repeat("─", max(ruleWidth-1, 0))))
+
+	for _, r := range t.rows {
+		prefix := indent
+		if r.mark != "" {
+			prefix = PadRight(r.mark, t.Indent)
+		}
+		_, _ = fmt.Fprintln(w, prefix+t.line(r.cells, widths))
+	}
+}
+
+// line joins cells padded to widths, stopping after the last non-blank cell.
+func (t *Table) line(cells []string, widths []int) string {
+	last := len(cells) - 1
+	for last >= 0 && cells[last] == "" {
+		last--
+	}
+	var b strings.Builder
+	for i := 0; i <= last; i++ {
+		if i == last {
+			b.WriteString(cells[i])
+			break
+		}
+		b.WriteString(PadRight(cells[i], widths[i]))
+		b.WriteByte(' ')
+	}
+	return b.String()
+}
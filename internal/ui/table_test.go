@@ -0,0 +1,40 @@
+package ui
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/fatih/color"
+)
+
+func TestTableRender(t *testing.T) {
+	noColor := color.NoColor
+	color.NoColor = true
+	t.Cleanup(func() { color.NoColor = noColor })
+
+	tbl := NewTable("Branch", "Sync", "PR", "")
+	tbl.Row("sidebar", "\x1b[33m⇣2\x1b[0m", "#12", "not pushed")
+	tbl.Mark("●")
+	tbl.Row("a-much-longer-branch", "✓")
+	tbl.Row("api", "", "#7")
+
+	var buf bytes.Buffer
+	tbl.Render(&buf)
+	got := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	want := []string{
+		"  Branch               Sync PR",
+		"  ─────────────────────────────",
+		"● sidebar              \x1b[33m⇣2\x1b[0m   #12 not pushed",
+		"  a-much-longer-branch ✓",
+		"  api                       #7",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d lines, want %d:\n%s", len(got), len(want), buf.String())
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("line %d = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
@@ -4,7 +4,9 @@ import (
 	"bufio"
 	"fmt"
 	"os"
+	"regexp"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/fatih/color"
 	"github.com/mattn/go-isatty"
@@ -57,6 +59,30 @@ func Truncate(s string, max int) string {
 	return string(runes[:max-1]) + "…"
 }
 
+// ansiRe matches the escape sequences wt emits: SGR color codes and OSC-8
+// hyperlink open/close.
+var ansiRe = regexp.MustCompile(`\x1b\[[0-9;]*m|\x1b\]8;[^\x1b]*\x1b\\`)
+
+// StripANSI removes color and hyperlink escape sequences from s.
+func StripANSI(s string) string {
+	return ansiRe.ReplaceAllString(s, "")
+}
+
+// Width returns the number of terminal columns s occupies, ignoring escape
+// sequences. Like Truncate, it counts one column per rune.
+func Width(s string) int {
+	return utf8.RuneCountInString(StripANSI(s))
+}
+
+// PadRight left-aligns s in a width-column field. Use it instead of %-*s for
+// colored or linked text, where fmt would count the escape codes as width.
+func PadRight(s string, width int) string {
+	if pad := width - Width(s); pad > 0 {
+		return s + strings.Repeat(" ", pad)
+	}
+	return s
+}
+
 // Confirm prompts the user with a y/n question.
 // defaultYes: if true, pressing Enter means yes (Y/n); if false, means no (y/N).
 // When stdin is not a terminal, returns defaultYes without prompting.
@@ -96,27 +122,6 @@ func Confirm(message string, defaultYes bool) bool {
 	}
 }
 
-// PrintCdHint tells the shell wrapper to cd into the given path.
-// When WT_CD_FILE is set (by the shell wrapper), writes the path to that file.
-// Otherwise prints a hint so the user knows to cd manually.
-func PrintCdHint(path string) {
-	if cdFile := os.Getenv("WT_CD_FILE"); cdFile != "" {
-		if err := os.WriteFile(cdFile, []byte(path), 0600); err != nil {
-			fmt.Printf("  → cd %s\n", shellQuote(path))
-		}
-		return
-	}
-	fmt.Printf("  → cd %s\n", shellQuote(path))
-}
-
-// shellQuote wraps a path in single quotes if it contains shell-special characters.
-func shellQuote(s string) string {
-	if !strings.ContainsAny(s, " \t\n'\"\\$`|&;<>(){}[]!*?~") {
-		return s
-	}
-	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
-}
-
 // Error prints an error message with ✗ prefix to stderr.
 func Error(format string, args ...any) {
 	fmt.Fprintf(os.Stderr, Red("✗")+" "+format+"\n", args...)
@@ -142,6 +147,20 @@ func IsTTY() bool {
 	return isatty.IsTerminal(os.Stdout.Fd()) || isatty.IsCygwinTerminal(os.Stdout.Fd())
 }
 
+// IsStderrTTY reports whether stderr is connected to a terminal.
+func IsStderrTTY() bool {
+	return isatty.IsTerminal(os.Stderr.Fd()) || isatty.IsCygwinTerminal(os.Stderr.Fd())
+}
+
+// ForceColor enables colored output even when stdout isn't a terminal, for
+// output that's rendered by another program (e.g. an fzf preview pane).
+// NO_COLOR is still respected.
+func ForceColor() {
+	if os.Getenv("NO_COLOR") == "" {
+		color.NoColor = false
+	}
+}
+
 // ClearLines moves the cursor up n lines and clears each one.
 // Used by wt watch to redraw the live status table in-place.
 // No-op when stdout is not a terminal.
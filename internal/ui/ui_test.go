@@ -27,3 +27,39 @@ func TestTruncate(t *testing.T) {
 		})
 	}
 }
+
+func TestPadRight(t *testing.T) {
+	const (
+		green = "\x1b[32m"
+		reset = "\x1b[0m"
+		link  = "\x1b]8;;https://example.com/pull/1\x1b\\#1\x1b]8;;\x1b\\"
+	)
+	tests := []struct {
+		name  string
+		input string
+		width int
+		want  string
+	}{
+		{"plain", "ab", 4, "ab  "},
+		{"colored", green + "ab" + reset, 4, green + "ab" + reset + "  "},
+		{"multibyte glyphs", green + "⇣3⇡1" + reset, 6, green + "⇣3⇡1" + reset + "  "},
+		{"hyperlink", link, 4, link + "  "},
+		{"already wide enough", green + "abcdef" + reset, 4, green + "abcdef" + reset},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := PadRight(tt.input, tt.width); got != tt.want {
+				t.Errorf("PadRight(%q, %d) = %q, want %q", tt.input, tt.width, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWidth(t *testing.T) {
+	if got := Width("\x1b[2m" + Dash + "\x1b[0m"); got != 1 {
+		t.Errorf("Width(dim dash) = %d, want 1", got)
+	}
+	if got := StripANSI("\x1b[1;31mred\x1b[0m"); got != "red" {
+		t.Errorf("StripANSI = %q, want %q", got, "red")
+	}
+}
@@ -2,10 +2,10 @@ package update
 
 import (
 	"encoding/json"
-	"fmt"
 	"net/http"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -78,16 +78,20 @@ func CheckInBackground() {
 			return
 		}
 
-		// Atomic write via temp file + rename to prevent corruption
-		// if the process exits mid-write.
-		tmpPath := path + ".tmp"
-		if err := os.WriteFile(tmpPath, []byte(release.TagName), 0644); err != nil {
-			return
-		}
-		_ = os.Rename(tmpPath, path)
+		writeCache(path, release.TagName)
 	}()
 }
 
+// writeCache records tag as the latest version. Atomic write via temp file
+// + rename to prevent corruption if the process exits mid-write.
+func writeCache(path, tag string) {
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, []byte(tag), 0644); err != nil {
+		return
+	}
+	_ = os.Rename(tmpPath, path)
+}
+
 // UpdateInfo holds version comparison results for the caller to format.
 type UpdateInfo struct {
 	Current string
@@ -127,35 +131,86 @@ func GetUpdateInfo(currentVersion string) *UpdateInfo {
 	return &UpdateInfo{Current: currentVersion, Latest: latest}
 }
 
-// isNewer returns true if version a is newer than b (simple semver comparison).
+// isNewer returns true if version a is newer than b, using semver
+// precedence: major.minor.patch numerically, then a pre-release sorts before
+// its release ("1.2.0-rc1" < "1.2.0"), with dot-separated pre-release
+// identifiers compared left to right. Build metadata ("+...") is ignored.
 func isNewer(a, b string) bool {
-	aParts := parseSemver(a)
-	bParts := parseSemver(b)
+	return compareSemver(a, b) > 0
+}
+
+// compareSemver returns -1, 0, or 1 as a is older than, equal to, or newer than b.
+func compareSemver(a, b string) int {
+	aCore, aPre := splitSemver(a)
+	bCore, bPre := splitSemver(b)
 	for i := 0; i < 3; i++ {
-		if aParts[i] > bParts[i] {
-			return true
+		if aCore[i] != bCore[i] {
+			return cmpInt(aCore[i], bCore[i])
 		}
-		if aParts[i] < bParts[i] {
-			return false
+	}
+
+	// A release outranks any pre-release of the same version.
+	switch {
+	case aPre == "" && bPre == "":
+		return 0
+	case aPre == "":
+		return 1
+	case bPre == "":
+		return -1
+	}
+
+	aIDs := strings.Split(aPre, ".")
+	bIDs := strings.Split(bPre, ".")
+	for i := 0; i < len(aIDs) && i < len(bIDs); i++ {
+		if c := comparePrereleaseID(aIDs[i], bIDs[i]); c != 0 {
+			return c
 		}
 	}
-	return false
+	// All shared identifiers equal: the longer set wins ("rc.1.1" > "rc.1").
+	return cmpInt(len(aIDs), len(bIDs))
+}
+
+// comparePrereleaseID compares one pre-release identifier. Numeric
+// identifiers compare numerically and sort before alphanumeric ones, which
+// compare lexically.
+func comparePrereleaseID(a, b string) int {
+	aNum, aErr := strconv.Atoi(a)
+	bNum, bErr := strconv.Atoi(b)
+	switch {
+	case aErr == nil && bErr == nil:
+		return cmpInt(aNum, bNum)
+	case aErr == nil:
+		return -1
+	case bErr == nil:
+		return 1
+	}
+	return strings.Compare(a, b)
 }
 
-// parseSemver extracts major.minor.patch as ints. Returns [0,0,0] on failure.
-func parseSemver(v string) [3]int {
-	var parts [3]int
+func cmpInt(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// splitSemver extracts major.minor.patch as ints plus the pre-release
+// string, dropping any "v" prefix and "+build" metadata. Unparseable numeric
+// parts are 0.
+func splitSemver(v string) (core [3]int, pre string) {
 	v = strings.TrimPrefix(v, "v")
+	if idx := strings.Index(v, "+"); idx >= 0 {
+		v = v[:idx]
+	}
+	if idx := strings.Index(v, "-"); idx >= 0 {
+		v, pre = v[:idx], v[idx+1:]
+	}
 	segments := strings.SplitN(v, ".", 3)
 	for i, s := range segments {
-		if i >= 3 {
-			break
-		}
-		// Strip pre-release suffix (e.g., "1-rc1" → "1")
-		if idx := strings.IndexAny(s, "-+"); idx >= 0 {
-			s = s[:idx]
-		}
-		_, _ = fmt.Sscanf(s, "%d", &parts[i])
+		core[i], _ = strconv.Atoi(s)
 	}
-	return parts
+	return core, pre
 }
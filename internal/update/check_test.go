@@ -0,0 +1,62 @@
+package update
+
+import "testing"
+
+func TestIsNewer(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		// Core version
+		{"1.2.1", "1.2.0", true},
+		{"1.10.0", "1.9.0", true},
+		{"1.2.0", "1.2.0", false},
+		{"v2.0.0", "1.9.9", true},
+
+		// rc → stable
+		{"1.2.0", "1.2.0-rc1", true},
+		{"1.2.0-rc1", "1.2.0", false},
+
+		// stable → next rc
+		{"1.3.0-rc1", "1.2.0", true},
+		{"1.2.0", "1.3.0-rc1", false},
+
+		// Pre-release identifiers
+		{"1.2.0-rc.2", "1.2.0-rc.1", true},
+		{"1.2.0-rc.10", "1.2.0-rc.2", true},
+		{"1.2.0-rc.1", "1.2.0-beta.2", true},
+		{"1.2.0-alpha.1", "1.2.0-alpha", true},
+		{"1.2.0-alpha", "1.2.0-1", true},
+
+		// Build metadata ignored
+		{"1.2.0+build.5", "1.2.0+build.4", false},
+		{"1.2.0+abc", "1.2.0", false},
+		{"1.2.1+abc", "1.2.0", true},
+		{"1.2.0-rc1+abc", "1.2.0-rc1", false},
+	}
+	for _, tt := range tests {
+		if got := isNewer(tt.a, tt.b); got != tt.want {
+			t.Errorf("isNewer(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestWriteCache(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", home)
+
+	path, err := cacheFile()
+	if err != nil {
+		t.Fatal(err)
+	}
+	writeCache(path, "v9.9.9")
+
+	info := GetUpdateInfo("1.0.0")
+	if info == nil || info.Latest != "v9.9.9" {
+		t.Fatalf("GetUpdateInfo() = %+v, want latest v9.9.9", info)
+	}
+	if GetUpdateInfo("v9.9.9") != nil {
+		t.Error("GetUpdateInfo() reported an update for the latest version")
+	}
+}
@@ -0,0 +1,84 @@
+package update
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const releasesPageURL = "https://github.com/mvwi/wt/releases/latest"
+
+// UpgradeHint returns the command (or link) the user should use to upgrade,
+// based on how the running binary appears to have been installed.
+func UpgradeHint() string {
+	exe, err := executablePath()
+	if err != nil {
+		return releasesPageURL
+	}
+	return upgradeHintFor(exe, goBinDirs())
+}
+
+// IsHomebrewInstall reports whether the running binary lives under a
+// Homebrew prefix, in which case brew owns upgrades.
+func IsHomebrewInstall() bool {
+	exe, err := executablePath()
+	return err == nil && isHomebrewPath(exe)
+}
+
+// executablePath returns the running binary's path with symlinks resolved
+// (Homebrew links bin/wt into the Cellar/Caskroom).
+func executablePath() (string, error) {
+	exe, err := os.Executable()
+	if err != nil {
+		return "", err
+	}
+	if resolved, err := filepath.EvalSymlinks(exe); err == nil {
+		exe = resolved
+	}
+	return exe, nil
+}
+
+// upgradeHintFor picks the hint for a binary at exe, given the directories
+// `go install` writes to. Detection is by location only: build info can't
+// tell `go install` apart from a release build (both stamp a module version).
+func upgradeHintFor(exe string, goBins []string) string {
+	if isHomebrewPath(exe) {
+		return "brew upgrade wt"
+	}
+	dir := filepath.Dir(exe)
+	for _, bin := range goBins {
+		if bin != "" && dir == filepath.Clean(bin) {
+			return "go install github.com/mvwi/wt@latest"
+		}
+	}
+	return "wt upgrade"
+}
+
+func isHomebrewPath(exe string) bool {
+	slash := filepath.ToSlash(exe)
+	for _, marker := range []string{"/Cellar/", "/Caskroom/", "/homebrew/", "/linuxbrew/"} {
+		if strings.Contains(slash, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// goBinDirs returns where `go install` puts binaries: $GOBIN, else
+// $GOPATH/bin (each GOPATH entry), else ~/go/bin.
+func goBinDirs() []string {
+	if gobin := os.Getenv("GOBIN"); gobin != "" {
+		return []string{gobin}
+	}
+	if gopath := os.Getenv("GOPATH"); gopath != "" {
+		var dirs []string
+		for _, p := range filepath.SplitList(gopath) {
+			dirs = append(dirs, filepath.Join(p, "bin"))
+		}
+		return dirs
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		return []string{filepath.Join(home, "go", "bin")}
+	}
+	return nil
+}
@@ -0,0 +1,27 @@
+package update
+
+import "testing"
+
+func TestUpgradeHintFor(t *testing.T) {
+	goBins := []string{"/home/me/go/bin"}
+
+	tests := []struct {
+		name string
+		exe  string
+		want string
+	}{
+		{"homebrew apple silicon", "/opt/homebrew/Cellar/wt/1.2.0/bin/wt", "brew upgrade wt"},
+		{"homebrew intel", "/usr/local/Cellar/wt/1.2.0/bin/wt", "brew upgrade wt"},
+		{"linuxbrew", "/home/linuxbrew/.linuxbrew/bin/wt", "brew upgrade wt"},
+		{"homebrew cask", "/usr/local/Caskroom/wt/1.2.0/wt", "brew upgrade wt"},
+		{"go install", "/home/me/go/bin/wt", "go install github.com/mvwi/wt@latest"},
+		{"manual download", "/usr/local/bin/wt", "wt upgrade"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := upgradeHintFor(tt.exe, goBins); got != tt.want {
+				t.Errorf("upgradeHintFor(%q) = %q, want %q", tt.exe, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,205 @@
+package update
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+)
+
+const (
+	checksumsAsset  = "checksums.txt"
+	downloadTimeout = 2 * time.Minute
+)
+
+// Release is the subset of a GitHub release that `wt upgrade` needs.
+type Release struct {
+	TagName string         `json:"tag_name"`
+	Assets  []ReleaseAsset `json:"assets"`
+}
+
+// ReleaseAsset is a downloadable file attached to a release.
+type ReleaseAsset struct {
+	Name string `json:"name"`
+	URL  string `json:"browser_download_url"`
+}
+
+// FetchLatestRelease queries the GitHub releases API for the latest release.
+// Also refreshes the update-check cache as a side effect.
+func FetchLatestRelease() (*Release, error) {
+	client := &http.Client{Timeout: downloadTimeout}
+	resp, err := client.Get(releasesURL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close() //nolint:errcheck // best-effort close
+	if resp.StatusCode != 200 {
+		return nil, fmt.Errorf("GitHub releases API returned %s", resp.Status)
+	}
+
+	var rel Release
+	if err := json.NewDecoder(resp.Body).Decode(&rel); err != nil {
+		return nil, err
+	}
+	if rel.TagName == "" {
+		return nil, fmt.Errorf("latest release has no tag")
+	}
+
+	if path, err := cacheFile(); err == nil {
+		writeCache(path, rel.TagName)
+	}
+	return &rel, nil
+}
+
+// NewerThan reports whether the release is newer than currentVersion.
+func (r *Release) NewerThan(currentVersion string) bool {
+	return isNewer(strings.TrimPrefix(r.TagName, "v"), strings.TrimPrefix(currentVersion, "v"))
+}
+
+// assetName returns the archive name goreleaser publishes for goos/goarch:
+// "wt_<version>_<os>_<arch>.tar.gz".
+func (r *Release) assetName(goos, goarch string) string {
+	return fmt.Sprintf("wt_%s_%s_%s.tar.gz", strings.TrimPrefix(r.TagName, "v"), goos, goarch)
+}
+
+func (r *Release) findAsset(name string) *ReleaseAsset {
+	for i := range r.Assets {
+		if r.Assets[i].Name == name {
+			return &r.Assets[i]
+		}
+	}
+	return nil
+}
+
+// Install downloads the release archive for the current OS/arch, verifies it
+// against the release's checksums.txt when present, and atomically replaces
+// the running binary (temp file in the same directory + rename).
+func (r *Release) Install() error {
+	name := r.assetName(runtime.GOOS, runtime.GOARCH)
+	asset := r.findAsset(name)
+	if asset == nil {
+		return fmt.Errorf("no release asset for %s/%s (%s)\n   Download manually: %s", runtime.GOOS, runtime.GOARCH, name, releasesPageURL)
+	}
+
+	archive, err := download(asset.URL)
+	if err != nil {
+		return fmt.Errorf("download %s: %w", name, err)
+	}
+
+	if sums := r.findAsset(checksumsAsset); sums != nil {
+		data, err := download(sums.URL)
+		if err != nil {
+			return fmt.Errorf("download %s: %w", checksumsAsset, err)
+		}
+		if err := verifyChecksum(archive, name, data); err != nil {
+			return err
+		}
+	}
+
+	bin, err := extractBinary(archive, "wt")
+	if err != nil {
+		return err
+	}
+
+	exe, err := executablePath()
+	if err != nil {
+		return fmt.Errorf("cannot locate running binary: %w", err)
+	}
+	return replaceFile(exe, bin)
+}
+
+func download(url string) ([]byte, error) {
+	client := &http.Client{Timeout: downloadTimeout}
+	resp, err := client.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close() //nolint:errcheck // best-effort close
+	if resp.StatusCode != 200 {
+		return nil, fmt.Errorf("HTTP %s", resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// verifyChecksum checks data's SHA-256 against the entry for name in a
+// goreleaser checksums file ("<hex>  <name>" per line).
+func verifyChecksum(data []byte, name string, checksums []byte) error {
+	var want string
+	scanner := bufio.NewScanner(bytes.NewReader(checksums))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 2 && fields[1] == name {
+			want = strings.ToLower(fields[0])
+			break
+		}
+	}
+	if want == "" {
+		return fmt.Errorf("%s has no entry for %s", checksumsAsset, name)
+	}
+
+	sum := sha256.Sum256(data)
+	if got := hex.EncodeToString(sum[:]); got != want {
+		return fmt.Errorf("checksum mismatch for %s: got %s, want %s", name, got, want)
+	}
+	return nil
+}
+
+// extractBinary returns the contents of the file called name at the top
+// level of a .tar.gz archive.
+func extractBinary(archive []byte, name string) ([]byte, error) {
+	gz, err := gzip.NewReader(bytes.NewReader(archive))
+	if err != nil {
+		return nil, fmt.Errorf("read archive: %w", err)
+	}
+	defer gz.Close() //nolint:errcheck // reading from memory
+
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("archive does not contain %s", name)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read archive: %w", err)
+		}
+		if hdr.Typeflag == tar.TypeReg && filepath.Base(hdr.Name) == name {
+			return io.ReadAll(tr)
+		}
+	}
+}
+
+// replaceFile atomically swaps path's contents for data, keeping it
+// executable. The temp file lives next to path so the rename can't cross
+// filesystems.
+func replaceFile(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".wt-upgrade-*")
+	if err != nil {
+		return fmt.Errorf("cannot write to %s: %w", filepath.Dir(path), err)
+	}
+	tmpPath := tmp.Name()
+	defer os.Remove(tmpPath) //nolint:errcheck // no-op after a successful rename
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close() //nolint:errcheck,gosec // already failing
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmpPath, 0755); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, path)
+}
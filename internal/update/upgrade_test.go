@@ -0,0 +1,104 @@
+package update
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func makeArchive(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for name, body := range files {
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0755, Size: int64(len(body)), Typeflag: tar.TypeReg}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(body)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestExtractBinary(t *testing.T) {
+	archive := makeArchive(t, map[string]string{"README.md": "docs", "wt": "binary"})
+
+	got, err := extractBinary(archive, "wt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != "binary" {
+		t.Errorf("got %q, want %q", got, "binary")
+	}
+
+	if _, err := extractBinary(archive, "missing"); err == nil {
+		t.Error("expected error for missing file")
+	}
+}
+
+func TestVerifyChecksum(t *testing.T) {
+	data := []byte("archive contents")
+	sum := sha256.Sum256(data)
+	checksums := []byte(hex.EncodeToString(sum[:]) + "  wt_1.2.0_linux_amd64.tar.gz\n" +
+		"deadbeef  wt_1.2.0_darwin_arm64.tar.gz\n")
+
+	if err := verifyChecksum(data, "wt_1.2.0_linux_amd64.tar.gz", checksums); err != nil {
+		t.Errorf("valid checksum rejected: %v", err)
+	}
+	if err := verifyChecksum(data, "wt_1.2.0_darwin_arm64.tar.gz", checksums); err == nil {
+		t.Error("mismatched checksum accepted")
+	}
+	if err := verifyChecksum(data, "wt_1.2.0_windows_amd64.tar.gz", checksums); err == nil {
+		t.Error("missing entry accepted")
+	}
+}
+
+func TestReplaceFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "wt")
+	if err := os.WriteFile(path, []byte("old"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := replaceFile(path, []byte("new")); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != "new" {
+		t.Errorf("got %q, want %q", got, "new")
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm()&0100 == 0 {
+		t.Errorf("mode = %v, want executable", info.Mode())
+	}
+	entries, _ := os.ReadDir(filepath.Dir(path))
+	if len(entries) != 1 {
+		t.Errorf("temp file left behind: %v", entries)
+	}
+}
+
+func TestReleaseAssetName(t *testing.T) {
+	r := &Release{TagName: "v1.2.0"}
+	if got := r.assetName("darwin", "arm64"); got != "wt_1.2.0_darwin_arm64.tar.gz" {
+		t.Errorf("assetName = %q", got)
+	}
+}